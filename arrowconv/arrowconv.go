@@ -0,0 +1,413 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package arrowconv convert the query results of openGemini into Apache Arrow record batches. It is a separate
+// module, so the client doesn't depend on Arrow unless the converter is imported.
+package arrowconv
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const (
+	// TimeColumn the name of time column in query result
+	TimeColumn = "time"
+	// MetadataMeasurement the schema metadata key of series name
+	MetadataMeasurement = "measurement"
+)
+
+// TagType the arrow type of tag columns
+var TagType = &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}
+
+// Converter convert series into record batches, one record batch per series, or per chunk of series when the
+// result comes from Client.QueryChunked. The time column is converted to timestamp[ns], the tags of series and the
+// columns specified by WithTagKeys are converted to dictionary columns, the types of fields are taken from
+// WithFieldKeys or inferred from values
+type Converter struct {
+	mem       memory.Allocator
+	fieldKeys map[string]map[string]string
+	tagKeys   map[string]bool
+	precision opengemini.Precision
+}
+
+type Option func(*Converter)
+
+// WithAllocator specify the allocator of arrays, default is memory.DefaultAllocator
+func WithAllocator(mem memory.Allocator) Option {
+	return func(c *Converter) {
+		c.mem = mem
+	}
+}
+
+// WithFieldKeys specify the field types returned by Client.ShowFieldKeys, it is keyed by measurement then field.
+// The json decoder can't tell integer from float, so the integer fields are inferred as float64 without it
+func WithFieldKeys(fieldKeys map[string]map[string]string) Option {
+	return func(c *Converter) {
+		c.fieldKeys = fieldKeys
+	}
+}
+
+// WithTagKeys specify the selected columns which are tags, they are converted to dictionary columns
+func WithTagKeys(keys ...string) Option {
+	return func(c *Converter) {
+		for _, key := range keys {
+			c.tagKeys[key] = true
+		}
+	}
+}
+
+// WithPrecision specify Query.Precision of the query, which is the unit of numeric time values, default is
+// nanosecond
+func WithPrecision(precision opengemini.Precision) Option {
+	return func(c *Converter) {
+		c.precision = precision
+	}
+}
+
+func NewConverter(options ...Option) *Converter {
+	c := &Converter{mem: memory.DefaultAllocator, tagKeys: make(map[string]bool)}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Records convert the series of all statements in result, the caller must release the record batches
+func (c *Converter) Records(result *opengemini.QueryResult) iter.Seq2[arrow.RecordBatch, error] {
+	return func(yield func(arrow.RecordBatch, error) bool) {
+		c.yieldResult(result, yield)
+	}
+}
+
+// Stream convert the chunks yielded by Client.QueryChunked as they arrive, so the whole result is never
+// materialized, the caller must release the record batches
+func (c *Converter) Stream(chunks iter.Seq2[*opengemini.QueryResult, error]) iter.Seq2[arrow.RecordBatch, error] {
+	return func(yield func(arrow.RecordBatch, error) bool) {
+		for chunk, err := range chunks {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !c.yieldResult(chunk, yield) {
+				return
+			}
+		}
+	}
+}
+
+func (c *Converter) yieldResult(result *opengemini.QueryResult, yield func(arrow.RecordBatch, error) bool) bool {
+	if result == nil {
+		return true
+	}
+	for _, statement := range result.Results {
+		for _, series := range statement.Series {
+			record, err := c.SeriesRecord(series)
+			if err != nil {
+				yield(nil, err)
+				return false
+			}
+			if !yield(record, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SeriesRecord convert a series into record batch, the schema metadata `measurement` holds the series name
+func (c *Converter) SeriesRecord(series *opengemini.Series) (arrow.RecordBatch, error) {
+	fields := make([]arrow.Field, 0, len(series.Columns)+len(series.Tags))
+	columns := make([]arrow.Array, 0, cap(fields))
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+
+	rows := len(series.Values)
+	for i, name := range series.Columns {
+		dataType := c.columnType(series, i)
+		column, err := c.buildColumn(dataType, rows, func(row int) any {
+			if i >= len(series.Values[row]) {
+				return nil
+			}
+			return series.Values[row][i]
+		})
+		if err != nil {
+			return nil, fmt.Errorf("column %s of series %s: %w", name, series.Name, err)
+		}
+		fields = append(fields, arrow.Field{Name: name, Type: dataType, Nullable: name != TimeColumn})
+		columns = append(columns, column)
+	}
+
+	tagKeys := make([]string, 0, len(series.Tags))
+	for key := range series.Tags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+	for _, key := range tagKeys {
+		value := series.Tags[key]
+		column, err := c.buildColumn(TagType, rows, func(int) any { return value })
+		if err != nil {
+			return nil, fmt.Errorf("tag %s of series %s: %w", key, series.Name, err)
+		}
+		fields = append(fields, arrow.Field{Name: key, Type: TagType, Nullable: true})
+		columns = append(columns, column)
+	}
+
+	metadata := arrow.NewMetadata([]string{MetadataMeasurement}, []string{series.Name})
+	schema := arrow.NewSchema(fields, &metadata)
+	return array.NewRecordBatch(schema, columns, int64(rows)), nil
+}
+
+// columnType resolve the arrow type of column by name, field keys and values in order
+func (c *Converter) columnType(series *opengemini.Series, index int) arrow.DataType {
+	name := series.Columns[index]
+	if name == TimeColumn {
+		return arrow.FixedWidthTypes.Timestamp_ns
+	}
+	if c.tagKeys[name] {
+		return TagType
+	}
+	if fieldType, ok := c.fieldKeys[series.Name][name]; ok {
+		if dataType := fieldKeyType(fieldType); dataType != nil {
+			return dataType
+		}
+	}
+	return inferType(series.Values, index)
+}
+
+// fieldKeyType map the field type of `SHOW FIELD KEYS` to arrow type
+func fieldKeyType(fieldType string) arrow.DataType {
+	switch fieldType {
+	case "float":
+		return arrow.PrimitiveTypes.Float64
+	case "integer":
+		return arrow.PrimitiveTypes.Int64
+	case "unsigned":
+		return arrow.PrimitiveTypes.Uint64
+	case "string":
+		return arrow.BinaryTypes.String
+	case "boolean":
+		return arrow.FixedWidthTypes.Boolean
+	default:
+		return nil
+	}
+}
+
+// inferType infer the type from all values of column, the numbers decoded from json are float64 or json.Number, the
+// json.Number is integer when it parses as int64, so that the integers beyond 2^53 of Client.QueryChunked are kept,
+// msgpack keeps integers, the column of mixed kinds falls back to string
+func inferType(values opengemini.SeriesValues, index int) arrow.DataType {
+	var hasFloat, hasInt, hasUint, hasBool, hasString bool
+	for _, row := range values {
+		if index >= len(row) {
+			continue
+		}
+		switch v := row[index].(type) {
+		case nil:
+		case json.Number:
+			if _, err := v.Int64(); err == nil {
+				hasInt = true
+			} else {
+				hasFloat = true
+			}
+		case float64, float32:
+			hasFloat = true
+		case int, int8, int16, int32, int64:
+			hasInt = true
+		case uint, uint8, uint16, uint32, uint64:
+			hasUint = true
+		case bool:
+			hasBool = true
+		default:
+			hasString = true
+		}
+	}
+	numeric := hasFloat || hasInt || hasUint
+	switch {
+	case hasString || (hasBool && numeric):
+		return arrow.BinaryTypes.String
+	case hasBool:
+		return arrow.FixedWidthTypes.Boolean
+	case hasFloat || (hasInt && hasUint):
+		return arrow.PrimitiveTypes.Float64
+	case hasInt:
+		return arrow.PrimitiveTypes.Int64
+	case hasUint:
+		return arrow.PrimitiveTypes.Uint64
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func (c *Converter) buildColumn(dataType arrow.DataType, rows int, value func(row int) any) (arrow.Array, error) {
+	builder := array.NewBuilder(c.mem, dataType)
+	defer builder.Release()
+	builder.Reserve(rows)
+
+	for row := 0; row < rows; row++ {
+		v := value(row)
+		if v == nil {
+			builder.AppendNull()
+			continue
+		}
+		if err := c.appendValue(builder, v); err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+	}
+	return builder.NewArray(), nil
+}
+
+func (c *Converter) appendValue(builder array.Builder, v any) error {
+	switch b := builder.(type) {
+	case *array.TimestampBuilder:
+		t, err := c.timestamp(v)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Timestamp(t))
+	case *array.Float64Builder:
+		n, ok := toFloat64(v)
+		if !ok {
+			return fmt.Errorf("unexpected float value %v", v)
+		}
+		b.Append(n)
+	case *array.Int64Builder:
+		n, ok := toInt64(v)
+		if !ok {
+			return fmt.Errorf("unexpected integer value %v", v)
+		}
+		b.Append(n)
+	case *array.Uint64Builder:
+		if n, ok := v.(uint64); ok {
+			b.Append(n)
+			return nil
+		}
+		n, ok := toInt64(v)
+		if !ok || n < 0 {
+			return fmt.Errorf("unexpected unsigned value %v", v)
+		}
+		b.Append(uint64(n))
+	case *array.BooleanBuilder:
+		value, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected boolean value %v", v)
+		}
+		b.Append(value)
+	case *array.StringBuilder:
+		b.Append(toString(v))
+	case *array.BinaryDictionaryBuilder:
+		return b.AppendString(toString(v))
+	default:
+		return fmt.Errorf("unsupported arrow type %s", builder.Type())
+	}
+	return nil
+}
+
+// timestamp return the unix nanoseconds of time value, which is RFC3339 string or number in precision
+func (c *Converter) timestamp(v any) (int64, error) {
+	if s, ok := v.(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return 0, err
+		}
+		return t.UnixNano(), nil
+	}
+	n, ok := toInt64(v)
+	if !ok {
+		return 0, fmt.Errorf("unexpected time value %v", v)
+	}
+	switch c.precision {
+	case opengemini.PrecisionMicrosecond:
+		return n * int64(time.Microsecond), nil
+	case opengemini.PrecisionMillisecond:
+		return n * int64(time.Millisecond), nil
+	case opengemini.PrecisionSecond:
+		return n * int64(time.Second), nil
+	case opengemini.PrecisionMinute:
+		return n * int64(time.Minute), nil
+	case opengemini.PrecisionHour:
+		return n * int64(time.Hour), nil
+	default:
+		return n, nil
+	}
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	if n, ok := toInt64(v); ok {
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func toInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case float32:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint:
+		return int64(n), true
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
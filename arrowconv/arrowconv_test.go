@@ -0,0 +1,180 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrowconv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+func TestSeriesRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	converter := NewConverter(WithAllocator(mem),
+		WithFieldKeys(map[string]map[string]string{"cpu": {"count": "integer"}}),
+		WithTagKeys("region"))
+	series := &opengemini.Series{
+		Name:    "cpu",
+		Tags:    map[string]string{"host": "a"},
+		Columns: []string{"time", "count", "usage", "region", "ok", "msg"},
+		Values: opengemini.SeriesValues{
+			{"2025-01-01T00:00:00Z", float64(1), 0.5, "east", true, "x"},
+			{"2025-01-01T00:00:01.5Z", float64(2), nil, "west", false, nil},
+		},
+	}
+	record, err := converter.SeriesRecord(series)
+	require.Nil(t, err)
+	defer record.Release()
+
+	require.Equal(t, int64(2), record.NumRows())
+	schema := record.Schema()
+	name, ok := schema.Metadata().GetValue(MetadataMeasurement)
+	require.True(t, ok)
+	require.Equal(t, "cpu", name)
+	require.Equal(t, []arrow.DataType{
+		arrow.FixedWidthTypes.Timestamp_ns,
+		arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Float64,
+		TagType,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.BinaryTypes.String,
+		TagType,
+	}, fieldTypes(schema))
+	require.Equal(t, "host", schema.Field(6).Name)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := record.Column(0).(*array.Timestamp)
+	require.Equal(t, arrow.Timestamp(start.UnixNano()), times.Value(0))
+	require.Equal(t, arrow.Timestamp(start.Add(1500*time.Millisecond).UnixNano()), times.Value(1))
+	require.Equal(t, []int64{1, 2}, record.Column(1).(*array.Int64).Int64Values())
+	require.True(t, record.Column(2).IsNull(1))
+	require.Equal(t, "west", record.Column(3).(*array.Dictionary).ValueStr(1))
+	require.True(t, record.Column(5).IsNull(1))
+	require.Equal(t, "a", record.Column(6).(*array.Dictionary).ValueStr(1))
+}
+
+func TestInferType(t *testing.T) {
+	values := opengemini.SeriesValues{
+		{int64(1), uint64(1), int64(1), true, nil, json.Number("1"), json.Number("1")},
+		{int64(2), uint64(2), float64(1.5), int64(1), nil, json.Number("9007199254740993"), json.Number("1.5")},
+	}
+	var types []arrow.DataType
+	for i := range values[0] {
+		types = append(types, inferType(values, i))
+	}
+	require.Equal(t, []arrow.DataType{
+		arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Uint64,
+		arrow.PrimitiveTypes.Float64,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.String,
+		arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Float64,
+	}, types)
+}
+
+func TestStreamQueryChunked(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", opengemini.HttpContentTypeJSON)
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","count"],` +
+			`"values":[[1,9007199254740993],[2,null]]}]}]}` + "\n"))
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.Nil(t, err)
+	host, port, err := net.SplitHostPort(serverURL.Host)
+	require.Nil(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.Nil(t, err)
+	client, err := opengemini.NewClient(&opengemini.Config{Addresses: []opengemini.Address{{Host: host, Port: portNumber}}})
+	require.Nil(t, err)
+	defer client.Close()
+
+	// the integer beyond 2^53 is inferred as int64 without any field keys, so it isn't rounded by float64
+	converter := NewConverter(WithAllocator(mem))
+	query := opengemini.Query{Database: "db", Command: "SELECT count FROM cpu"}
+	var records int
+	for record, err := range converter.Stream(client.QueryChunked(context.Background(), query, 10)) {
+		require.Nil(t, err)
+		records++
+		require.Equal(t, arrow.PrimitiveTypes.Int64, record.Schema().Field(1).Type)
+		require.Equal(t, int64(9007199254740993), record.Column(1).(*array.Int64).Value(0))
+		require.True(t, record.Column(1).IsNull(1))
+		record.Release()
+	}
+	require.Equal(t, 1, records)
+}
+
+func TestStream(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	chunk := func(values ...opengemini.SeriesValue) *opengemini.QueryResult {
+		return &opengemini.QueryResult{Results: []*opengemini.SeriesResult{{Series: []*opengemini.Series{
+			{Name: "cpu", Columns: []string{"time", "v"}, Values: values},
+		}}}}
+	}
+	chunks := func(yield func(*opengemini.QueryResult, error) bool) {
+		_ = yield(chunk(opengemini.SeriesValue{float64(1), float64(1)}, opengemini.SeriesValue{float64(2), float64(2)}), nil) &&
+			yield(chunk(opengemini.SeriesValue{float64(3), float64(3)}), nil) &&
+			yield(nil, errors.New("connection reset"))
+	}
+
+	converter := NewConverter(WithAllocator(mem), WithPrecision(opengemini.PrecisionMillisecond))
+	var rows []int64
+	var errs []error
+	for record, err := range converter.Stream(chunks) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rows = append(rows, record.NumRows())
+		require.Equal(t, arrow.Timestamp(time.Millisecond), record.Column(0).(*array.Timestamp).Value(0)/
+			arrow.Timestamp(record.Column(1).(*array.Float64).Value(0)))
+		record.Release()
+	}
+	require.Equal(t, []int64{2, 1}, rows)
+	require.Len(t, errs, 1)
+
+	_, err := converter.SeriesRecord(&opengemini.Series{Columns: []string{"time"}, Values: opengemini.SeriesValues{{"now"}}})
+	require.NotNil(t, err)
+}
+
+func fieldTypes(schema *arrow.Schema) []arrow.DataType {
+	var types []arrow.DataType
+	for _, field := range schema.Fields() {
+		types = append(types, field.Type)
+	}
+	return types
+}
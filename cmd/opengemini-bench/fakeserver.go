@@ -0,0 +1,120 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+// fakeServer accept the writes and queries without storing them, so that the benchmark measures the client and the
+// network only
+type fakeServer struct {
+	proto.UnimplementedWriteServiceServer
+
+	httpServer *http.Server
+	grpcServer *grpc.Server
+	httpAddr   net.Addr
+	grpcAddr   net.Addr
+
+	lines    atomic.Int64
+	records  atomic.Int64
+	requests atomic.Int64
+}
+
+// startFakeServer listen on the addresses, use `127.0.0.1:0` to pick free ports
+func startFakeServer(httpAddress, grpcAddress string) (*fakeServer, error) {
+	httpListener, err := net.Listen("tcp", httpAddress)
+	if err != nil {
+		return nil, err
+	}
+	grpcListener, err := net.Listen("tcp", grpcAddress)
+	if err != nil {
+		_ = httpListener.Close()
+		return nil, err
+	}
+
+	s := &fakeServer{httpAddr: httpListener.Addr(), grpcAddr: grpcListener.Addr()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ping", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/write", s.handleWrite)
+	mux.HandleFunc("/query", func(writer http.ResponseWriter, request *http.Request) {
+		s.requests.Add(1)
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	})
+	s.httpServer = &http.Server{Handler: mux}
+	s.grpcServer = grpc.NewServer(grpc.MaxRecvMsgSize(64 * 1024 * 1024))
+	proto.RegisterWriteServiceServer(s.grpcServer, s)
+
+	go func() { _ = s.httpServer.Serve(httpListener) }()
+	go func() { _ = s.grpcServer.Serve(grpcListener) }()
+	return s, nil
+}
+
+func (s *fakeServer) handleWrite(writer http.ResponseWriter, request *http.Request) {
+	s.requests.Add(1)
+	body := io.Reader(request.Body)
+	if request.Header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(request.Body)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = reader
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lines := bytes.Count(data, []byte{'\n'})
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		lines++
+	}
+	s.lines.Add(int64(lines))
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (s *fakeServer) Write(ctx context.Context, request *proto.WriteRequest) (*proto.WriteResponse, error) {
+	s.requests.Add(1)
+	s.records.Add(int64(len(request.Records)))
+	return &proto.WriteResponse{Code: proto.ResponseCode_Success}, nil
+}
+
+func (s *fakeServer) Ping(ctx context.Context, request *proto.PingRequest) (*proto.PingResponse, error) {
+	return &proto.PingResponse{Status: proto.ServerStatus_Up}, nil
+}
+
+func (s *fakeServer) Close() error {
+	s.grpcServer.Stop()
+	err := s.httpServer.Close()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
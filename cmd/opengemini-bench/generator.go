@@ -0,0 +1,115 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const (
+	fieldFloat  = "float"
+	fieldInt    = "int"
+	fieldBool   = "bool"
+	fieldString = "string"
+)
+
+type fieldSpec struct {
+	name string
+	kind string
+}
+
+// parseFieldSpecs parse the field types such as `float:2,int:1`, which means fields f0 and f1 are float and f2 is
+// integer
+func parseFieldSpecs(spec string) ([]fieldSpec, error) {
+	var fields []fieldSpec
+	for _, item := range strings.Split(spec, ",") {
+		kind, count, found := strings.Cut(strings.TrimSpace(item), ":")
+		n := 1
+		if found {
+			var err error
+			n, err = strconv.Atoi(count)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid field count in %q", item)
+			}
+		}
+		switch kind {
+		case fieldFloat, fieldInt, fieldBool, fieldString:
+		default:
+			return nil, fmt.Errorf("unknown field type %q, expect float, int, bool or string", kind)
+		}
+		for i := 0; i < n; i++ {
+			fields = append(fields, fieldSpec{name: "f" + strconv.Itoa(len(fields)), kind: kind})
+		}
+	}
+	return fields, nil
+}
+
+// generator generate the points of synthetic series, the nth point belongs to series n%series and its timestamp is
+// start+n/series*interval, so every series has a point at each interval and the data is the same for each run
+type generator struct {
+	measurement string
+	series      int
+	tagKeys     int
+	fields      []fieldSpec
+	start       int64
+	interval    time.Duration
+	next        atomic.Int64
+}
+
+func (g *generator) points(n int) []*opengemini.Point {
+	first := g.next.Add(int64(n)) - int64(n)
+	points := make([]*opengemini.Point, n)
+	for i := range points {
+		points[i] = g.point(first + int64(i))
+	}
+	return points
+}
+
+func (g *generator) point(seq int64) *opengemini.Point {
+	series := seq % int64(g.series)
+	point := &opengemini.Point{
+		Measurement: g.measurement,
+		Tags:        make(map[string]string, g.tagKeys),
+		Fields:      make(map[string]any, len(g.fields)),
+		Timestamp:   g.start + seq/int64(g.series)*int64(g.interval),
+	}
+	// tag0 identifies the series, the others group the series so that they don't add cardinality
+	for k := 0; k < g.tagKeys; k++ {
+		value := series
+		if k > 0 {
+			value = series % int64(10*k)
+		}
+		point.Tags["tag"+strconv.Itoa(k)] = "v" + strconv.FormatInt(value, 10)
+	}
+	for _, field := range g.fields {
+		switch field.kind {
+		case fieldFloat:
+			point.Fields[field.name] = float64(seq%1000) / 10
+		case fieldInt:
+			point.Fields[field.name] = seq
+		case fieldBool:
+			point.Fields[field.name] = seq%2 == 0
+		case fieldString:
+			point.Fields[field.name] = "value-" + strconv.FormatInt(seq%100, 10)
+		}
+	}
+	return point
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command opengemini-bench generate synthetic series and drive the write and query paths of the client, it reports
+// the throughput and latency percentiles of each combination of mode and compress method
+//
+//	opengemini-bench -mode http,grpc -compress none,gzip -series 10000 -points 1000000 -concurrency 8
+//	opengemini-bench -fake -mode http,batch,grpc     run against the in-process fake server
+//	opengemini-bench serve -port 8086 -grpc-port 8305 run the fake server only
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+type options struct {
+	host           string
+	port           int
+	grpcPort       int
+	username       string
+	password       string
+	ssl            bool
+	unsafeSsl      bool
+	database       string
+	rp             string
+	createDatabase bool
+	fake           bool
+
+	modes      string
+	compresses string
+
+	measurement string
+	series      int
+	tagKeys     int
+	fields      string
+	interval    time.Duration
+	start       string
+
+	points      int64
+	duration    time.Duration
+	batchSize   int
+	concurrency int
+	rate        float64
+
+	query   string
+	queries int64
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "ERR:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts options
+	flags := flag.NewFlagSet("opengemini-bench", flag.ContinueOnError)
+	flags.StringVar(&opts.host, "host", "127.0.0.1", "openGemini host")
+	flags.IntVar(&opts.port, "port", 8086, "openGemini HTTP port")
+	flags.IntVar(&opts.grpcPort, "grpc-port", 8305, "openGemini gRPC write port")
+	flags.StringVar(&opts.username, "username", "", "username for authentication")
+	flags.StringVar(&opts.password, "password", os.Getenv("OPENGEMINI_PASSWORD"),
+		"password for authentication, default is $OPENGEMINI_PASSWORD")
+	flags.BoolVar(&opts.ssl, "ssl", false, "use TLS to connect")
+	flags.BoolVar(&opts.unsafeSsl, "unsafe-ssl", false, "skip verification of server certificate")
+	flags.StringVar(&opts.database, "database", "bench", "database to write and query")
+	flags.StringVar(&opts.rp, "rp", "", "retention policy to write")
+	flags.BoolVar(&opts.createDatabase, "create-database", true, "create the database before running")
+	flags.BoolVar(&opts.fake, "fake", false, "run against the in-process fake server instead of -host")
+	flags.StringVar(&opts.modes, "mode", modeHTTP, "comma separated workloads: http, batch, grpc or query")
+	flags.StringVar(&opts.compresses, "compress", "none", "comma separated compress methods: none, gzip, zstd or snappy")
+	flags.StringVar(&opts.measurement, "measurement", "bench", "measurement of synthetic series")
+	flags.IntVar(&opts.series, "series", 1000, "number of series, it is the cardinality")
+	flags.IntVar(&opts.tagKeys, "tag-keys", 3, "number of tags of each series")
+	flags.StringVar(&opts.fields, "fields", "float:2,int:1", "field types and counts: float, int, bool or string")
+	flags.DurationVar(&opts.interval, "interval", 10*time.Second, "timestamp spacing of points in a series")
+	flags.StringVar(&opts.start, "start", "", "RFC3339 timestamp of the first points, default is now")
+	flags.Int64Var(&opts.points, "points", 1000000, "number of points to write by each workload, zero means no limit until -duration")
+	flags.DurationVar(&opts.duration, "duration", 0, "stop each workload after the duration, zero means no limit")
+	flags.IntVar(&opts.batchSize, "batch-size", 5000, "number of points of each write")
+	flags.IntVar(&opts.concurrency, "concurrency", 4, "number of concurrent writers or queriers")
+	flags.Float64Var(&opts.rate, "rate", 0, "target points or queries per second, zero means as fast as possible")
+	flags.StringVar(&opts.query, "query", "", "query of query workload, default counts the measurement")
+	flags.Int64Var(&opts.queries, "queries", 1000, "number of queries of query workload, zero means no limit until -duration")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	switch flags.Arg(0) {
+	case "":
+	case "serve":
+		return serve(ctx, opts, flags.Args()[1:], stdout)
+	default:
+		return fmt.Errorf("unknown command %q, run with -h for usage", flags.Arg(0))
+	}
+
+	if opts.fake {
+		server, err := startFakeServer("127.0.0.1:0", "127.0.0.1:0")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = server.Close() }()
+		opts.host = "127.0.0.1"
+		opts.port = server.httpAddr.(*net.TCPAddr).Port
+		opts.grpcPort = server.grpcAddr.(*net.TCPAddr).Port
+	}
+
+	for _, compress := range strings.Split(opts.compresses, ",") {
+		for _, mode := range strings.Split(opts.modes, ",") {
+			if err := checkWorkload(strings.TrimSpace(mode), strings.TrimSpace(compress)); err != nil {
+				return err
+			}
+		}
+	}
+
+	var reports []*report
+	for _, compress := range strings.Split(opts.compresses, ",") {
+		for _, mode := range strings.Split(opts.modes, ",") {
+			if ctx.Err() != nil {
+				break
+			}
+			result, err := runWorkload(ctx, opts, strings.TrimSpace(mode), strings.TrimSpace(compress))
+			if err != nil {
+				return err
+			}
+			reports = append(reports, result)
+		}
+	}
+	return printReports(stdout, reports)
+}
+
+// checkWorkload reject the combination before running any workload, the HTTP write compresses the body by gzip
+// only, the zstd and snappy of HTTP are used by the responses of query
+func checkWorkload(mode, compress string) error {
+	method, err := parseCompressMethod(compress)
+	if err != nil {
+		return err
+	}
+	if (mode == modeHTTP || mode == modeBatch) &&
+		(method == opengemini.CompressMethodZstd || method == opengemini.CompressMethodSnappy) {
+		return fmt.Errorf("workload %s/%s isn't supported, the HTTP write is compressed by gzip only", mode, compress)
+	}
+	return nil
+}
+
+func runWorkload(ctx context.Context, opts options, mode, compress string) (*report, error) {
+	compressMethod, err := parseCompressMethod(compress)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFieldSpecs(opts.fields)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now().UnixNano()
+	if opts.start != "" {
+		t, err := time.Parse(time.RFC3339, opts.start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start: %w", err)
+		}
+		start = t.UnixNano()
+	}
+	if opts.series <= 0 || opts.batchSize <= 0 || opts.concurrency <= 0 {
+		return nil, errors.New("series, batch-size and concurrency must be greater than 0")
+	}
+	gen := &generator{
+		measurement: opts.measurement,
+		series:      opts.series,
+		tagKeys:     opts.tagKeys,
+		fields:      fields,
+		start:       start,
+		interval:    opts.interval,
+	}
+
+	config := &opengemini.Config{
+		Addresses:      []opengemini.Address{{Host: opts.host, Port: opts.port}},
+		CompressMethod: compressMethod,
+	}
+	if opts.username != "" {
+		config.AuthConfig = &opengemini.AuthConfig{
+			AuthType: opengemini.AuthTypePassword,
+			Username: opts.username,
+			Password: opts.password,
+		}
+	}
+	if opts.ssl {
+		config.TlsConfig = &tls.Config{InsecureSkipVerify: opts.unsafeSsl} // #nosec G402 opt-in by -unsafe-ssl
+	}
+	w := workload{
+		total:       opts.points,
+		duration:    opts.duration,
+		batchSize:   opts.batchSize,
+		concurrency: opts.concurrency,
+		rate:        opts.rate,
+	}
+	switch mode {
+	case modeBatch:
+		config.BatchConfig = &opengemini.BatchConfig{BatchSize: opts.batchSize, BatchInterval: time.Second}
+	case modeGrpc:
+		config.GrpcConfig = &opengemini.GrpcConfig{
+			Addresses:      []opengemini.Address{{Host: opts.host, Port: opts.grpcPort}},
+			AuthConfig:     config.AuthConfig,
+			TlsConfig:      config.TlsConfig,
+			CompressMethod: compressMethod,
+		}
+	case modeQuery:
+		w.total, w.batchSize = opts.queries, 1
+	case modeHTTP:
+	default:
+		return nil, fmt.Errorf("unknown mode %q, expect http, batch, grpc or query", mode)
+	}
+	if w.total < 0 || w.total == 0 && w.duration <= 0 {
+		return nil, errors.New("the number of points or queries must be greater than 0 unless duration is set")
+	}
+
+	client, err := opengemini.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+	if opts.createDatabase && mode != modeQuery {
+		if err := client.CreateDatabase(opts.database); err != nil {
+			return nil, fmt.Errorf("create database: %w", err)
+		}
+	}
+
+	var send target
+	switch mode {
+	case modeHTTP:
+		send = httpTarget(client, opts.database, opts.rp, gen)
+	case modeBatch:
+		send = batchTarget(client, opts.database, opts.rp, gen)
+	case modeGrpc:
+		send = grpcTarget(client, opts.database, opts.rp, compressMethod, config.AuthConfig, gen)
+	case modeQuery:
+		command := opts.query
+		if command == "" {
+			command = "SELECT COUNT(*) FROM " + opengemini.QuoteIdentifier(opts.measurement)
+		}
+		send = queryTarget(client, opengemini.Query{Database: opts.database, RetentionPolicy: opts.rp, Command: command})
+	}
+	return w.run(ctx, mode+"/"+compress, send), nil
+}
+
+func parseCompressMethod(compress string) (opengemini.CompressMethod, error) {
+	switch method := opengemini.CompressMethod(strings.ToUpper(compress)); method {
+	case opengemini.CompressMethodNone, opengemini.CompressMethodGzip, opengemini.CompressMethodZstd,
+		opengemini.CompressMethodSnappy:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown compress method %q, expect none, gzip, zstd or snappy", compress)
+	}
+}
+
+// serve run the fake server until interrupted, so that the benchmark on another host doesn't need a cluster, the
+// addresses given before the command are the defaults of its own flags
+func serve(ctx context.Context, opts options, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.StringVar(&opts.host, "host", opts.host, "address to listen on")
+	flags.IntVar(&opts.port, "port", opts.port, "HTTP port to listen on")
+	flags.IntVar(&opts.grpcPort, "grpc-port", opts.grpcPort, "gRPC write port to listen on")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments %q of serve", flags.Args())
+	}
+
+	server, err := startFakeServer(net.JoinHostPort(opts.host, strconv.Itoa(opts.port)),
+		net.JoinHostPort(opts.host, strconv.Itoa(opts.grpcPort)))
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "fake server listening on %s (HTTP) and %s (gRPC)\n", server.httpAddr, server.grpcAddr)
+	<-ctx.Done()
+	_, _ = fmt.Fprintf(stdout, "received %d requests, %d lines, %d records\n",
+		server.requests.Load(), server.lines.Load(), server.records.Load())
+	return server.Close()
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	fields, err := parseFieldSpecs("float,int:2,bool,string")
+	require.Nil(t, err)
+	gen := &generator{measurement: "m", series: 3, tagKeys: 2, fields: fields, start: 100, interval: time.Second}
+
+	points := gen.points(4)
+	require.Len(t, points, 4)
+	require.Equal(t, map[string]string{"tag0": "v0", "tag1": "v0"}, points[0].Tags)
+	require.Equal(t, map[string]string{"tag0": "v0", "tag1": "v0"}, points[3].Tags)
+	require.Equal(t, map[string]any{"f0": 0.3, "f1": int64(3), "f2": int64(3), "f3": false, "f4": "value-3"},
+		points[3].Fields)
+	require.Equal(t, int64(100), points[2].Timestamp)
+	require.Equal(t, int64(100)+int64(time.Second), points[3].Timestamp)
+
+	_, err = parseFieldSpecs("decimal:1")
+	require.NotNil(t, err)
+	_, err = parseFieldSpecs("float:0")
+	require.NotNil(t, err)
+}
+
+func TestWorkloadRate(t *testing.T) {
+	var calls int
+	w := workload{total: 10, batchSize: 3, concurrency: 1, rate: 300}
+	result := w.run(context.Background(), "test", func(ctx context.Context, n int) error {
+		calls++
+		return nil
+	})
+	require.Equal(t, int64(10), result.sent)
+	require.Equal(t, 4, calls)
+	require.Len(t, result.latencies, 4)
+	// 4 batches of 3 points at 300 points per second take 40ms
+	require.GreaterOrEqual(t, result.elapsed, 30*time.Millisecond)
+}
+
+func TestWorkloadRateBeyondTicker(t *testing.T) {
+	w := workload{total: 10, batchSize: 1, concurrency: 1, rate: 1e10}
+	result := w.run(context.Background(), "test", func(ctx context.Context, n int) error { return nil })
+	require.Equal(t, int64(10), result.sent)
+}
+
+func TestWorkloadDurationOnly(t *testing.T) {
+	w := workload{duration: 50 * time.Millisecond, batchSize: 2, concurrency: 2}
+	result := w.run(context.Background(), "test", func(ctx context.Context, n int) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	require.Greater(t, result.sent, int64(0))
+	require.Zero(t, result.errors)
+	require.Less(t, result.elapsed, time.Second)
+}
+
+func TestRunAgainstFakeServer(t *testing.T) {
+	server, err := startFakeServer("127.0.0.1:0", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer func() { _ = server.Close() }()
+
+	var out bytes.Buffer
+	err = run(context.Background(), []string{
+		"-port", strconv.Itoa(server.httpAddr.(*net.TCPAddr).Port),
+		"-grpc-port", strconv.Itoa(server.grpcAddr.(*net.TCPAddr).Port),
+		"-mode", "http,batch,grpc,query", "-compress", "none,gzip",
+		"-series", "10", "-points", "100", "-batch-size", "20", "-concurrency", "2", "-queries", "5",
+	}, &out)
+	require.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 9, out.String())
+	require.Contains(t, lines[1], "http/none")
+	require.Contains(t, lines[8], "query/gzip")
+	for _, line := range lines[1:] {
+		require.Regexp(t, ` (100|5)\s+0\s`, line)
+	}
+	require.Equal(t, int64(400), server.lines.Load())
+	// 5 write requests of each gRPC workload, one record per measurement
+	require.Equal(t, int64(10), server.records.Load())
+}
+
+func TestRunFake(t *testing.T) {
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"-fake", "-points", "10", "-batch-size", "5"}, &out)
+	require.Nil(t, err)
+	require.Contains(t, out.String(), "http/none")
+
+	err = run(context.Background(), []string{"-fake", "-mode", "tcp"}, &out)
+	require.NotNil(t, err)
+	err = run(context.Background(), []string{"-fake", "-compress", "lz4"}, &out)
+	require.NotNil(t, err)
+	err = run(context.Background(), []string{"-fake", "-mode", "grpc,http", "-compress", "zstd"}, &out)
+	require.ErrorContains(t, err, "http/zstd")
+	err = run(context.Background(), []string{"-fake", "-points", "0"}, &out)
+	require.NotNil(t, err)
+
+	out.Reset()
+	err = run(context.Background(), []string{"-fake", "-mode", "grpc,query", "-compress", "zstd,snappy",
+		"-points", "10", "-batch-size", "5", "-queries", "2"}, &out)
+	require.Nil(t, err)
+	require.Contains(t, out.String(), "grpc/snappy")
+}
+
+func TestRunServe(t *testing.T) {
+	// pick the free ports, each run has its own since the listeners of a stopped server are closed asynchronously,
+	// the server is stopped right after listening since the context is done
+	var ports []string
+	for i := 0; i < 4; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.Nil(t, err)
+		ports = append(ports, strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+		require.Nil(t, listener.Close())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	err := run(ctx, []string{"serve", "-port", ports[0], "-grpc-port", ports[1]}, &out)
+	require.Nil(t, err)
+	require.Contains(t, out.String(), "127.0.0.1:"+ports[0]+" (HTTP) and 127.0.0.1:"+ports[1]+" (gRPC)")
+
+	// the flags before the command are the defaults
+	out.Reset()
+	err = run(ctx, []string{"-port", ports[2], "serve", "-grpc-port", ports[3]}, &out)
+	require.Nil(t, err)
+	require.Contains(t, out.String(), "127.0.0.1:"+ports[2]+" (HTTP)")
+
+	err = run(ctx, []string{"serve", "-port", ports[2], "extra"}, &out)
+	require.ErrorContains(t, err, "extra")
+	err = run(ctx, []string{"serve", "-points", "10"}, &out)
+	require.NotNil(t, err)
+}
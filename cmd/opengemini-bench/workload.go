@@ -0,0 +1,239 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const (
+	modeHTTP  = "http"
+	modeBatch = "batch"
+	modeGrpc  = "grpc"
+	modeQuery = "query"
+)
+
+// target send a batch of the workload, n is the number of points or queries
+type target func(ctx context.Context, n int) error
+
+func httpTarget(client opengemini.Client, database, rp string, gen *generator) target {
+	return func(ctx context.Context, n int) error {
+		return client.WriteBatchPointsWithRp(ctx, database, rp, gen.points(n))
+	}
+}
+
+// batchTarget write the points one by one and wait for the callbacks, the points are batched by BatchConfig of client
+func batchTarget(client opengemini.Client, database, rp string, gen *generator) target {
+	return func(ctx context.Context, n int) error {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var errs []error
+		for _, point := range gen.points(n) {
+			wg.Add(1)
+			err := client.WritePointWithRp(database, rp, point, func(err error) {
+				defer wg.Done()
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			})
+			if err != nil {
+				wg.Done()
+				return err
+			}
+		}
+		wg.Wait()
+		return errors.Join(errs...)
+	}
+}
+
+func grpcTarget(client opengemini.Client, database, rp string, compress opengemini.CompressMethod,
+	auth *opengemini.AuthConfig, gen *generator) target {
+	return func(ctx context.Context, n int) error {
+		builder, err := opengemini.NewWriteRequestBuilder(database, rp)
+		if err != nil {
+			return err
+		}
+		if auth != nil {
+			builder.Authenticate(auth.Username, auth.Password)
+		}
+		for _, point := range gen.points(n) {
+			line, err := opengemini.NewRecordBuilder(point.Measurement)
+			if err != nil {
+				return err
+			}
+			builder.AddRecord(line.CompressMethod(compress).AddTags(point.Tags).AddFields(point.Fields).
+				Build(point.Timestamp))
+		}
+		request, err := builder.Build()
+		if err != nil {
+			return err
+		}
+		return client.WriteByGrpc(ctx, request)
+	}
+}
+
+// queryTarget run the query n times sequentially, the batch size of query workload is 1
+func queryTarget(client opengemini.Client, query opengemini.Query) target {
+	return func(ctx context.Context, n int) error {
+		for i := 0; i < n; i++ {
+			result, err := client.Query(query)
+			if err != nil {
+				return err
+			}
+			if result.Error != "" {
+				return errors.New(result.Error)
+			}
+		}
+		return nil
+	}
+}
+
+type workload struct {
+	// total the number of points or queries to send, zero means no limit, the workload runs until the duration
+	total int64
+	// duration stop the workload after the duration even if total is not reached, zero means no limit
+	duration    time.Duration
+	batchSize   int
+	concurrency int
+	// rate the target number of points or queries per second, zero means as fast as possible
+	rate float64
+}
+
+type report struct {
+	name      string
+	sent      int64
+	errors    int64
+	elapsed   time.Duration
+	latencies []time.Duration
+	lastError error
+}
+
+// run send the batches by concurrent workers, the rate is shaped by releasing a token per batch at the interval
+func (w workload) run(ctx context.Context, name string, send target) *report {
+	if w.duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.duration)
+		defer cancel()
+	}
+
+	// the interval rounds to zero when the rate is beyond the resolution of ticker, it is as fast as possible
+	var tokens <-chan time.Time
+	if interval := time.Duration(float64(time.Second) * float64(w.batchSize) / w.rate); w.rate > 0 && interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	var claimed, sent, failed atomic.Int64
+	var mu sync.Mutex
+	result := &report{name: name}
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var latencies []time.Duration
+			var lastError error
+			for ctx.Err() == nil {
+				end := claimed.Add(int64(w.batchSize))
+				n := int64(w.batchSize)
+				if w.total > 0 && end > w.total {
+					n = w.total - (end - int64(w.batchSize))
+				}
+				if n <= 0 {
+					break
+				}
+				if tokens != nil {
+					select {
+					case <-tokens:
+					case <-ctx.Done():
+						continue
+					}
+				}
+				begin := time.Now()
+				if err := send(ctx, int(n)); err != nil {
+					if ctx.Err() != nil {
+						break
+					}
+					failed.Add(n)
+					lastError = err
+					continue
+				}
+				latencies = append(latencies, time.Since(begin))
+				sent.Add(n)
+			}
+			mu.Lock()
+			result.latencies = append(result.latencies, latencies...)
+			if lastError != nil {
+				result.lastError = lastError
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	result.elapsed = time.Since(start)
+	result.sent = sent.Load()
+	result.errors = failed.Load()
+	slices.Sort(result.latencies)
+	return result
+}
+
+func (r *report) percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	index := int(float64(len(r.latencies))*p/100+0.5) - 1
+	index = max(0, min(index, len(r.latencies)-1))
+	return r.latencies[index].Round(time.Microsecond)
+}
+
+func (r *report) throughput() float64 {
+	if r.elapsed <= 0 {
+		return 0
+	}
+	return float64(r.sent) / r.elapsed.Seconds()
+}
+
+func printReports(w io.Writer, reports []*report) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(table, "workload\tsent\terrors\telapsed\tper second\tp50\tp90\tp99\tmax\t")
+	for _, r := range reports {
+		_, _ = fmt.Fprintf(table, "%s\t%d\t%d\t%s\t%.0f\t%s\t%s\t%s\t%s\t\n", r.name, r.sent, r.errors,
+			r.elapsed.Round(time.Millisecond), r.throughput(), r.percentile(50), r.percentile(90), r.percentile(99),
+			r.percentile(100))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.lastError != nil {
+			_, _ = fmt.Fprintf(w, "%s last error: %v\n", r.name, r.lastError)
+		}
+	}
+	return nil
+}
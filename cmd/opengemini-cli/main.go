@@ -0,0 +1,166 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command opengemini-cli is the command line tool of openGemini built on the Go client, run it without subcommand to
+// start the interactive shell
+//
+//	opengemini-cli [flags]                        start the interactive shell
+//	opengemini-cli [flags] query <command>        run the query and print the result
+//	opengemini-cli [flags] write [-file <path>]   write line protocol or CSV from file or stdin
+//	opengemini-cli [flags] show <object>          show databases, retention-policies, measurements or series
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const usage = `Usage: opengemini-cli [flags] [command]
+
+Commands:
+  query <command>    run the query and print the result
+  write [flags]      write line protocol or annotated CSV from file or stdin
+  show <object>      show databases, retention-policies, measurements or series
+  without command, start the interactive shell
+
+Flags:
+`
+
+type options struct {
+	host            string
+	port            int
+	username        string
+	password        string
+	ssl             bool
+	unsafeSsl       bool
+	timeout         time.Duration
+	database        string
+	retentionPolicy string
+	precision       string
+	format          string
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "ERR:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var opts options
+	flags := flag.NewFlagSet("opengemini-cli", flag.ContinueOnError)
+	flags.StringVar(&opts.host, "host", "127.0.0.1", "openGemini host")
+	flags.IntVar(&opts.port, "port", 8086, "openGemini port")
+	flags.StringVar(&opts.username, "username", "", "username for authentication")
+	flags.StringVar(&opts.password, "password", os.Getenv("OPENGEMINI_PASSWORD"),
+		"password for authentication, default is $OPENGEMINI_PASSWORD")
+	flags.BoolVar(&opts.ssl, "ssl", false, "use https to connect")
+	flags.BoolVar(&opts.unsafeSsl, "unsafe-ssl", false, "skip verification of server certificate")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "timeout of each request")
+	flags.StringVar(&opts.database, "database", "", "database to use")
+	flags.StringVar(&opts.retentionPolicy, "rp", "", "retention policy to use")
+	flags.StringVar(&opts.precision, "precision", "ns", "precision of timestamps: ns, u, ms, s, m, h or rfc3339")
+	flags.StringVar(&opts.format, "format", formatTable, "output format: table, csv or json")
+	flags.Usage = func() {
+		_, _ = fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	precision, err := parsePrecision(opts.precision)
+	if err != nil {
+		return err
+	}
+	out, err := newPrinter(opts.format, stdout, precision)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	session := &session{
+		client:          client,
+		database:        opts.database,
+		retentionPolicy: opts.retentionPolicy,
+		precision:       precision,
+		out:             out,
+	}
+	command, args := flags.Arg(0), flags.Args()
+	if len(args) > 0 {
+		args = args[1:]
+	}
+	switch command {
+	case "":
+		return session.repl(ctx, stdin)
+	case "query":
+		if len(args) == 0 {
+			return errors.New("query: command is required")
+		}
+		return session.execute(ctx, strings.Join(args, " "))
+	case "write":
+		return session.write(ctx, args, stdin)
+	case "show":
+		return session.show(args)
+	default:
+		return fmt.Errorf("unknown command %q, run with -h for usage", command)
+	}
+}
+
+func newClient(opts options) (opengemini.Client, error) {
+	config := &opengemini.Config{
+		Addresses: []opengemini.Address{{Host: opts.host, Port: opts.port}},
+		Timeout:   opts.timeout,
+	}
+	if opts.username != "" {
+		config.AuthConfig = &opengemini.AuthConfig{
+			AuthType: opengemini.AuthTypePassword,
+			Username: opts.username,
+			Password: opts.password,
+		}
+	}
+	if opts.ssl {
+		config.TlsConfig = &tls.Config{InsecureSkipVerify: opts.unsafeSsl} // #nosec G402 opt-in by -unsafe-ssl
+	}
+	return opengemini.NewClient(config)
+}
+
+// parsePrecision is stricter than opengemini.ToPrecision, which falls back to nanosecond silently
+func parsePrecision(epoch string) (opengemini.Precision, error) {
+	precision := opengemini.ToPrecision(epoch)
+	if precision.Epoch() != epoch {
+		return 0, fmt.Errorf("unknown precision %q, expect ns, u, ms, s, m, h or rfc3339", epoch)
+	}
+	return precision, nil
+}
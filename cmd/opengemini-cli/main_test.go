@@ -0,0 +1,131 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testServer answer the queries by command and record the requests
+func testServer(t *testing.T, responses map[string]string) (string, string, *[]*http.Request) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		require.Nil(t, err)
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		requests = append(requests, request)
+		if request.URL.Path == "/write" {
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		response, ok := responses[request.FormValue("q")]
+		if !ok {
+			response = `{"results":[{"statement_id":0}]}`
+		}
+		_, _ = writer.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.Nil(t, err)
+	return u.Hostname(), u.Port(), &requests
+}
+
+func TestReplSwitchContext(t *testing.T) {
+	host, port, requests := testServer(t, map[string]string{
+		`SELECT * FROM cpu`: `{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"host":"a"},` +
+			`"columns":["time","v"],"values":[[1,0.5],[2,null]]}]}]}`,
+	})
+	input := strings.Join([]string{
+		"use db0.rp0",
+		"precision s",
+		"SELECT * FROM cpu",
+		"format csv",
+		"SELECT * FROM cpu",
+		"precision year",
+		"INSERT cpu v=1 3",
+		"exit",
+		"SELECT * FROM ignored",
+	}, "\n")
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"-host", host, "-port", port}, strings.NewReader(input), &out)
+	require.Nil(t, err)
+	require.Equal(t, `name: cpu
+tags: host=a
+time v
+---- -
+1    0.5
+2    
+name,host,time,v
+cpu,a,1,0.5
+cpu,a,2,
+ERR: unknown precision "year", expect ns, u, ms, s, m, h or rfc3339
+`, out.String())
+
+	require.Len(t, *requests, 3)
+	query := (*requests)[0].URL.Query()
+	require.Equal(t, "db0", query.Get("db"))
+	require.Equal(t, "rp0", query.Get("rp"))
+	require.Equal(t, "s", query.Get("epoch"))
+	body, err := io.ReadAll((*requests)[2].Body)
+	require.Nil(t, err)
+	require.Equal(t, "cpu v=1 3000000000", strings.TrimSpace(string(body)))
+}
+
+func TestShowDatabasesAsJSON(t *testing.T) {
+	host, port, _ := testServer(t, map[string]string{
+		`SHOW DATABASES`: `{"results":[{"statement_id":0,"series":[{"name":"databases","columns":["name"],` +
+			`"values":[["db0"],["db1"]]}]}]}`,
+	})
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"-host", host, "-port", port, "-format", "json", "show", "databases"},
+		nil, &out)
+	require.Nil(t, err)
+	require.Contains(t, out.String(), `"values": [
+            [
+              "db0"
+            ],`)
+}
+
+func TestWriteFromStdin(t *testing.T) {
+	host, port, requests := testServer(t, nil)
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"-host", host, "-port", port, "-database", "db0", "write"},
+		strings.NewReader("cpu v=1 1\nbad\n"), &out)
+	require.Nil(t, err)
+	require.Equal(t, "1 points written, 1 lines rejected, offset 14\n", out.String())
+	require.Len(t, *requests, 1)
+
+	err = run(context.Background(), []string{"-host", host, "-port", port, "write"}, strings.NewReader(""), &out)
+	require.NotNil(t, err)
+}
+
+func TestSplitDatabase(t *testing.T) {
+	database, rp := splitDatabase(`"my.db"."rp0"`)
+	require.Equal(t, "my.db", database)
+	require.Equal(t, "rp0", rp)
+	database, rp = splitDatabase("db0")
+	require.Equal(t, "db0", database)
+	require.Equal(t, "", rp)
+}
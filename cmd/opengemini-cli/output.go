@@ -0,0 +1,130 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
+type printer struct {
+	format    string
+	w         io.Writer
+	precision opengemini.Precision
+}
+
+func newPrinter(format string, w io.Writer, precision opengemini.Precision) (*printer, error) {
+	switch format {
+	case formatTable, formatCSV, formatJSON:
+		return &printer{format: format, w: w, precision: precision}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q, expect table, csv or json", format)
+	}
+}
+
+func (p *printer) print(result *opengemini.QueryResult) error {
+	if result == nil {
+		return nil
+	}
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+	for _, seriesResult := range result.Results {
+		if seriesResult.Error != "" {
+			return errors.New(seriesResult.Error)
+		}
+	}
+
+	switch p.format {
+	case formatCSV:
+		encoder := opengemini.NewCSVEncoder(p.w, opengemini.ExportConfig{Precision: p.precision})
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+		return encoder.Flush()
+	case formatJSON:
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	default:
+		return p.printTable(result)
+	}
+}
+
+// printTable print each series as an aligned table headed by its name and tags
+func (p *printer) printTable(result *opengemini.QueryResult) error {
+	first := true
+	for _, seriesResult := range result.Results {
+		for _, series := range seriesResult.Series {
+			if !first {
+				_, _ = fmt.Fprintln(p.w)
+			}
+			first = false
+			if series.Name != "" {
+				_, _ = fmt.Fprintf(p.w, "name: %s\n", series.Name)
+			}
+			if len(series.Tags) > 0 {
+				_, _ = fmt.Fprintf(p.w, "tags: %s\n", formatTags(series.Tags))
+			}
+
+			table := tabwriter.NewWriter(p.w, 0, 0, 1, ' ', 0)
+			separators := make([]string, len(series.Columns))
+			for i, column := range series.Columns {
+				separators[i] = strings.Repeat("-", len(column))
+			}
+			_, _ = fmt.Fprintln(table, strings.Join(series.Columns, "\t"))
+			_, _ = fmt.Fprintln(table, strings.Join(separators, "\t"))
+			for _, value := range series.Values {
+				cells := make([]string, len(value))
+				for i, cell := range value {
+					if cell != nil {
+						cells[i] = fmt.Sprint(cell)
+					}
+				}
+				_, _ = fmt.Fprintln(table, strings.Join(cells, "\t"))
+			}
+			if err := table.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + tags[key]
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const replHelp = `Commands:
+  use <database>[.<retention policy>]  switch database and retention policy
+  precision <ns|u|ms|s|m|h|rfc3339>    switch precision of timestamps
+  format <table|csv|json>              switch output format
+  help                                 show this help
+  exit, quit                           leave the shell
+  the other input is executed as statement, such as SELECT, SHOW, CREATE or INSERT
+`
+
+// session holds the context of the shell which is switched by `use`, `precision` and `format`
+type session struct {
+	client          opengemini.Client
+	database        string
+	retentionPolicy string
+	precision       opengemini.Precision
+	out             *printer
+}
+
+func (s *session) repl(ctx context.Context, stdin io.Reader) error {
+	interactive := isTerminal(stdin)
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for {
+		if interactive {
+			_, _ = fmt.Fprint(s.out.w, s.prompt())
+		}
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		quit, err := s.handle(ctx, line)
+		if err != nil {
+			_, _ = fmt.Fprintln(s.out.w, "ERR:", err)
+		}
+		if quit || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (s *session) prompt() string {
+	switch {
+	case s.database == "":
+		return "> "
+	case s.retentionPolicy == "":
+		return s.database + "> "
+	default:
+		return s.database + "." + s.retentionPolicy + "> "
+	}
+}
+
+// handle run a line of shell, the statements are sent by Execute, the others are the commands of shell
+func (s *session) handle(ctx context.Context, line string) (bool, error) {
+	keyword, argument, _ := strings.Cut(line, " ")
+	argument = strings.TrimSpace(argument)
+	switch strings.ToLower(keyword) {
+	case "exit", "quit":
+		return true, nil
+	case "help":
+		_, err := fmt.Fprint(s.out.w, replHelp)
+		return false, err
+	case "use":
+		if argument == "" {
+			return false, errors.New("use: database is required")
+		}
+		s.database, s.retentionPolicy = splitDatabase(argument)
+		return false, nil
+	case "precision":
+		precision, err := parsePrecision(strings.ToLower(argument))
+		if err != nil {
+			return false, err
+		}
+		s.precision = precision
+		s.out.precision = precision
+		return false, nil
+	case "format":
+		out, err := newPrinter(strings.ToLower(argument), s.out.w, s.precision)
+		if err != nil {
+			return false, err
+		}
+		s.out = out
+		return false, nil
+	default:
+		return false, s.execute(ctx, line)
+	}
+}
+
+// splitDatabase split `db.rp`, the quoted names may contain dot, such as `"my.db"."rp"`
+func splitDatabase(argument string) (string, string) {
+	if strings.HasPrefix(argument, `"`) {
+		if end := strings.Index(argument[1:], `"`); end >= 0 {
+			database, rest := argument[1:end+1], argument[end+2:]
+			return database, strings.Trim(strings.TrimPrefix(rest, "."), `"`)
+		}
+	}
+	database, retentionPolicy, _ := strings.Cut(argument, ".")
+	return database, strings.Trim(retentionPolicy, `"`)
+}
+
+func (s *session) execute(ctx context.Context, command string) error {
+	result, err := s.client.ExecuteContext(ctx, opengemini.Statement{
+		Database:        s.database,
+		RetentionPolicy: s.retentionPolicy,
+		Command:         command,
+		Precision:       s.precision,
+	})
+	if err != nil {
+		return err
+	}
+	if result.StatementType == opengemini.StatementTypeInsert {
+		return nil
+	}
+	return s.out.print(result.QueryResult)
+}
+
+func (s *session) write(ctx context.Context, args []string, stdin io.Reader) error {
+	flags := flag.NewFlagSet("write", flag.ContinueOnError)
+	file := flags.String("file", "", "file to write, gzip is detected, default is stdin")
+	format := flags.String("format", string(opengemini.ImportFormatLineProtocol), "format of input: line_protocol or csv")
+	batchSize := flags.Int("batch-size", 5000, "number of points of each write request")
+	checkpoint := flags.Int64("checkpoint", 0, "resume from the offset reported by the interrupted write")
+	rejected := flags.String("rejected", "", "file to save the rejected lines, default is discarded")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if s.database == "" {
+		return errors.New("write: -database is required")
+	}
+
+	input := stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		input = f
+	}
+	config := opengemini.ImportConfig{
+		Database:        s.database,
+		RetentionPolicy: s.retentionPolicy,
+		Format:          opengemini.ImportFormat(*format),
+		Precision:       s.precision,
+		BatchSize:       *batchSize,
+		Checkpoint:      *checkpoint,
+	}
+	if *rejected != "" {
+		f, err := os.Create(*rejected)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		config.DeadLetter = f
+	}
+
+	progress, err := s.client.Import(ctx, input, config)
+	_, _ = fmt.Fprintf(s.out.w, "%d points written, %d lines rejected, offset %d\n",
+		progress.Points, progress.Rejected, progress.Offset)
+	if err != nil {
+		return fmt.Errorf("write: %w, resume with -checkpoint %d", err, progress.Offset)
+	}
+	return nil
+}
+
+func (s *session) show(args []string) error {
+	if len(args) == 0 {
+		return errors.New("show: object is required, such as databases, retention-policies, measurements or series")
+	}
+	flags := flag.NewFlagSet("show "+args[0], flag.ContinueOnError)
+	measurement := flags.String("measurement", "", "filter series by measurement")
+	limit := flags.Int("limit", 0, "max number of series")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var series *opengemini.Series
+	switch args[0] {
+	case "databases":
+		databases, err := s.client.ShowDatabases()
+		if err != nil {
+			return err
+		}
+		series = stringSeries("databases", databases)
+	case "retention-policies":
+		policies, err := s.client.ShowRetentionPolicies(s.database)
+		if err != nil {
+			return err
+		}
+		series = &opengemini.Series{
+			Name:    "retention policies",
+			Columns: []string{"name", "duration", "shard_group_duration", "replica_num", "default"},
+		}
+		for _, policy := range policies {
+			series.Values = append(series.Values, opengemini.SeriesValue{policy.Name, policy.Duration,
+				policy.ShardGroupDuration, strconv.FormatInt(policy.ReplicaNum, 10), policy.IsDefault})
+		}
+	case "measurements":
+		measurements, err := s.client.ShowMeasurements(opengemini.NewMeasurementBuilder().
+			Database(s.database).RetentionPolicy(s.retentionPolicy).Show())
+		if err != nil {
+			return err
+		}
+		series = stringSeries("measurements", measurements)
+	case "series":
+		builder := opengemini.NewShowSeriesBuilder().Database(s.database).RetentionPolicy(s.retentionPolicy).
+			Measurement(*measurement)
+		if *limit > 0 {
+			builder = builder.Limit(*limit)
+		}
+		keys, err := s.client.ShowSeries(builder)
+		if err != nil {
+			return err
+		}
+		series = stringSeries("series", keys)
+	default:
+		return fmt.Errorf("show: unknown object %q", args[0])
+	}
+	return s.out.print(&opengemini.QueryResult{Results: []*opengemini.SeriesResult{{Series: []*opengemini.Series{series}}}})
+}
+
+func stringSeries(name string, values []string) *opengemini.Series {
+	series := &opengemini.Series{Name: name, Columns: []string{"name"}}
+	for _, value := range values {
+		series.Values = append(series.Values, opengemini.SeriesValue{value})
+	}
+	return series
+}
+
+// isTerminal report whether the prompt should be printed, the piped input such as a script file is not prompted
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
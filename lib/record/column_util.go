@@ -15,6 +15,7 @@
 package record
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -226,3 +227,64 @@ func (cv *ColVal) Marshal(buf []byte) ([]byte, error) {
 	buf = AppendUint32Slice(buf, cv.Offset)
 	return buf, nil
 }
+
+// Unmarshal decode the column written by Marshal, the values are copied so buf can be reused
+func (cv *ColVal) Unmarshal(buf []byte) error {
+	d := &decoder{buf: buf}
+	cv.Len = d.int()
+	cv.NilCount = d.int()
+	cv.BitMapOffset = d.int()
+	cv.Val = d.bytes(cv.Val)
+	cv.Bitmap = d.bytes(cv.Bitmap)
+	cv.Offset = d.uint32Slice(cv.Offset)
+	if err := d.finish(); err != nil {
+		return fmt.Errorf("unmarshal column: %w", err)
+	}
+	return cv.checkLayout()
+}
+
+// checkLayout verify that the bitmap covers all the rows and marks NilCount of them as null, and the offsets are
+// within the values
+func (cv *ColVal) checkLayout() error {
+	if cv.Len < 0 || cv.NilCount < 0 || cv.NilCount > cv.Len {
+		return fmt.Errorf("invalid column: len %d, nil count %d", cv.Len, cv.NilCount)
+	}
+	if cv.BitMapOffset < 0 || cv.BitMapOffset > 7 {
+		return fmt.Errorf("invalid column: bitmap offset %d", cv.BitMapOffset)
+	}
+	// compare without adding to Len, which is decoded from the input and may overflow
+	if cv.Len > 0 && cv.Len > len(cv.Bitmap)*8-cv.BitMapOffset {
+		return fmt.Errorf("invalid column: bitmap of %d bytes for %d rows", len(cv.Bitmap), cv.Len)
+	}
+	nilCount := 0
+	for i := cv.BitMapOffset; i < cv.BitMapOffset+cv.Len; i++ {
+		if cv.Bitmap[i>>3]&BitMask[i&0x07] == 0 {
+			nilCount++
+		}
+	}
+	if nilCount != cv.NilCount {
+		return fmt.Errorf("invalid column: bitmap marks %d null rows, nil count %d", nilCount, cv.NilCount)
+	}
+	for i, off := range cv.Offset {
+		if int(off) > len(cv.Val) || i > 0 && off < cv.Offset[i-1] {
+			return fmt.Errorf("invalid column: offset %d of row %d", off, i)
+		}
+	}
+	return nil
+}
+
+// checkType verify that the size of values matches the type of the column
+func (cv *ColVal) checkType(typ int) error {
+	switch typ {
+	case FieldTypeString, FieldTypeTag:
+		if len(cv.Offset) != cv.Len {
+			return fmt.Errorf("invalid string column: %d offsets for %d rows", len(cv.Offset), cv.Len)
+		}
+	case FieldTypeInt, FieldTypeUInt, FieldTypeFloat, FieldTypeBoolean:
+		if expLen := typeSize[typ] * (cv.Len - cv.NilCount); expLen != len(cv.Val) {
+			return fmt.Errorf("invalid %s column: %d bytes of values for %d non-null rows",
+				FieldTypeName[typ], len(cv.Val), cv.Len-cv.NilCount)
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@
 package record
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -74,3 +75,17 @@ func (f *Field) Size() int {
 	size += SizeOfInt()
 	return size
 }
+
+// Unmarshal decode the field written by Marshal
+func (f *Field) Unmarshal(buf []byte) error {
+	d := &decoder{buf: buf}
+	f.Name = d.string()
+	f.Type = d.int()
+	if err := d.finish(); err != nil {
+		return fmt.Errorf("unmarshal field: %w", err)
+	}
+	if f.Type <= FieldTypeUnknown || f.Type >= FieldTypeLast {
+		return fmt.Errorf("unmarshal field %s: unknown type %d", f.Name, f.Type)
+	}
+	return nil
+}
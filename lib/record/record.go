@@ -71,7 +71,7 @@ func (rec *Record) String() string {
 			line = fmt.Sprintf("field(%v):%#v\n", f.Name, rec.Column(i).StringValues(nil))
 		case FieldTypeBoolean:
 			line = fmt.Sprintf("field(%v):%#v\n", f.Name, rec.Column(i).BooleanValues())
-		case FieldTypeInt:
+		case FieldTypeInt, FieldTypeUInt:
 			line = fmt.Sprintf("field(%v):%#v\n", f.Name, rec.Column(i).IntegerValues())
 		}
 		sb.WriteString(line)
@@ -202,3 +202,44 @@ func (rec *Record) Marshal(buf []byte) ([]byte, error) {
 	}
 	return buf, nil
 }
+
+// Unmarshal decode the record written by Marshal, the columns are checked against the schema so that the values
+// can be read safely afterwards
+func (rec *Record) Unmarshal(buf []byte) error {
+	d := &decoder{buf: buf}
+	schemaN := int(d.uint32())
+	rec.Schema = rec.Schema[:0]
+	for i := 0; i < schemaN && d.err == nil; i++ {
+		var f Field
+		if err := f.Unmarshal(d.take(int(d.uint32()))); err != nil && d.err == nil {
+			return fmt.Errorf("schema %d: %w", i, err)
+		}
+		rec.Schema = append(rec.Schema, f)
+	}
+
+	colN := int(d.uint32())
+	if d.err == nil && colN != schemaN {
+		return fmt.Errorf("unmarshal record: %d columns for %d fields", colN, schemaN)
+	}
+	rec.ColVals = rec.ColVals[:0]
+	for i := 0; i < colN && d.err == nil; i++ {
+		var cv ColVal
+		if err := cv.Unmarshal(d.take(int(d.uint32()))); err != nil && d.err == nil {
+			return fmt.Errorf("column %s: %w", rec.Schema[i].Name, err)
+		}
+		rec.ColVals = append(rec.ColVals, cv)
+	}
+	if err := d.finish(); err != nil {
+		return fmt.Errorf("unmarshal record: %w", err)
+	}
+
+	for i := range rec.ColVals {
+		if err := rec.ColVals[i].checkType(rec.Schema[i].Type); err != nil {
+			return fmt.Errorf("column %s: %w", rec.Schema[i].Name, err)
+		}
+		if rec.ColVals[i].Len != rec.RowNums() {
+			return fmt.Errorf("column %s: %d rows, expect %d", rec.Schema[i].Name, rec.ColVals[i].Len, rec.RowNums())
+		}
+	}
+	return nil
+}
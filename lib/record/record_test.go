@@ -15,6 +15,7 @@
 package record
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -299,3 +300,125 @@ func TestCheckRecord(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestRecordUnmarshal(t *testing.T) {
+	schema := []Field{
+		{Name: "int_field", Type: FieldTypeInt},
+		{Name: "float_field", Type: FieldTypeFloat},
+		{Name: "bool_field", Type: FieldTypeBoolean},
+		{Name: "string_field", Type: FieldTypeString},
+		{Name: "tag", Type: FieldTypeTag},
+		{Name: TimeField, Type: FieldTypeInt},
+	}
+	rec := NewRecordBuilder(schema)
+	rec.ColVals[0].AppendInteger(-123)
+	rec.ColVals[0].AppendIntegerNull()
+	rec.ColVals[1].AppendFloatNull()
+	rec.ColVals[1].AppendFloat(3.14)
+	rec.ColVals[2].AppendBoolean(true)
+	rec.ColVals[2].AppendBoolean(false)
+	rec.ColVals[3].AppendString("test")
+	rec.ColVals[3].AppendStringNull()
+	rec.ColVals[4].AppendString("t1")
+	rec.ColVals[4].AppendString("t2")
+	rec.AppendTime(100, 200)
+
+	buf, err := rec.Marshal(nil)
+	assert.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		var decoded Record
+		assert.NoError(t, decoded.Unmarshal(buf))
+		assert.Equal(t, rec.Schema, decoded.Schema)
+		assert.Equal(t, 2, decoded.RowNums())
+		assert.Equal(t, []int64{-123}, decoded.ColVals[0].IntegerValues())
+		assert.True(t, decoded.ColVals[0].IsNil(1))
+		assert.True(t, decoded.ColVals[1].IsNil(0))
+		assert.Equal(t, []float64{3.14}, decoded.ColVals[1].FloatValues())
+		assert.Equal(t, []bool{true, false}, decoded.ColVals[2].BooleanValues())
+		assert.Equal(t, []string{"test"}, decoded.ColVals[3].StringValues(nil))
+		assert.Equal(t, []string{"t1", "t2"}, decoded.ColVals[4].StringValues(nil))
+		assert.Equal(t, []int64{100, 200}, decoded.Times())
+	})
+
+	t.Run("truncated buffer", func(t *testing.T) {
+		for _, n := range []int{0, 3, len(buf) / 2, len(buf) - 1} {
+			var decoded Record
+			assert.ErrorIs(t, decoded.Unmarshal(buf[:n]), ErrTooSmallBuffer, "length %d", n)
+		}
+	})
+
+	t.Run("trailing bytes", func(t *testing.T) {
+		var decoded Record
+		assert.Error(t, decoded.Unmarshal(append(buf[:len(buf):len(buf)], 0)))
+	})
+
+	t.Run("mismatched rows", func(t *testing.T) {
+		broken := NewRecordBuilder(schema[:1:1])
+		broken.Schema = append(broken.Schema, schema[5])
+		broken.ColVals = append(broken.ColVals, ColVal{})
+		broken.ColVals[0].AppendInteger(1)
+		broken.AppendTime(100, 200)
+		buf, err := broken.Marshal(nil)
+		assert.NoError(t, err)
+
+		var decoded Record
+		assert.Error(t, decoded.Unmarshal(buf))
+	})
+}
+
+func TestRecordUnmarshalMalformed(t *testing.T) {
+	// the columns are an integer with a null row, a string with a null row and the time, all of 2 rows
+	build := func() *Record {
+		rec := NewRecordBuilder([]Field{
+			{Name: "int_field", Type: FieldTypeInt},
+			{Name: "string_field", Type: FieldTypeString},
+			{Name: TimeField, Type: FieldTypeInt},
+		})
+		rec.ColVals[0].AppendInteger(1)
+		rec.ColVals[0].AppendIntegerNull()
+		rec.ColVals[1].AppendString("a")
+		rec.ColVals[1].AppendStringNull()
+		rec.AppendTime(100, 200)
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		modify func(rec *Record)
+	}{
+		{name: "bitmap without the null row", modify: func(rec *Record) { rec.ColVals[0].Bitmap = []byte{0x03} }},
+		{name: "bitmap with more null rows", modify: func(rec *Record) { rec.ColVals[0].Bitmap = []byte{0x00} }},
+		{name: "null rows beyond bitmap offset", modify: func(rec *Record) { rec.ColVals[0].BitMapOffset = 1 }},
+		{name: "short bitmap", modify: func(rec *Record) { rec.ColVals[0].Bitmap = nil }},
+		{name: "bitmap offset out of byte", modify: func(rec *Record) { rec.ColVals[0].BitMapOffset = 8 }},
+		{name: "negative nil count", modify: func(rec *Record) { rec.ColVals[0].NilCount = -1 }},
+		{name: "nil count above rows", modify: func(rec *Record) { rec.ColVals[0].NilCount = 3 }},
+		{name: "overflowing rows", modify: func(rec *Record) {
+			rec.ColVals[0].Len = math.MaxInt
+			rec.ColVals[0].BitMapOffset = 7
+		}},
+		{name: "short values", modify: func(rec *Record) { rec.ColVals[0].Val = rec.ColVals[0].Val[:4] }},
+		{name: "extra values", modify: func(rec *Record) {
+			rec.ColVals[0].Val = append(rec.ColVals[0].Val, make([]byte, 8)...)
+		}},
+		{name: "missing string offset", modify: func(rec *Record) { rec.ColVals[1].Offset = rec.ColVals[1].Offset[:1] }},
+		{name: "string offset beyond values", modify: func(rec *Record) { rec.ColVals[1].Offset[1] = 10 }},
+		{name: "decreasing string offsets", modify: func(rec *Record) {
+			rec.ColVals[1].Offset = []uint32{1, 0}
+		}},
+		{name: "rows of time mismatch", modify: func(rec *Record) { rec.ColVals[2].AppendInteger(300) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := build()
+			tt.modify(rec)
+			buf, err := rec.Marshal(nil)
+			assert.NoError(t, err)
+
+			var decoded Record
+			assert.NotPanics(t, func() { err = decoded.Unmarshal(buf) })
+			assert.Error(t, err)
+		})
+	}
+}
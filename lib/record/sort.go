@@ -270,7 +270,7 @@ func (cv *ColVal) AppendWithNilCount(src *ColVal, colType, start, end int, nc *N
 	switch colType {
 	case FieldTypeString, FieldTypeTag:
 		cv.appendString(src, start, end)
-	case FieldTypeInt, FieldTypeFloat, FieldTypeBoolean:
+	case FieldTypeInt, FieldTypeUInt, FieldTypeFloat, FieldTypeBoolean:
 		size := typeSize[colType]
 		cv.Val = append(cv.Val, src.Val[startOffset*size:endOffset*size]...)
 	default:
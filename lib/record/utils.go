@@ -15,6 +15,8 @@
 package record
 
 import (
+	"errors"
+	"fmt"
 	"unsafe"
 )
 
@@ -37,10 +39,15 @@ var (
 
 func init() {
 	typeSize[FieldTypeInt] = Int64SizeBytes
+	// the unsigned values are stored as the bits of int64
+	typeSize[FieldTypeUInt] = Int64SizeBytes
 	typeSize[FieldTypeFloat] = Float64SizeBytes
 	typeSize[FieldTypeBoolean] = BooleanSizeBytes
 }
 
+// ErrTooSmallBuffer the buffer ends before the value being unmarshalled
+var ErrTooSmallBuffer = errors.New("too small buffer for unmarshal")
+
 type ExceptString interface {
 	int64 | float64 | bool
 }
@@ -122,3 +129,85 @@ func Uint32Slice2byte(u []uint32) []byte {
 	// Create a new byte slice from the pointer
 	return unsafe.Slice((*byte)(ptr), len(u)*Uint32SizeBytes)
 }
+
+// decoder reads the values written by the Append functions, the first error is kept and the following reads return
+// zero values, so the caller checks it once at the end
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.buf) {
+		d.err = fmt.Errorf("%w: need %d bytes, %d left", ErrTooSmallBuffer, n, len(d.buf))
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) uint16() uint16 {
+	b := d.take(sizeOfUint16)
+	if b == nil {
+		return 0
+	}
+	return uint16(b[0])<<8 | uint16(b[1])
+}
+
+func (d *decoder) uint32() uint32 {
+	b := d.take(sizeOfUint32)
+	if b == nil {
+		return 0
+	}
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+func (d *decoder) int64() int64 {
+	b := d.take(Int64SizeBytes)
+	if b == nil {
+		return 0
+	}
+	u := uint64(b[0])<<56 | uint64(b[1])<<48 | uint64(b[2])<<40 | uint64(b[3])<<32 |
+		uint64(b[4])<<24 | uint64(b[5])<<16 | uint64(b[6])<<8 | uint64(b[7])
+	// reverse the zig-zag encoding of AppendInt64
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (d *decoder) int() int {
+	return int(d.int64())
+}
+
+func (d *decoder) string() string {
+	return string(d.take(int(d.uint16())))
+}
+
+// bytes return a copy of the length prefixed bytes, so that the result doesn't refer to the buffer
+func (d *decoder) bytes(dst []byte) []byte {
+	b := d.take(int(d.uint32()))
+	return append(dst[:0], b...)
+}
+
+// uint32Slice read the slice written by AppendUint32Slice in the native byte order
+func (d *decoder) uint32Slice(dst []uint32) []uint32 {
+	n := int(d.uint32())
+	b := d.take(n * Uint32SizeBytes)
+	dst = dst[:0]
+	if len(b) == 0 {
+		return dst
+	}
+	dst = append(dst, make([]uint32, n)...)
+	copy(Uint32Slice2byte(dst), b)
+	return dst
+}
+
+// finish fail if there are bytes left, each value is unmarshalled from a buffer of its own size
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.buf) != 0 {
+		d.err = fmt.Errorf("%d unexpected bytes after the value", len(d.buf))
+	}
+	return d.err
+}
@@ -17,12 +17,16 @@ package opengemini
 import (
 	"context"
 	"crypto/tls"
+	"io"
+	"iter"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"github.com/openGemini/opengemini-client-go/proto"
 )
@@ -52,6 +56,16 @@ const (
 	CompressMethodNone   CompressMethod = "NONE"
 )
 
+// WriteTransport the transport used by the Writer of client
+type WriteTransport string
+
+const (
+	WriteTransportHttp WriteTransport = "HTTP"
+	WriteTransportGrpc WriteTransport = "GRPC"
+)
+
+// InterceptorClosure is called with the response of the request, it isn't called if the request failed before any
+// response was received
 type InterceptorClosure func(ctx context.Context, response *http.Response) error
 
 type Interceptor interface {
@@ -59,11 +73,45 @@ type Interceptor interface {
 	Write(ctx context.Context, write *InterceptorWrite) InterceptorClosure
 }
 
+// InterceptorErrorClosure is called with the error of a request which failed before any response was received
+type InterceptorErrorClosure func(ctx context.Context, err error)
+
+// TransportErrorInterceptor can be implemented by an Interceptor to observe the requests which failed before any
+// response was received, its methods are called instead of Query and Write, and exactly one of the returned closures
+// is called for each request
+type TransportErrorInterceptor interface {
+	QueryWithError(ctx context.Context, query *InterceptorQuery) (InterceptorClosure, InterceptorErrorClosure)
+	WriteWithError(ctx context.Context, write *InterceptorWrite) (InterceptorClosure, InterceptorErrorClosure)
+}
+
+// GrpcInterceptorClosure is called with the result of a write by gRPC, err is the error of the call. ctx is the
+// context of the caller, an error with ctx.Err() != nil means the attempt was abandoned, e.g. a hedged attempt
+// cancelled because another one succeeded
+type GrpcInterceptorClosure func(ctx context.Context, response *proto.WriteResponse, err error) error
+
+// GrpcWriteInterceptor can be implemented by an Interceptor to observe the writes by gRPC
+type GrpcWriteInterceptor interface {
+	GrpcWrite(ctx context.Context, write *InterceptorGrpcWrite) GrpcInterceptorClosure
+}
+
+// BatchInterceptorClosure is called when the flush of a batch is done, err is the error passed to the callbacks
+type BatchInterceptorClosure func(ctx context.Context, err error)
+
+// BatchInterceptor can be implemented by an Interceptor to observe the flushes of the batch queue, the returned
+// context is used to write the batch, so that the write is traced as a child of the flush
+type BatchInterceptor interface {
+	BatchFlush(ctx context.Context, flush *InterceptorBatchFlush) (context.Context, BatchInterceptorClosure)
+}
+
 // Client represents a openGemini client.
 type Client interface {
 	// Ping check that status of cluster.
 	Ping(idx int) error
 	Query(query Query) (*QueryResult, error)
+	// QueryChunked sends a command with `chunked=true` and yields the chunks of result as they are decoded from the
+	// response stream, use it for large results which should not be held in memory at once. Unlike Query, the numbers
+	// in values are decoded as json.Number, so the integers and nanosecond timestamps beyond 2^53 stay exact
+	QueryChunked(ctx context.Context, query Query, chunkSize int) iter.Seq2[*QueryResult, error]
 
 	// Execute executes a SQL-like statement with automatic routing to appropriate methods
 	// Supports INSERT (routed to Write methods), SELECT/SHOW/CREATE/DROP (routed to Query method)
@@ -84,6 +132,8 @@ type Client interface {
 	// WriteByGrpc write batch record to assigned database.retention_policy by gRPC.
 	// You'd better use NewWriteRequestBuilder to build req.
 	WriteByGrpc(ctx context.Context, req *proto.WriteRequest) error
+	// Writer return the Writer of points by Config.WriteTransport, so that the producers don't depend on the transport
+	Writer() Writer
 
 	// CreateDatabase Create database
 	CreateDatabase(database string) error
@@ -125,6 +175,15 @@ type Client interface {
 	// forget to set the database and measurement otherwise it will return an error
 	DropMeasurement(database, retentionPolicy, measurement string) error
 
+	// DropSeries use command `DROP SERIES` to delete the series matching the condition, including index and data,
+	// calling NewDropSeriesBuilder().Database("db0").From("m0").Where(NewComparisonCondition("tag1", Equals, "v1"))
+	// is the best way to set up the builder, the where condition is required to avoid dropping all series by accident
+	DropSeries(builder DropSeriesBuilder) error
+	// Delete use command `DELETE` to delete the data matching the condition and time range, calling
+	// NewDeleteBuilder().Database("db0").From("m0").Where(...).TimeRange(start, end) is the best way to set up the
+	// builder, the where condition or time range is required to avoid deleting all data by accident
+	Delete(builder DeleteBuilder) error
+
 	// ShowTagKeys view all TAG fields in the measurements, return {"measurement_name":["TAG1","TAG2"]}
 	// calling `NewShowTagKeysBuilder().Database("db0").Measurement("m0")...` to setup builder, don't forget to set the
 	// database otherwise it will return an error, if retention policy is empty, use default retention policy `autogen`,
@@ -144,6 +203,84 @@ type Client interface {
 	// it will return an error
 	ShowSeries(builder ShowSeriesBuilder) ([]string, error)
 
+	// CreateUser use command `CREATE USER` to create user, calling
+	// NewCreateUserBuilder().Username("user").Password("pwd") is the best way to set up the builder, call Admin()
+	// to create an admin user
+	CreateUser(builder CreateUserBuilder) error
+	// DropUser use command `DROP USER` to delete user
+	DropUser(username string) error
+	// SetPassword use command `SET PASSWORD` to reset the password of user
+	SetPassword(username, password string) error
+	// ShowUsers use command `SHOW USERS` to view all users and whether they are admin
+	ShowUsers() ([]UserInfo, error)
+	// GrantPrivilege use command `GRANT` to grant privilege on database to user, calling
+	// NewPrivilegeBuilder().Privilege(PrivilegeRead).Database("db0").Username("user") is the best way to set up the
+	// builder, don't forget to set the database and username otherwise it will return an error
+	GrantPrivilege(builder PrivilegeBuilder) error
+	// RevokePrivilege use command `REVOKE` to revoke privilege on database from user, the builder is the same as
+	// GrantPrivilege
+	RevokePrivilege(builder PrivilegeBuilder) error
+	// ShowGrants use command `SHOW GRANTS FOR` to view the database privileges of user
+	ShowGrants(username string) ([]GrantInfo, error)
+	// GrantAdmin use command `GRANT ALL PRIVILEGES` to grant admin privilege to user
+	GrantAdmin(username string) error
+	// RevokeAdmin use command `REVOKE ALL PRIVILEGES` to revoke admin privilege from user
+	RevokeAdmin(username string) error
+
+	// CreateContinuousQuery use command `CREATE CONTINUOUS QUERY` to create continuous query, calling
+	// NewContinuousQueryBuilder().Name("cq").Database("db0").Select(...).Into("rp0", "m1").From("m0").
+	// GroupByTime(time.Hour) is the best way to set up the builder, name, database, into measurement, from
+	// measurements and group by time interval are required
+	CreateContinuousQuery(builder ContinuousQueryBuilder) error
+	// ShowContinuousQueries use command `SHOW CONTINUOUS QUERIES` to view continuous queries of all databases
+	ShowContinuousQueries() ([]ContinuousQuery, error)
+	// DropContinuousQuery use command `DROP CONTINUOUS QUERY` to delete continuous query
+	DropContinuousQuery(database, name string) error
+
+	// CreateDownsample use command `CREATE DOWNSAMPLE` to create downsample policy on retention policy, calling
+	// NewDownsampleBuilder().Database("db0").RetentionPolicy("rp0").Create().
+	//		Aggregate(DownsampleTypeFloat, FunctionSum, FunctionLast).Duration(720 * time.Hour).
+	//		SampleInterval(24 * time.Hour, 48 * time.Hour).TimeInterval(time.Minute, 5 * time.Minute)
+	// is the best way to set up the builder, don't forget to set the database and retention policy otherwise it
+	// will return an error
+	CreateDownsample(builder CreateDownsampleBuilder) error
+	// ShowDownsamples use command `SHOW DOWNSAMPLES` to view downsample policies of database
+	ShowDownsamples(database string) ([]DownsamplePolicy, error)
+	// DropDownsample use command `DROP DOWNSAMPLE` to delete downsample policy on retention policy
+	DropDownsample(database, retentionPolicy string) error
+	// CreateStream use command `CREATE STREAM` to create stream computation task, calling
+	// NewStreamBuilder().Name("stream0").Database("db0").Into("rp0", "m1").Select(...).From("m0").
+	// GroupByTime(time.Minute) is the best way to set up the builder, name, database, into measurement, from
+	// measurement and group by time interval are required
+	CreateStream(builder StreamBuilder) error
+	// ShowStreams use command `SHOW STREAMS` to view stream tasks of database
+	ShowStreams(database string) ([]StreamTask, error)
+	// DropStream use command `DROP STREAM` to delete stream task
+	DropStream(database, name string) error
+
+	// ShowShards use command `SHOW SHARDS` to view the shards of all databases, with parsed times and owners
+	ShowShards() ([]Shard, error)
+	// ShowShardGroups use command `SHOW SHARD GROUPS` to view the shard groups of all databases
+	ShowShardGroups() ([]ShardGroup, error)
+	// ShowCluster use command `SHOW CLUSTER` to view the nodes of cluster
+	ShowCluster() ([]Node, error)
+	// Status check the service status of the server at idx by endpoint `/status`, only the status code is meaningful
+	Status(idx int) error
+
+	// Paginate issue successive requests of pageSize rows and yield the rows until exhausted, builder can be
+	// ShowTagKeysBuilder, ShowTagValuesBuilder, ShowSeriesBuilder or NewPageableQuery("db0", "", queryBuilder),
+	// the raw `SELECT` without GROUP BY is paginated by time cursor, the others are paginated by LIMIT and OFFSET
+	Paginate(ctx context.Context, builder Pageable, pageSize int) iter.Seq2[Row, error]
+
+	// Export run the query in chunks and write the rows into w as CSV or NDJSON, the tags of series are flattened
+	// into columns and the time column follows query.Precision
+	Export(ctx context.Context, query Query, format ExportFormat, w io.Writer) error
+
+	// Import read line protocol or annotated CSV from r, gzip compressed or not, and write it in batches by HTTP or
+	// gRPC, the rejected lines go to ImportConfig.DeadLetter. If a batch fails, resume the import with the returned
+	// ImportProgress.Offset as ImportConfig.Checkpoint
+	Import(ctx context.Context, r io.Reader, config ImportConfig) (ImportProgress, error)
+
 	// Close shut down resources, such as health check tasks
 	Close() error
 
@@ -181,10 +318,20 @@ type Config struct {
 	TlsConfig *tls.Config
 	// CustomMetricsLabels add custom labels to all the metrics reported by this client instance
 	CustomMetricsLabels map[string]string
+	// MetricsConfig configuration information for the latency histograms of the prometheus metrics
+	MetricsConfig *MetricsConfig
 	// Logger structured logger for logging operations
 	Logger *slog.Logger
 	// GrpcConfig configuration information for write service by gRPC
 	GrpcConfig *GrpcConfig
+	// WriteTransport the transport of Client.Writer, default WriteTransportHttp, WriteTransportGrpc requires GrpcConfig
+	WriteTransport WriteTransport
+}
+
+// Writer writes points by HTTP or gRPC, the points are the same whichever transport is used
+type Writer interface {
+	// WritePoints write points to assigned database.retention_policy
+	WritePoints(ctx context.Context, database string, rp string, points []*Point) error
 }
 
 // Address configuration for providing service.
@@ -246,8 +393,64 @@ type GrpcConfig struct {
 	TlsConfig *tls.Config
 	// CompressMethod determines the compress method used for data transmission.
 	CompressMethod CompressMethod
-	// Timeout default 30s
+	// Timeout of each write when the context of caller has no deadline, default 30s
 	Timeout time.Duration
+	// KeepaliveTime interval of the keepalive pings on an idle connection, default 10s
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout wait for the ack of a keepalive ping before closing the connection, default 3s
+	KeepaliveTimeout time.Duration
+	// BackoffBaseDelay delay of the first reconnection after a failure, default 1s
+	BackoffBaseDelay time.Duration
+	// BackoffMaxDelay upper bound of the reconnection delay, default 30s
+	BackoffMaxDelay time.Duration
+	// MinConnectTimeout minimum time to complete a connection, default 20s
+	MinConnectTimeout time.Duration
+	// InitialWindowSize flow control window of each stream in bytes, default 16MB
+	InitialWindowSize int32
+	// InitialConnWindowSize flow control window of each connection in bytes, default 16MB
+	InitialConnWindowSize int32
+	// MaxCallRecvMsgSize maximum size in bytes of a message received, default 64MB
+	MaxCallRecvMsgSize int
+	// MaxCallSendMsgSize maximum size in bytes of a message sent, set it according to the size of the batches,
+	// default 64MB
+	MaxCallSendMsgSize int
+	// HealthCheckInterval interval of the Ping RPC sent to each endpoint, the endpoints failing the ping are skipped
+	// by the writes. Default 10s, a negative value disables the check and only the connectivity state is used
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout timeout of each Ping RPC, default 3s
+	HealthCheckTimeout time.Duration
+	// EjectionThreshold consecutive failures with Unavailable, ResourceExhausted or DeadlineExceeded after which an
+	// endpoint is skipped for EjectionDuration, default 3, a negative value disables the ejection
+	EjectionThreshold int
+	// EjectionDuration how long an ejected endpoint is skipped, default 30s
+	EjectionDuration time.Duration
+	// RetryConfig retry the failed writes on the next endpoint, nil disables the retry
+	RetryConfig *GrpcRetryConfig
+	// UnaryInterceptors are chained in order around every unary call except the Ping RPC of health check, such as
+	// NewOtelGrpcInterceptor or an interceptor attaching the credentials to the metadata
+	UnaryInterceptors []grpc.UnaryClientInterceptor
+	// StreamInterceptors are chained in order around every stream call
+	StreamInterceptors []grpc.StreamClientInterceptor
+	// DialOptions are appended after the default dial options, so they take precedence
+	DialOptions []grpc.DialOption
+}
+
+// GrpcRetryConfig represents the configuration information for retrying the writes by gRPC, every attempt is sent
+// to the next available endpoint. The server overwrites the points with the same series and timestamp, so a write
+// retried after a timeout doesn't duplicate data
+type GrpcRetryConfig struct {
+	// MaxAttempts total number of attempts including the first one, default 3
+	MaxAttempts int
+	// InitialBackoff delay before the first retry, it is doubled for each retry, default 100ms
+	InitialBackoff time.Duration
+	// MaxBackoff upper bound of the delay between attempts, default 2s
+	MaxBackoff time.Duration
+	// RetryableCodes the gRPC status codes which are retried, default Unavailable, ResourceExhausted and
+	// DeadlineExceeded
+	RetryableCodes []codes.Code
+	// HedgingDelay send the write to the next endpoint as well if there is no response after this delay, until
+	// MaxAttempts are in flight, the first success wins. Zero disables hedging
+	HedgingDelay time.Duration
 }
 
 // NewClient Creates a openGemini client instance
@@ -83,12 +83,23 @@ func newClient(c *Config) (Client, error) {
 	if c.ConnectTimeout <= 0 {
 		c.ConnectTimeout = 10 * time.Second
 	}
+	switch c.WriteTransport {
+	case "":
+		c.WriteTransport = WriteTransportHttp
+	case WriteTransportHttp:
+	case WriteTransportGrpc:
+		if c.GrpcConfig == nil {
+			return nil, errors.New("write transport GRPC requires GrpcConfig")
+		}
+	default:
+		return nil, errors.New("unknown write transport: " + string(c.WriteTransport))
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	dbClient := &client{
 		config:             c,
 		endpoints:          buildEndpoints(c.Addresses, c.TlsConfig != nil),
 		cli:                newHttpClient(*c),
-		metrics:            newMetricsProvider(c.CustomMetricsLabels),
+		metrics:            newMetricsProvider(c.CustomMetricsLabels, c.MetricsConfig),
 		batchContext:       ctx,
 		batchContextCancel: cancel,
 	}
@@ -0,0 +1,259 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shard defines the structure for shard info returned by `SHOW SHARDS`
+type Shard struct {
+	ID              uint64
+	Database        string
+	RetentionPolicy string
+	ShardGroup      uint64
+	StartTime       time.Time
+	EndTime         time.Time
+	ExpiryTime      time.Time
+	// Duration the time range covered by the shard, equals EndTime - StartTime
+	Duration time.Duration
+	// Owners the ids of data nodes which own the shard
+	Owners []uint64
+	// Tier the storage tier of the shard, such as `hot`, `warm`, `cold`, empty if server does not report it
+	Tier string
+}
+
+// ShardGroup defines the structure for shard group info returned by `SHOW SHARD GROUPS`
+type ShardGroup struct {
+	ID              uint64
+	Database        string
+	RetentionPolicy string
+	StartTime       time.Time
+	EndTime         time.Time
+	ExpiryTime      time.Time
+	// Duration the time range covered by the shard group, equals EndTime - StartTime
+	Duration time.Duration
+}
+
+// Node defines the structure for cluster node info returned by `SHOW CLUSTER`
+type Node struct {
+	Time         time.Time
+	Status       string
+	Hostname     string
+	NodeID       uint64
+	NodeType     string
+	Availability string
+}
+
+// seriesRow read series value by column name, the columns of introspection commands are different between
+// openGemini versions, so don't rely on the column position
+type seriesRow struct {
+	columns map[string]int
+	value   SeriesValue
+}
+
+func newColumnIndex(columns []string) map[string]int {
+	index := make(map[string]int, len(columns))
+	for i, column := range columns {
+		index[column] = i
+	}
+	return index
+}
+
+func (r seriesRow) get(column string) (any, bool) {
+	idx, ok := r.columns[column]
+	if !ok || idx >= len(r.value) || r.value[idx] == nil {
+		return nil, false
+	}
+	return r.value[idx], true
+}
+
+func (r seriesRow) string(column string) string {
+	v, ok := r.get(column)
+	if !ok {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func (r seriesRow) uint64(column string) uint64 {
+	v, ok := r.get(column)
+	if !ok {
+		return 0
+	}
+	n, _ := seriesValueToInt64(v)
+	return uint64(n)
+}
+
+func (r seriesRow) time(column string) time.Time {
+	v, ok := r.get(column)
+	if !ok {
+		return time.Time{}
+	}
+	if s, ok := v.(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return time.Time{}
+		}
+		return t
+	}
+	if n, ok := seriesValueToInt64(v); ok {
+		return time.Unix(0, n).UTC()
+	}
+	return time.Time{}
+}
+
+func (r seriesRow) owners(column string) []uint64 {
+	v, ok := r.get(column)
+	if !ok {
+		return nil
+	}
+	var items []string
+	switch owners := v.(type) {
+	case string:
+		items = strings.FieldsFunc(strings.Trim(owners, "[]"), func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	case []any:
+		for _, owner := range owners {
+			items = append(items, fmt.Sprintf("%v", owner))
+		}
+	default:
+		items = []string{fmt.Sprintf("%v", owners)}
+	}
+	var result = make([]uint64, 0, len(items))
+	for _, item := range items {
+		id, err := strconv.ParseUint(strings.TrimSpace(item), 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, id)
+	}
+	return result
+}
+
+// seriesValueToInt64 convert number decoded from json or msgpack to int64
+func seriesValueToInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case float32:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case string:
+		i, err := strconv.ParseInt(n, 10, 64)
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// ShowShards use command `SHOW SHARDS` to view the shards of all databases
+func (c *client) ShowShards() ([]Shard, error) {
+	queryResult, err := c.Query(Query{Command: "SHOW SHARDS"})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show shards err: %s", err)
+	}
+
+	return queryResult.convertShards(), nil
+}
+
+// ShowShardGroups use command `SHOW SHARD GROUPS` to view the shard groups of all databases
+func (c *client) ShowShardGroups() ([]ShardGroup, error) {
+	queryResult, err := c.Query(Query{Command: "SHOW SHARD GROUPS"})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show shard groups err: %s", err)
+	}
+
+	return queryResult.convertShardGroups(), nil
+}
+
+// ShowCluster use command `SHOW CLUSTER` to view the nodes of cluster
+func (c *client) ShowCluster() ([]Node, error) {
+	queryResult, err := c.Query(Query{Command: "SHOW CLUSTER"})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show cluster err: %s", err)
+	}
+
+	return queryResult.convertNodes(), nil
+}
+
+// Status check the service status of the server at idx by endpoint `/status`. The server answers a healthy status
+// by the status code only, the body carries no status of its own, so it is drained for reusing the connection and
+// returned in the error only when the status code is unhealthy
+func (c *client) Status(idx int) error {
+	resp, err := c.executeHttpRequestByIdxWithContext(context.TODO(), idx, http.MethodGet, UrlStatus, requestDetails{})
+	if err != nil {
+		return errors.New("status request failed, error: " + err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.New("read status resp failed, error: " + err.Error())
+		}
+		return errors.New("status error resp, code: " + resp.Status + "body: " + string(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
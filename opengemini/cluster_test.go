@@ -0,0 +1,132 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertShards(t *testing.T) {
+	result := &QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Name:    "db0",
+		Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners", "tier"},
+		Values: SeriesValues{
+			{float64(1), "db0", "autogen", float64(2), "2025-01-01T00:00:00Z", "2025-01-08T00:00:00Z", "2025-01-08T00:00:00Z", "1,3", "warm"},
+		},
+	}}}}}
+	shards := result.convertShards()
+	require.Equal(t, 1, len(shards))
+	require.Equal(t, uint64(1), shards[0].ID)
+	require.Equal(t, "db0", shards[0].Database)
+	require.Equal(t, "autogen", shards[0].RetentionPolicy)
+	require.Equal(t, uint64(2), shards[0].ShardGroup)
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), shards[0].StartTime)
+	require.Equal(t, 7*24*time.Hour, shards[0].Duration)
+	require.Equal(t, []uint64{1, 3}, shards[0].Owners)
+	require.Equal(t, "warm", shards[0].Tier)
+}
+
+func TestConvertShardGroups(t *testing.T) {
+	result := &QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Name:    "shard groups",
+		Columns: []string{"id", "database", "retention_policy", "start_time", "end_time", "expiry_time"},
+		Values: SeriesValues{
+			{int64(3), "db0", "rp0", "2025-01-01T00:00:00Z", "2025-01-01T01:00:00Z", "2025-01-02T01:00:00Z"},
+		},
+	}}}}}
+	groups := result.convertShardGroups()
+	require.Equal(t, []ShardGroup{{
+		ID:              3,
+		Database:        "db0",
+		RetentionPolicy: "rp0",
+		StartTime:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+		ExpiryTime:      time.Date(2025, 1, 2, 1, 0, 0, 0, time.UTC),
+		Duration:        time.Hour,
+	}}, groups)
+}
+
+func TestConvertNodes(t *testing.T) {
+	result := &QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Columns: []string{"time", "status", "hostname", "nodeID", "nodeType", "availability"},
+		Values: SeriesValues{
+			{float64(1735689600000000000), "alive", "127.0.0.1:8091", float64(1), "meta", "available"},
+			{"2025-01-01T00:00:00Z", "alive", "127.0.0.1:8400", float64(2), "data", "available"},
+		},
+	}}}}}
+	nodes := result.convertNodes()
+	require.Equal(t, 2, len(nodes))
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nodes[0].Time)
+	require.Equal(t, nodes[0].Time, nodes[1].Time)
+	require.Equal(t, "meta", nodes[0].NodeType)
+	require.Equal(t, uint64(2), nodes[1].NodeID)
+	require.Equal(t, "127.0.0.1:8400", nodes[1].Hostname)
+}
+
+func TestClientStatus(t *testing.T) {
+	var code = http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, UrlStatus, request.URL.Path)
+		writer.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}})
+
+	require.Nil(t, c.Status(0))
+	code = http.StatusServiceUnavailable
+	require.NotNil(t, c.Status(0))
+	require.NotNil(t, c.Status(1))
+}
+
+func TestClientShowShardsAndCluster(t *testing.T) {
+	c := testDefaultClient(t)
+	databaseName := randomDatabaseName()
+	err := c.CreateDatabase(databaseName)
+	require.Nil(t, err)
+	err = c.WriteBatchPoints(context.Background(), databaseName, []*Point{{
+		Measurement: randomMeasurement(),
+		Fields:      map[string]interface{}{"v": 1},
+	}})
+	require.Nil(t, err)
+
+	shards, err := c.ShowShards()
+	require.Nil(t, err)
+	var found bool
+	for _, shard := range shards {
+		if shard.Database == databaseName {
+			found = true
+			require.True(t, shard.Duration > 0)
+		}
+	}
+	require.True(t, found)
+
+	groups, err := c.ShowShardGroups()
+	require.Nil(t, err)
+	require.NotEqual(t, 0, len(groups))
+
+	nodes, err := c.ShowCluster()
+	require.Nil(t, err)
+	require.NotEqual(t, 0, len(nodes))
+
+	err = c.DropDatabase(databaseName)
+	require.Nil(t, err)
+}
@@ -113,7 +113,7 @@ func (c *client) ShowFieldKeys(database string, measurements ...string) (map[str
 
 	var command = "SHOW FIELD KEYS"
 	if measurement != "" {
-		command += " FROM " + measurement
+		command += " FROM " + QuoteIdentifier(measurement)
 	}
 
 	queryResult, err := c.Query(Query{Database: database, Command: command})
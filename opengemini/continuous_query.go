@@ -0,0 +1,245 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ContinuousQuery defines the structure for continuous query info
+type ContinuousQuery struct {
+	// Database the database which continuous query belongs to
+	Database string
+	Name     string
+	// Query the full `CREATE CONTINUOUS QUERY` statement
+	Query string
+}
+
+func (cq *ContinuousQuery) SetName(value SeriesValue) error {
+	name, ok := value[0].(string)
+	if !ok {
+		return fmt.Errorf("set ContinuousQuery name: name must be a string")
+	}
+	cq.Name = name
+	return nil
+}
+
+func (cq *ContinuousQuery) SetQuery(value SeriesValue) error {
+	query, ok := value[1].(string)
+	if !ok {
+		return fmt.Errorf("set ContinuousQuery query: query must be a string")
+	}
+	cq.Query = query
+	return nil
+}
+
+func NewContinuousQuery(database string, value SeriesValue) *ContinuousQuery {
+	cq := &ContinuousQuery{Database: database}
+	if !errors.Is(cq.SetName(value), nil) ||
+		!errors.Is(cq.SetQuery(value), nil) {
+		return nil
+	}
+	return cq
+}
+
+// ContinuousQueryBuilder build command `CREATE CONTINUOUS QUERY`, such as
+//
+//	CREATE CONTINUOUS QUERY "cq" ON "db" RESAMPLE EVERY 1h FOR 2h
+//	BEGIN SELECT MEAN("v") INTO "rp"."mst" FROM "src" GROUP BY time(30m) END
+type ContinuousQueryBuilder interface {
+	// Name specify continuous query name
+	Name(name string) ContinuousQueryBuilder
+	// Database specify the database which continuous query runs on
+	Database(database string) ContinuousQueryBuilder
+	// ResampleEvery specify how often the continuous query runs, default is the GROUP BY time interval
+	ResampleEvery(every time.Duration) ContinuousQueryBuilder
+	// ResampleFor specify the time range covered by each run, default is the GROUP BY time interval
+	ResampleFor(duration time.Duration) ContinuousQueryBuilder
+	// Select specify the select expressions, the same as QueryBuilder.Select
+	Select(selectExpressions ...Expression) ContinuousQueryBuilder
+	// Into specify the destination measurement, if retention policy is empty, use default retention policy
+	Into(retentionPolicy, measurement string) ContinuousQueryBuilder
+	// From specify the source measurements
+	From(measurements ...string) ContinuousQueryBuilder
+	// Where filter source data, the same as QueryBuilder.Where
+	Where(condition Condition) ContinuousQueryBuilder
+	// GroupByTime specify the interval of GROUP BY time(), it is required
+	GroupByTime(interval time.Duration) ContinuousQueryBuilder
+	// GroupBy specify the other group by expressions, such as tag fields or `*`
+	GroupBy(groupByExpressions ...Expression) ContinuousQueryBuilder
+	build() (string, error)
+}
+
+type continuousQueryBuilder struct {
+	name          string
+	database      string
+	resampleEvery time.Duration
+	resampleFor   time.Duration
+	query         *QueryBuilder
+}
+
+func NewContinuousQueryBuilder() ContinuousQueryBuilder {
+	return &continuousQueryBuilder{query: CreateQueryBuilder()}
+}
+
+func (c *continuousQueryBuilder) Name(name string) ContinuousQueryBuilder {
+	c.name = name
+	return c
+}
+
+func (c *continuousQueryBuilder) Database(database string) ContinuousQueryBuilder {
+	c.database = database
+	return c
+}
+
+func (c *continuousQueryBuilder) ResampleEvery(every time.Duration) ContinuousQueryBuilder {
+	c.resampleEvery = every
+	return c
+}
+
+func (c *continuousQueryBuilder) ResampleFor(duration time.Duration) ContinuousQueryBuilder {
+	c.resampleFor = duration
+	return c
+}
+
+func (c *continuousQueryBuilder) Select(selectExpressions ...Expression) ContinuousQueryBuilder {
+	c.query.Select(selectExpressions...)
+	return c
+}
+
+func (c *continuousQueryBuilder) Into(retentionPolicy, measurement string) ContinuousQueryBuilder {
+	c.query.Into("", retentionPolicy, measurement)
+	return c
+}
+
+func (c *continuousQueryBuilder) From(measurements ...string) ContinuousQueryBuilder {
+	c.query.From(measurements...)
+	return c
+}
+
+func (c *continuousQueryBuilder) Where(condition Condition) ContinuousQueryBuilder {
+	c.query.Where(condition)
+	return c
+}
+
+func (c *continuousQueryBuilder) GroupByTime(interval time.Duration) ContinuousQueryBuilder {
+	c.query.GroupByTime(interval, 0)
+	return c
+}
+
+func (c *continuousQueryBuilder) GroupBy(groupByExpressions ...Expression) ContinuousQueryBuilder {
+	c.query.GroupBy(groupByExpressions...)
+	return c
+}
+
+func (c *continuousQueryBuilder) build() (string, error) {
+	if len(c.name) == 0 {
+		return "", ErrEmptyContinuousQueryName
+	}
+	if err := checkDatabaseName(c.database); err != nil {
+		return "", err
+	}
+	if err := checkMeasurementName(c.query.intoMeasurement); err != nil {
+		return "", fmt.Errorf("into: %w", err)
+	}
+	if len(c.query.from) == 0 {
+		return "", fmt.Errorf("from: %w", ErrEmptyMeasurement)
+	}
+	if c.query.groupByTime <= 0 {
+		return "", errors.New("group by time interval must be greater than 0")
+	}
+	if c.resampleEvery < 0 || c.resampleFor < 0 {
+		return "", errors.New("resample duration must not be negative")
+	}
+
+	var buf strings.Builder
+	buf.WriteString("CREATE CONTINUOUS QUERY " + QuoteIdentifier(c.name) + " ON " + QuoteIdentifier(c.database))
+	if c.resampleEvery > 0 || c.resampleFor > 0 {
+		buf.WriteString(" RESAMPLE")
+		if c.resampleEvery > 0 {
+			buf.WriteString(" EVERY " + formatDuration(c.resampleEvery))
+		}
+		if c.resampleFor > 0 {
+			buf.WriteString(" FOR " + formatDuration(c.resampleFor))
+		}
+	}
+	buf.WriteString(" BEGIN ")
+	buf.WriteString(c.query.buildSelect())
+	buf.WriteString(c.query.buildInto())
+	buf.WriteString(c.query.buildFrom(nil))
+	buf.WriteString(c.query.buildWhere(nil))
+	buf.WriteString(c.query.buildGroupBy())
+	buf.WriteString(" END")
+	return buf.String(), nil
+}
+
+// CreateContinuousQuery use command `CREATE CONTINUOUS QUERY` to create continuous query
+func (c *client) CreateContinuousQuery(builder ContinuousQueryBuilder) error {
+	command, err := builder.build()
+	if err != nil {
+		return err
+	}
+
+	queryResult, err := c.queryPost(Query{Command: command})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("create continuous query %w", err)
+	}
+	return nil
+}
+
+// ShowContinuousQueries use command `SHOW CONTINUOUS QUERIES` to view continuous queries of all databases
+func (c *client) ShowContinuousQueries() ([]ContinuousQuery, error) {
+	queryResult, err := c.Query(Query{Command: "SHOW CONTINUOUS QUERIES"})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show continuous queries err: %s", err)
+	}
+
+	return queryResult.convertContinuousQueryList(), nil
+}
+
+// DropContinuousQuery use command `DROP CONTINUOUS QUERY` to delete continuous query
+func (c *client) DropContinuousQuery(database, name string) error {
+	if err := checkDatabaseName(database); err != nil {
+		return err
+	}
+	if len(name) == 0 {
+		return ErrEmptyContinuousQueryName
+	}
+
+	cmd := "DROP CONTINUOUS QUERY " + QuoteIdentifier(name) + " ON " + QuoteIdentifier(database)
+	queryResult, err := c.queryPost(Query{Command: cmd})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("drop continuous query %w", err)
+	}
+	return nil
+}
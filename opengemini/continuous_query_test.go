@@ -0,0 +1,112 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinuousQueryBuilder(t *testing.T) {
+	command, err := NewContinuousQueryBuilder().Name("cq0").Database("db0").
+		Select(NewFunctionExpression(FunctionMean, NewFieldExpression("water_level"))).
+		Into("rp0", "average_water_level").From("h2o_feet").GroupByTime(30 * time.Minute).build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE CONTINUOUS QUERY "cq0" ON "db0" BEGIN SELECT MEAN("water_level") INTO "rp0"."average_water_level" FROM "h2o_feet" GROUP BY time(30m) END`, command)
+
+	command, err = NewContinuousQueryBuilder().Name("cq0").Database("db0").
+		ResampleEvery(time.Hour).ResampleFor(48*time.Hour).
+		Select(NewFunctionExpression(FunctionMax, NewFieldExpression("water_level"))).
+		Into("", "max_water_level").From("h2o_feet").
+		Where(NewComparisonCondition("location", Equals, "santa_monica")).
+		GroupByTime(time.Hour).GroupBy(NewStarExpression()).build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE CONTINUOUS QUERY "cq0" ON "db0" RESAMPLE EVERY 1h FOR 2d BEGIN SELECT MAX("water_level") INTO "max_water_level" FROM "h2o_feet" WHERE "location" = 'santa_monica' GROUP BY time(1h), * END`, command)
+}
+
+func TestContinuousQueryBuilderError(t *testing.T) {
+	_, err := NewContinuousQueryBuilder().Database("db0").Into("", "m1").From("m0").GroupByTime(time.Hour).build()
+	require.ErrorIs(t, err, ErrEmptyContinuousQueryName)
+
+	_, err = NewContinuousQueryBuilder().Name("cq0").Into("", "m1").From("m0").GroupByTime(time.Hour).build()
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+
+	_, err = NewContinuousQueryBuilder().Name("cq0").Database("db0").From("m0").GroupByTime(time.Hour).build()
+	require.ErrorIs(t, err, ErrEmptyMeasurement)
+
+	_, err = NewContinuousQueryBuilder().Name("cq0").Database("db0").Into("", "m1").GroupByTime(time.Hour).build()
+	require.ErrorIs(t, err, ErrEmptyMeasurement)
+
+	_, err = NewContinuousQueryBuilder().Name("cq0").Database("db0").Into("", "m1").From("m0").build()
+	require.NotNil(t, err)
+}
+
+func TestFormatDuration(t *testing.T) {
+	require.Equal(t, "0s", formatDuration(0))
+	require.Equal(t, "2w", formatDuration(14*24*time.Hour))
+	require.Equal(t, "3d", formatDuration(72*time.Hour))
+	require.Equal(t, "90m", formatDuration(90*time.Minute))
+	require.Equal(t, "1500ms", formatDuration(1500*time.Millisecond))
+	require.Equal(t, "10u", formatDuration(10*time.Microsecond))
+	require.Equal(t, "7ns", formatDuration(7))
+}
+
+func TestConvertContinuousQueryList(t *testing.T) {
+	result := &QueryResult{Results: []*SeriesResult{{Series: []*Series{
+		{Name: "db0", Columns: []string{"name", "query"}, Values: SeriesValues{{"cq0", "CREATE CONTINUOUS QUERY cq0"}}},
+		{Name: "db1", Columns: []string{"name", "query"}},
+	}}}}
+	require.Equal(t, []ContinuousQuery{{Database: "db0", Name: "cq0", Query: "CREATE CONTINUOUS QUERY cq0"}},
+		result.convertContinuousQueryList())
+}
+
+func TestClientContinuousQuery(t *testing.T) {
+	c := testDefaultClient(t)
+	databaseName := randomDatabaseName()
+	cqName := "cq_" + randomMeasurement()
+	err := c.CreateDatabase(databaseName)
+	require.Nil(t, err)
+
+	err = c.CreateContinuousQuery(NewContinuousQueryBuilder().Name(cqName).Database(databaseName).
+		ResampleEvery(time.Hour).ResampleFor(2*time.Hour).
+		Select(NewFunctionExpression(FunctionMean, NewFieldExpression("value"))).
+		Into("", "mean_value").From("cpu").GroupByTime(time.Hour).GroupBy(NewStarExpression()))
+	require.Nil(t, err)
+
+	cqs, err := c.ShowContinuousQueries()
+	require.Nil(t, err)
+	var found bool
+	for _, cq := range cqs {
+		if cq.Database == databaseName && cq.Name == cqName {
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	err = c.DropContinuousQuery(databaseName, cqName)
+	require.Nil(t, err)
+	err = c.DropDatabase(databaseName)
+	require.Nil(t, err)
+}
+
+func TestClientDropContinuousQueryEmptyName(t *testing.T) {
+	c := testDefaultClient(t)
+	err := c.DropContinuousQuery("", "cq0")
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+	err = c.DropContinuousQuery("db0", "")
+	require.ErrorIs(t, err, ErrEmptyContinuousQueryName)
+}
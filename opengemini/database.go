@@ -25,7 +25,7 @@ func (c *client) CreateDatabase(database string) error {
 		return err
 	}
 
-	cmd := fmt.Sprintf("CREATE DATABASE \"%s\"", database)
+	cmd := "CREATE DATABASE " + QuoteIdentifier(database)
 	queryResult, err := c.queryPost(Query{Command: cmd})
 	if err != nil {
 		return err
@@ -45,15 +45,19 @@ func (c *client) CreateDatabaseWithRp(database string, rpConfig RpConfig) error
 		return err
 	}
 
+	if err = rpConfig.checkDurations(); err != nil {
+		return err
+	}
+
 	var buf strings.Builder
-	buf.WriteString(fmt.Sprintf("CREATE DATABASE \"%s\" WITH DURATION %s REPLICATION 1", database, rpConfig.Duration))
+	buf.WriteString(fmt.Sprintf("CREATE DATABASE %s WITH DURATION %s REPLICATION 1", QuoteIdentifier(database), rpConfig.Duration))
 	if len(rpConfig.ShardGroupDuration) > 0 {
 		buf.WriteString(fmt.Sprintf(" SHARD DURATION %s", rpConfig.ShardGroupDuration))
 	}
 	if len(rpConfig.IndexDuration) > 0 {
 		buf.WriteString(fmt.Sprintf(" INDEX DURATION %s", rpConfig.IndexDuration))
 	}
-	buf.WriteString(fmt.Sprintf(" NAME %s", rpConfig.Name))
+	buf.WriteString(" NAME " + QuoteIdentifier(rpConfig.Name))
 	queryResult, err := c.queryPost(Query{Command: buf.String()})
 	if err != nil {
 		return err
@@ -103,7 +107,7 @@ func (c *client) DropDatabase(database string) error {
 		return err
 	}
 
-	cmd := fmt.Sprintf("DROP DATABASE \"%s\"", database)
+	cmd := "DROP DATABASE " + QuoteIdentifier(database)
 	queryResult, err := c.queryPost(Query{Command: cmd})
 	if err != nil {
 		return err
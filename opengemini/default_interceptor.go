@@ -17,20 +17,33 @@ package opengemini
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/openGemini/opengemini-client-go/proto"
 )
 
 const (
 	TraceName                   = "opengemini-client-go"
 	SpanNameQuery               = "query"
 	SpanNameWrite               = "write"
+	SpanNameGrpcWrite           = "grpc_write"
+	SpanNameBatchFlush          = "batch_flush"
 	AttributeDatabase           = "db"
 	AttributeRetentionPolicy    = "rp"
 	AttributeMeasurement        = "mst"
@@ -39,17 +52,42 @@ const (
 	AttributeResponseStatusCode = "status-code"
 	AttributeResponseBody       = "response-body"
 	AttributeWriteLineProtocol  = "lp"
+	AttributeBatchPoints        = "batch-points"
+	AttributeGrpcRecords        = "grpc-records"
+	// semantic convention attributes, see https://opentelemetry.io/docs/specs/semconv/database/
+	AttributeDbSystem      = "db.system"
+	AttributeDbName        = "db.name"
+	AttributeDbOperation   = "db.operation"
+	AttributeServerAddress = "server.address"
+	AttributeServerPort    = "server.port"
+	AttributeErrorType     = "error.type"
+)
+
+const (
+	DbSystemOpenGemini = "opengemini"
+	// DefaultMaxAttributeLength the captured command, line protocol and response body are truncated to it
+	DefaultMaxAttributeLength = 1024
 )
 
 var (
 	tracer = otel.Tracer(TraceName)
 )
 
+var passwordPattern = regexp.MustCompile(`(?i)(\bpassword(?:\s+for\s+(?:"(?:[^"\\]|\\.)*"|[^\s=]+)\s*=)?\s*)` +
+	`('(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*")`)
+
+// RedactPassword replace the quoted passwords in the command, such as CREATE USER and SET PASSWORD, with '******'
+func RedactPassword(command string) string {
+	return passwordPattern.ReplaceAllString(command, "$1'******'")
+}
+
 type InterceptorQuery struct {
 	*Query
-	Ctx     context.Context
-	Span    trace.Span
-	Carrier propagation.TextMapCarrier
+	// ServerAddress host:port of the server which receives the request
+	ServerAddress string
+	Ctx           context.Context
+	Span          trace.Span
+	Carrier       propagation.TextMapCarrier
 }
 
 type InterceptorWrite struct {
@@ -58,89 +96,357 @@ type InterceptorWrite struct {
 	Measurement     string
 	LineProtocol    string
 	Precision       string
-	Ctx             context.Context
-	Span            trace.Span
-	Carrier         propagation.TextMapCarrier
+	// ServerAddress host:port of the server which receives the request
+	ServerAddress string
+	Ctx           context.Context
+	Span          trace.Span
+	Carrier       propagation.TextMapCarrier
+}
+
+// InterceptorGrpcWrite carries the write request by gRPC
+type InterceptorGrpcWrite struct {
+	Request *proto.WriteRequest
+	// ServerAddress host:port of the gRPC endpoint which receives the request
+	ServerAddress string
 }
 
+// InterceptorBatchFlush describe a flush of the batch queue of a database and retention policy
+type InterceptorBatchFlush struct {
+	Database        string
+	RetentionPolicy string
+	Points          int
+}
+
+// OtelConfig represents the configuration information for the OpenTelemetry interceptor
+type OtelConfig struct {
+	// TracerProvider create the spans, default otel.GetTracerProvider()
+	TracerProvider trace.TracerProvider
+	// MeterProvider create the metric instruments, default otel.GetMeterProvider()
+	MeterProvider metric.MeterProvider
+	// CaptureCommand record the query command as span attribute
+	CaptureCommand bool
+	// CaptureLineProtocol record the line protocol of writes as span attribute
+	CaptureLineProtocol bool
+	// CaptureResponseBody record the response body of queries as span attribute, only the first
+	// MaxAttributeLength bytes are read ahead, so that large and chunked responses are still streamed
+	CaptureResponseBody bool
+	// MaxAttributeLength truncate the captured values to this number of bytes, default DefaultMaxAttributeLength
+	MaxAttributeLength int
+	// Redact rewrite the captured values before they are recorded, default RedactPassword
+	Redact func(value string) string
+}
+
+var (
+	_ Interceptor               = (*OtelClient)(nil)
+	_ TransportErrorInterceptor = (*OtelClient)(nil)
+)
+
 type OtelClient struct {
+	config  OtelConfig
+	tracer  trace.Tracer
+	metrics *otelMetrics
 }
 
+// NewOtelInterceptor create an interceptor which traces the requests and records the metrics with the global
+// providers, only the query command is captured
 func NewOtelInterceptor() Interceptor {
-	return &OtelClient{}
+	return NewOtelInterceptorWithConfig(&OtelConfig{CaptureCommand: true})
 }
 
-func (o *OtelClient) Query(ctx context.Context, query *InterceptorQuery) InterceptorClosure {
-	if query.Carrier != nil {
-		ctx = otel.GetTextMapPropagator().Extract(ctx, query.Carrier)
-	}
-	var span trace.Span
+// NewOtelInterceptorWithConfig create an interceptor with config, the returned interceptor also traces the writes
+// by gRPC and the flushes of the batch queue
+func NewOtelInterceptorWithConfig(config *OtelConfig) Interceptor {
+	return newOtelClient(config)
+}
 
-	if query.Span != nil {
-		span = query.Span
+func newOtelClient(config *OtelConfig) *OtelClient {
+	o := &OtelClient{config: *config}
+	if o.config.MaxAttributeLength <= 0 {
+		o.config.MaxAttributeLength = DefaultMaxAttributeLength
+	}
+	if o.config.Redact == nil {
+		o.config.Redact = RedactPassword
+	}
+	if o.config.TracerProvider != nil {
+		o.tracer = o.config.TracerProvider.Tracer(TraceName)
 	} else {
-		ctx, span = tracer.Start(ctx, SpanNameQuery)
+		o.tracer = tracer
 	}
+	meterProvider := o.config.MeterProvider
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	o.metrics = newOtelMetrics(meterProvider.Meter(TraceName))
+	return o
+}
 
-	if query.Carrier != nil {
-		otel.GetTextMapPropagator().Inject(ctx, query.Carrier)
+// capture redact and truncate value so that it is safe to record as attribute
+func (o *OtelClient) capture(value string) string {
+	value = o.config.Redact(value)
+	if len(value) <= o.config.MaxAttributeLength {
+		return value
+	}
+	cut := o.config.MaxAttributeLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
 	}
+	return value[:cut] + "..."
+}
 
-	span.SetAttributes(attribute.String(AttributeDatabase, query.Database))
-	span.SetAttributes(attribute.String(AttributeRetentionPolicy, query.RetentionPolicy))
-	span.SetAttributes(attribute.String(AttributePrecision, query.Precision.Epoch()))
-	span.SetAttributes(attribute.String(AttributeCommand, query.Command))
+func (o *OtelClient) startSpan(ctx context.Context, name string, span trace.Span, carrier propagation.TextMapCarrier) (context.Context, trace.Span) {
+	if carrier != nil {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	}
+	if span == nil {
+		ctx, span = o.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	}
+	if carrier != nil {
+		otel.GetTextMapPropagator().Inject(ctx, carrier)
+	}
+	return ctx, span
+}
 
-	return func(ctx context.Context, response *http.Response) error {
-		defer span.End()
-		if response == nil {
-			fmt.Println("otel interceptor query response body is nil")
-			return nil // when otel makes an error, it does not affect the main business process
-		}
+func (o *OtelClient) Query(ctx context.Context, query *InterceptorQuery) InterceptorClosure {
+	closure, _ := o.QueryWithError(ctx, query)
+	return closure
+}
 
-		var buf bytes.Buffer
-		tee := io.TeeReader(response.Body, &buf)
-		data, err := io.ReadAll(tee)
-		if err != nil {
-			fmt.Println("otel interceptor read query response body failed: " + err.Error())
-			return nil
-		}
-		response.Body = io.NopCloser(&buf)
+// QueryWithError end the span of query with the error when the request failed before any response was received
+func (o *OtelClient) QueryWithError(ctx context.Context, query *InterceptorQuery) (InterceptorClosure, InterceptorErrorClosure) {
+	_, span := o.startSpan(ctx, SpanNameQuery, query.Span, query.Carrier)
+	operation := queryOperation(query.Command)
+	attrs := []attribute.KeyValue{
+		attribute.String(AttributeDbSystem, DbSystemOpenGemini),
+		attribute.String(AttributeDbName, query.Database),
+		attribute.String(AttributeDbOperation, operation),
+		attribute.String(AttributeDatabase, query.Database),
+		attribute.String(AttributeRetentionPolicy, query.RetentionPolicy),
+		attribute.String(AttributePrecision, query.Precision.Epoch()),
+	}
+	attrs = append(attrs, serverAttributes(query.ServerAddress)...)
+	if o.config.CaptureCommand {
+		attrs = append(attrs, attribute.String(AttributeCommand, o.capture(query.Command)))
+	}
+	span.SetAttributes(attrs...)
+	startAt := time.Now()
+
+	closure := func(ctx context.Context, response *http.Response) error {
+		defer span.End()
+		o.metrics.record(ctx, operation, query.Database, query.ServerAddress, time.Since(startAt), 0, response.ContentLength)
 
 		span.SetAttributes(attribute.Int(AttributeResponseStatusCode, response.StatusCode))
-		span.SetAttributes(attribute.String(AttributeResponseBody, string(data)))
+		if response.StatusCode >= http.StatusBadRequest {
+			o.endWithError(ctx, span, SpanNameQuery, operation, query.Database, query.ServerAddress, strconv.Itoa(response.StatusCode))
+		}
+		if o.config.CaptureResponseBody {
+			head, err := io.ReadAll(io.LimitReader(response.Body, int64(o.config.MaxAttributeLength)+1))
+			if err != nil {
+				otel.Handle(err)
+			}
+			response.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(head), response.Body), response.Body}
+			span.SetAttributes(attribute.String(AttributeResponseBody, o.capture(string(head))))
+		}
 		return nil
 	}
+	return closure, func(ctx context.Context, err error) {
+		defer span.End()
+		span.RecordError(err)
+		o.endWithError(ctx, span, SpanNameQuery, operation, query.Database, query.ServerAddress, errorTypeNetwork)
+	}
 }
 
 func (o *OtelClient) Write(ctx context.Context, write *InterceptorWrite) InterceptorClosure {
-	if write.Carrier != nil {
-		ctx = otel.GetTextMapPropagator().Extract(ctx, write.Carrier)
-	}
-	var span trace.Span
+	closure, _ := o.WriteWithError(ctx, write)
+	return closure
+}
 
-	if write.Span != nil {
-		span = write.Span
-	} else {
-		ctx, span = tracer.Start(ctx, SpanNameWrite)
+// WriteWithError end the span of write with the error when the request failed before any response was received
+func (o *OtelClient) WriteWithError(ctx context.Context, write *InterceptorWrite) (InterceptorClosure, InterceptorErrorClosure) {
+	_, span := o.startSpan(ctx, SpanNameWrite, write.Span, write.Carrier)
+	attrs := []attribute.KeyValue{
+		attribute.String(AttributeDbSystem, DbSystemOpenGemini),
+		attribute.String(AttributeDbName, write.Database),
+		attribute.String(AttributeDbOperation, SpanNameWrite),
+		attribute.String(AttributeDatabase, write.Database),
+		attribute.String(AttributeRetentionPolicy, write.RetentionPolicy),
+		attribute.String(AttributePrecision, write.Precision),
 	}
+	attrs = append(attrs, serverAttributes(write.ServerAddress)...)
+	if o.config.CaptureLineProtocol {
+		attrs = append(attrs, attribute.String(AttributeWriteLineProtocol, o.capture(write.LineProtocol)))
+	}
+	span.SetAttributes(attrs...)
+	startAt := time.Now()
 
-	if write.Carrier != nil {
-		otel.GetTextMapPropagator().Inject(ctx, write.Carrier)
+	closure := func(ctx context.Context, response *http.Response) error {
+		defer span.End()
+		o.metrics.record(ctx, SpanNameWrite, write.Database, write.ServerAddress, time.Since(startAt),
+			int64(len(write.LineProtocol)), response.ContentLength)
+		span.SetAttributes(attribute.Int(AttributeResponseStatusCode, response.StatusCode))
+		if response.StatusCode >= http.StatusBadRequest {
+			o.endWithError(ctx, span, SpanNameWrite, SpanNameWrite, write.Database, write.ServerAddress, strconv.Itoa(response.StatusCode))
+		}
+		return nil
+	}
+	return closure, func(ctx context.Context, err error) {
+		defer span.End()
+		span.RecordError(err)
+		o.endWithError(ctx, span, SpanNameWrite, SpanNameWrite, write.Database, write.ServerAddress, errorTypeNetwork)
 	}
+}
 
-	span.SetAttributes(attribute.String(AttributeDatabase, write.Database))
-	span.SetAttributes(attribute.String(AttributeRetentionPolicy, write.RetentionPolicy))
-	span.SetAttributes(attribute.String(AttributePrecision, write.Precision))
-	span.SetAttributes(attribute.String(AttributeWriteLineProtocol, write.LineProtocol))
+func (o *OtelClient) GrpcWrite(ctx context.Context, write *InterceptorGrpcWrite) GrpcInterceptorClosure {
+	ctx, span := o.startSpan(ctx, SpanNameGrpcWrite, nil, nil)
+	database := write.Request.GetDatabase()
+	attrs := []attribute.KeyValue{
+		attribute.String(AttributeDbSystem, DbSystemOpenGemini),
+		attribute.String(AttributeDbName, database),
+		attribute.String(AttributeDbOperation, SpanNameGrpcWrite),
+		attribute.String(AttributeDatabase, database),
+		attribute.String(AttributeRetentionPolicy, write.Request.GetRetentionPolicy()),
+		attribute.Int(AttributeGrpcRecords, len(write.Request.GetRecords())),
+	}
+	span.SetAttributes(append(attrs, serverAttributes(write.ServerAddress)...)...)
+	o.metrics.grpcRecords.Add(ctx, int64(len(write.Request.GetRecords())),
+		metric.WithAttributes(attribute.String(AttributeDbName, database)))
+	startAt := time.Now()
 
-	return func(ctx context.Context, response *http.Response) error {
+	return func(ctx context.Context, response *proto.WriteResponse, err error) error {
 		defer span.End()
-		if response == nil {
-			fmt.Println("otel interceptor write response body is nil")
+		if err != nil && ctx.Err() != nil {
+			// abandoned by the caller, e.g. a hedged attempt which lost the race, it's not a failure of the server
 			return nil
 		}
-		span.SetAttributes(attribute.Int(AttributeResponseStatusCode, response.StatusCode))
+		if err != nil {
+			span.RecordError(err)
+			o.endWithError(ctx, span, SpanNameGrpcWrite, SpanNameGrpcWrite, database, write.ServerAddress, status.Code(err).String())
+			return nil
+		}
+		o.metrics.record(ctx, SpanNameGrpcWrite, database, write.ServerAddress, time.Since(startAt),
+			int64(protobuf.Size(write.Request)), int64(protobuf.Size(response)))
+		if response.GetCode() != proto.ResponseCode_Success {
+			o.endWithError(ctx, span, SpanNameGrpcWrite, SpanNameGrpcWrite, database, write.ServerAddress, response.GetCode().String())
+		}
+		return nil
+	}
+}
+
+func (o *OtelClient) BatchFlush(ctx context.Context, flush *InterceptorBatchFlush) (context.Context, BatchInterceptorClosure) {
+	ctx, span := o.tracer.Start(ctx, SpanNameBatchFlush, trace.WithAttributes(
+		attribute.String(AttributeDbSystem, DbSystemOpenGemini),
+		attribute.String(AttributeDbName, flush.Database),
+		attribute.String(AttributeDatabase, flush.Database),
+		attribute.String(AttributeRetentionPolicy, flush.RetentionPolicy),
+		attribute.Int(AttributeBatchPoints, flush.Points),
+	))
+	o.metrics.batchPoints.Add(ctx, int64(flush.Points), metric.WithAttributes(attribute.String(AttributeDbName, flush.Database)))
+
+	return ctx, func(ctx context.Context, err error) {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
+
+// endWithError mark the span as failed and count the error, errorType is the status code of the response if
+// there is one
+func (o *OtelClient) endWithError(ctx context.Context, span trace.Span, name, operation, database, address, errorType string) {
+	span.SetStatus(codes.Error, name+" failed: "+errorType)
+	span.SetAttributes(attribute.String(AttributeErrorType, errorType))
+	o.metrics.errors.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(AttributeDbOperation, operation),
+		attribute.String(AttributeDbName, database),
+		attribute.String(AttributeServerAddress, address),
+		attribute.String(AttributeErrorType, errorType),
+	))
+}
+
+// queryOperation return the first keyword of command, such as SELECT or SHOW
+func queryOperation(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+func serverAttributes(address string) []attribute.KeyValue {
+	if address == "" {
 		return nil
 	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return []attribute.KeyValue{attribute.String(AttributeServerAddress, address)}
+	}
+	attrs := []attribute.KeyValue{attribute.String(AttributeServerAddress, host)}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, attribute.Int(AttributeServerPort, p))
+	}
+	return attrs
+}
+
+// otelMetrics the OpenTelemetry instruments mirroring the prometheus metrics of the client
+type otelMetrics struct {
+	requests      metric.Int64Counter
+	duration      metric.Float64Histogram
+	errors        metric.Int64Counter
+	sentBytes     metric.Int64Counter
+	receivedBytes metric.Int64Counter
+	batchPoints   metric.Int64Counter
+	grpcRecords   metric.Int64Counter
+}
+
+func newOtelMetrics(meter metric.Meter) *otelMetrics {
+	m := &otelMetrics{}
+	var err error
+	// the meter returns a working no-op instrument along with the error, so the error is only reported
+	m.requests, err = meter.Int64Counter("opengemini.client.requests",
+		metric.WithDescription("Count of opengemini requests"))
+	otelHandle(err)
+	m.duration, err = meter.Float64Histogram("opengemini.client.request.duration", metric.WithUnit("s"),
+		metric.WithDescription("Latency of the requests until the response headers are received"))
+	otelHandle(err)
+	m.errors, err = meter.Int64Counter("opengemini.client.errors",
+		metric.WithDescription("Count of failed requests and classify using error type"))
+	otelHandle(err)
+	m.sentBytes, err = meter.Int64Counter("opengemini.client.sent_bytes", metric.WithUnit("By"),
+		metric.WithDescription("Count of uncompressed bytes of the request payloads"))
+	otelHandle(err)
+	m.receivedBytes, err = meter.Int64Counter("opengemini.client.received_bytes", metric.WithUnit("By"),
+		metric.WithDescription("Count of bytes of the responses whose length is known"))
+	otelHandle(err)
+	m.batchPoints, err = meter.Int64Counter("opengemini.client.batch.points",
+		metric.WithDescription("Count of points flushed from the batch queue"))
+	otelHandle(err)
+	m.grpcRecords, err = meter.Int64Counter("opengemini.client.grpc.write.records",
+		metric.WithDescription("Count of records written by gRPC"))
+	otelHandle(err)
+	return m
+}
+
+func (m *otelMetrics) record(ctx context.Context, operation, database, address string, elapsed time.Duration, sent, received int64) {
+	attrs := metric.WithAttributes(
+		attribute.String(AttributeDbOperation, operation),
+		attribute.String(AttributeDbName, database),
+		attribute.String(AttributeServerAddress, address),
+	)
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, elapsed.Seconds(), attrs)
+	if sent > 0 {
+		m.sentBytes.Add(ctx, sent, attrs)
+	}
+	if received > 0 {
+		m.receivedBytes.Add(ctx, received, attrs)
+	}
+}
+
+func otelHandle(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
 }
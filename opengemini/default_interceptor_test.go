@@ -15,12 +15,27 @@
 package opengemini
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+
+	"github.com/openGemini/opengemini-client-go/proto"
 )
 
 func TestOtelClient_WriteInterceptors(t *testing.T) {
@@ -146,3 +161,267 @@ func TestOtelCreateAndQueryMeasurement(t *testing.T) {
 	require.Nil(t, err)
 	assert.NotEmpty(t, result)
 }
+
+type testSpan struct {
+	noop.Span
+	mu     sync.Mutex
+	name   string
+	parent *testSpan
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	ended  bool
+}
+
+func (s *testSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *testSpan) SetStatus(code codes.Code, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = code
+}
+
+func (s *testSpan) End(...trace.SpanEndOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended = true
+}
+
+type testTracerProvider struct {
+	noop.TracerProvider
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (p *testTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &testTracer{provider: p}
+}
+
+// span return the last span named name
+func (p *testTracerProvider) span(t *testing.T, name string) *testSpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := len(p.spans) - 1; i >= 0; i-- {
+		if p.spans[i].name == name {
+			return p.spans[i]
+		}
+	}
+	t.Fatalf("span %s not found", name)
+	return nil
+}
+
+type testTracer struct {
+	noop.Tracer
+	provider *testTracerProvider
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &testSpan{name: name, attrs: make(map[attribute.Key]attribute.Value)}
+	config := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(config.Attributes()...)
+	if parent, ok := trace.SpanFromContext(ctx).(*testSpan); ok {
+		span.parent = parent
+	}
+	t.provider.mu.Lock()
+	t.provider.spans = append(t.provider.spans, span)
+	t.provider.mu.Unlock()
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func TestRedactPassword(t *testing.T) {
+	require.Equal(t, `CREATE USER "admin" WITH PASSWORD '******' WITH ALL PRIVILEGES`,
+		RedactPassword(`CREATE USER "admin" WITH PASSWORD 'p@ss\'word' WITH ALL PRIVILEGES`))
+	require.Equal(t, `SET PASSWORD FOR "admin" = '******'`, RedactPassword(`SET PASSWORD FOR "admin" = 'secret'`))
+	require.Equal(t, `SELECT "password" FROM users`, RedactPassword(`SELECT "password" FROM users`))
+}
+
+func TestOtelInterceptorHttp(t *testing.T) {
+	body := `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[1,2]]}]}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case UrlWrite:
+			writer.WriteHeader(http.StatusNoContent)
+		default:
+			writer.Header().Set("Content-Type", HttpContentTypeJSON)
+			_, _ = writer.Write([]byte(body))
+		}
+	}))
+	defer server.Close()
+
+	address := testServerAddress(t, server.URL)
+	c := testNewClient(t, &Config{
+		Addresses:      []Address{address},
+		BatchConfig:    &BatchConfig{BatchInterval: 10 * time.Millisecond, BatchSize: 10},
+		CompressMethod: CompressMethodGzip,
+	})
+	defer c.Close()
+	provider := &testTracerProvider{}
+	c.Interceptors(NewOtelInterceptorWithConfig(&OtelConfig{
+		TracerProvider:      provider,
+		CaptureCommand:      true,
+		CaptureResponseBody: true,
+		MaxAttributeLength:  16,
+	}))
+
+	result, err := c.Query(Query{Database: "db0", Command: "select value from cpu where host = 'a'"})
+	require.Nil(t, err)
+	require.Equal(t, "cpu", result.Results[0].Series[0].Name)
+	span := provider.span(t, SpanNameQuery)
+	require.True(t, span.ended)
+	require.Equal(t, DbSystemOpenGemini, span.attrs[AttributeDbSystem].AsString())
+	require.Equal(t, "SELECT", span.attrs[AttributeDbOperation].AsString())
+	require.Equal(t, "db0", span.attrs[AttributeDbName].AsString())
+	require.Equal(t, address.Host, span.attrs[AttributeServerAddress].AsString())
+	require.Equal(t, int64(address.Port), span.attrs[AttributeServerPort].AsInt64())
+	require.Equal(t, "select value fro...", span.attrs[AttributeCommand].AsString())
+	require.Equal(t, body[:16]+"...", span.attrs[AttributeResponseBody].AsString())
+
+	written := make(chan error, 1)
+	point := &Point{Measurement: "cpu", Fields: map[string]any{"value": 1}}
+	require.Nil(t, c.WritePoint("db0", point, func(err error) { written <- err }))
+	require.Nil(t, <-written)
+	flush := provider.span(t, SpanNameBatchFlush)
+	write := provider.span(t, SpanNameWrite)
+	require.Same(t, flush, write.parent)
+	require.Equal(t, int64(1), flush.attrs[AttributeBatchPoints].AsInt64())
+	require.Equal(t, int64(http.StatusNoContent), write.attrs[AttributeResponseStatusCode].AsInt64())
+	_, captured := write.attrs[AttributeWriteLineProtocol]
+	require.False(t, captured)
+	require.True(t, flush.ended)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	closedPort := listener.Addr().(*net.TCPAddr).Port
+	require.Nil(t, listener.Close())
+	unreachable := testNewClient(t, &Config{Addresses: []Address{{Host: "127.0.0.1", Port: closedPort}}})
+	unreachable.Interceptors(NewOtelInterceptorWithConfig(&OtelConfig{TracerProvider: provider}))
+	require.NotNil(t, unreachable.Ping(0))
+	span = provider.span(t, SpanNameQuery)
+	require.True(t, span.ended)
+	require.Equal(t, codes.Error, span.status)
+}
+
+// testStatusInterceptor read the status code of every response as the interceptors before TransportErrorInterceptor
+type testStatusInterceptor struct {
+	codes []int
+}
+
+func (i *testStatusInterceptor) Query(context.Context, *InterceptorQuery) InterceptorClosure {
+	return func(ctx context.Context, response *http.Response) error {
+		i.codes = append(i.codes, response.StatusCode)
+		return nil
+	}
+}
+
+func (i *testStatusInterceptor) Write(ctx context.Context, write *InterceptorWrite) InterceptorClosure {
+	return i.Query(ctx, nil)
+}
+
+func TestInterceptorTransportError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	closedPort := listener.Addr().(*net.TCPAddr).Port
+	require.Nil(t, listener.Close())
+
+	c := testNewClient(t, &Config{Addresses: []Address{{Host: "127.0.0.1", Port: closedPort}}})
+	defer c.Close()
+	interceptor := &testStatusInterceptor{}
+	provider := &testTracerProvider{}
+	c.Interceptors(interceptor, NewOtelInterceptorWithConfig(&OtelConfig{TracerProvider: provider}))
+
+	require.NotPanics(t, func() {
+		_, err = c.Query(Query{Database: "db0", Command: "SELECT * FROM cpu"})
+	})
+	require.NotNil(t, err)
+	require.NotNil(t, c.WriteBatchPoints(context.Background(), "db0",
+		[]*Point{{Measurement: "cpu", Fields: map[string]any{"v": 1}}}))
+	require.Empty(t, interceptor.codes, "the closure of Interceptor only receives responses")
+	for _, name := range []string{SpanNameQuery, SpanNameWrite} {
+		span := provider.span(t, name)
+		require.True(t, span.ended, name)
+		require.Equal(t, codes.Error, span.status, name)
+	}
+}
+
+func TestOtelInterceptorIdleBatch(t *testing.T) {
+	var writes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writes.Add(1)
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{
+		Addresses:   []Address{testServerAddress(t, server.URL)},
+		BatchConfig: &BatchConfig{BatchInterval: 5 * time.Millisecond, BatchSize: 10},
+	})
+	defer c.Close()
+	provider := &testTracerProvider{}
+	c.Interceptors(NewOtelInterceptorWithConfig(&OtelConfig{TracerProvider: provider}))
+
+	written := make(chan error, 1)
+	point := &Point{Measurement: "cpu", Fields: map[string]any{"value": 1}}
+	require.Nil(t, c.WritePoint("db0", point, func(err error) { written <- err }))
+	require.Nil(t, <-written)
+	// the batch goroutine keeps ticking after the flush
+	time.Sleep(50 * time.Millisecond)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	var flushes int
+	for _, span := range provider.spans {
+		if span.name == SpanNameBatchFlush {
+			flushes++
+		}
+	}
+	require.Equal(t, 1, flushes)
+	require.Equal(t, int32(1), writes.Load())
+}
+
+func TestOtelInterceptorWriteLineProtocol(t *testing.T) {
+	c := testNewClient(t, &Config{Addresses: []Address{{Host: "127.0.0.1", Port: 8086}}, CompressMethod: CompressMethodGzip})
+	buffer, err := c.(*client).encodePoint(&Point{Measurement: "cpu", Fields: map[string]any{"v": 1}, Timestamp: 1})
+	require.Nil(t, err)
+	req := requestDetails{queryValues: url.Values{"db": {"db0"}}, header: http.Header{"Content-Encoding": {"gzip"}}, body: buffer}
+	write := req.toWrite()
+	require.Equal(t, "cpu v=1i 1", strings.TrimSpace(write.LineProtocol))
+	require.NotZero(t, buffer.Len(), "the request body must not be consumed")
+}
+
+func TestOtelInterceptorGrpc(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	server := grpc.NewServer()
+	proto.RegisterWriteServiceServer(server, &testMetricsWriteServer{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	address := listener.Addr().(*net.TCPAddr)
+	c := testNewClient(t, &Config{
+		Addresses:  []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{Addresses: []Address{{Host: "127.0.0.1", Port: address.Port}}},
+	})
+	defer c.Close()
+	provider := &testTracerProvider{}
+	c.Interceptors(NewOtelInterceptorWithConfig(&OtelConfig{TracerProvider: provider}))
+
+	recordBuilder, err := NewRecordBuilder("cpu")
+	require.Nil(t, err)
+	builder, err := NewWriteRequestBuilder("readonly", "")
+	require.Nil(t, err)
+	request, err := builder.AddRecord(recordBuilder.AddField("v", 1.5).Build(time.Now().UnixNano())).Build()
+	require.Nil(t, err)
+	require.NotNil(t, c.WriteByGrpc(context.Background(), request))
+
+	span := provider.span(t, SpanNameGrpcWrite)
+	require.True(t, span.ended)
+	require.Equal(t, codes.Error, span.status)
+	require.Equal(t, "Failed", span.attrs[AttributeErrorType].AsString())
+	require.Equal(t, int64(1), span.attrs[AttributeGrpcRecords].AsInt64())
+	require.Equal(t, int64(address.Port), span.attrs[AttributeServerPort].AsInt64())
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DropSeriesBuilder build command `DROP SERIES`, a where condition is required to avoid dropping all series by
+// accident, use DropMeasurement to delete the whole measurement
+type DropSeriesBuilder interface {
+	// Database specify the database of series
+	Database(database string) DropSeriesBuilder
+	// From specify the measurements of series, if not set, series of all measurements matching the condition
+	// will be dropped
+	From(measurements ...string) DropSeriesBuilder
+	// Where specify the tag condition of series, it is required
+	Where(condition Condition) DropSeriesBuilder
+	build() (string, error)
+	getDatabase() string
+}
+
+type dropSeriesBuilder struct {
+	database string
+	from     []string
+	where    Condition
+}
+
+func NewDropSeriesBuilder() DropSeriesBuilder {
+	return &dropSeriesBuilder{}
+}
+
+func (d *dropSeriesBuilder) Database(database string) DropSeriesBuilder {
+	d.database = database
+	return d
+}
+
+func (d *dropSeriesBuilder) From(measurements ...string) DropSeriesBuilder {
+	d.from = measurements
+	return d
+}
+
+func (d *dropSeriesBuilder) Where(condition Condition) DropSeriesBuilder {
+	d.where = condition
+	return d
+}
+
+func (d *dropSeriesBuilder) build() (string, error) {
+	if err := checkDatabaseName(d.database); err != nil {
+		return "", err
+	}
+	if d.where == nil {
+		return "", ErrEmptyCondition
+	}
+	var buf strings.Builder
+	buf.WriteString("DROP SERIES")
+	buf.WriteString(CreateQueryBuilder().From(d.from...).buildFrom(nil))
+	buf.WriteString(" WHERE " + d.where.build())
+	return buf.String(), nil
+}
+
+func (d *dropSeriesBuilder) getDatabase() string {
+	return d.database
+}
+
+// DeleteBuilder build command `DELETE`, a where condition or a time range is required to avoid deleting all data
+// by accident
+type DeleteBuilder interface {
+	// Database specify the database of data
+	Database(database string) DeleteBuilder
+	// RetentionPolicy specify the retention policy of data, if empty, use default retention policy
+	RetentionPolicy(rp string) DeleteBuilder
+	// From specify the measurements of data
+	From(measurements ...string) DeleteBuilder
+	// Where specify the tag condition of data
+	Where(condition Condition) DeleteBuilder
+	// TimeRange specify the time range [start, end) of data, zero value means unbounded
+	TimeRange(start, end time.Time) DeleteBuilder
+	build() (string, error)
+	getDatabase() string
+	getRetentionPolicy() string
+}
+
+type deleteBuilder struct {
+	database        string
+	retentionPolicy string
+	from            []string
+	where           Condition
+	start           time.Time
+	end             time.Time
+}
+
+func NewDeleteBuilder() DeleteBuilder {
+	return &deleteBuilder{}
+}
+
+func (d *deleteBuilder) Database(database string) DeleteBuilder {
+	d.database = database
+	return d
+}
+
+func (d *deleteBuilder) RetentionPolicy(rp string) DeleteBuilder {
+	d.retentionPolicy = rp
+	return d
+}
+
+func (d *deleteBuilder) From(measurements ...string) DeleteBuilder {
+	d.from = measurements
+	return d
+}
+
+func (d *deleteBuilder) Where(condition Condition) DeleteBuilder {
+	d.where = condition
+	return d
+}
+
+func (d *deleteBuilder) TimeRange(start, end time.Time) DeleteBuilder {
+	d.start = start
+	d.end = end
+	return d
+}
+
+func (d *deleteBuilder) build() (string, error) {
+	if err := checkDatabaseName(d.database); err != nil {
+		return "", err
+	}
+	if !d.start.IsZero() && !d.end.IsZero() && !d.start.Before(d.end) {
+		return "", fmt.Errorf("invalid time range: start %s must be before end %s",
+			d.start.Format(time.RFC3339Nano), d.end.Format(time.RFC3339Nano))
+	}
+
+	var conditions []Condition
+	if d.where != nil {
+		conditions = append(conditions, d.where)
+	}
+	conditions = append(conditions, timeRangeConditions(d.start, d.end)...)
+	if len(conditions) == 0 {
+		return "", ErrEmptyCondition
+	}
+
+	var buf strings.Builder
+	buf.WriteString("DELETE")
+	buf.WriteString(CreateQueryBuilder().From(d.from...).buildFrom(nil))
+	buf.WriteString(" WHERE ")
+	if len(conditions) == 1 {
+		buf.WriteString(conditions[0].build())
+	} else {
+		buf.WriteString(NewCompositeCondition(And, conditions...).build())
+	}
+	return buf.String(), nil
+}
+
+func (d *deleteBuilder) getDatabase() string {
+	return d.database
+}
+
+func (d *deleteBuilder) getRetentionPolicy() string {
+	return d.retentionPolicy
+}
+
+// DropSeries use command `DROP SERIES` to delete the series matching the condition, including index and data
+func (c *client) DropSeries(builder DropSeriesBuilder) error {
+	command, err := builder.build()
+	if err != nil {
+		return err
+	}
+
+	queryResult, err := c.queryPost(Query{Database: builder.getDatabase(), Command: command})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("drop series %w", err)
+	}
+	return nil
+}
+
+// Delete use command `DELETE` to delete the data matching the condition and time range
+func (c *client) Delete(builder DeleteBuilder) error {
+	command, err := builder.build()
+	if err != nil {
+		return err
+	}
+
+	queryResult, err := c.queryPost(Query{
+		Database:        builder.getDatabase(),
+		RetentionPolicy: builder.getRetentionPolicy(),
+		Command:         command,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("delete %w", err)
+	}
+	return nil
+}
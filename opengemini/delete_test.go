@@ -0,0 +1,101 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropSeriesBuilder(t *testing.T) {
+	command, err := NewDropSeriesBuilder().Database("db0").From("m0", "m1").
+		Where(NewComparisonCondition("host", Equals, "server01")).build()
+	require.Nil(t, err)
+	require.Equal(t, `DROP SERIES FROM "m0", "m1" WHERE "host" = 'server01'`, command)
+
+	command, err = NewDropSeriesBuilder().Database("db0").
+		Where(NewCompositeCondition(Or, NewComparisonCondition("host", Equals, "a"), NewComparisonCondition("host", Equals, "b"))).build()
+	require.Nil(t, err)
+	require.Equal(t, `DROP SERIES WHERE ("host" = 'a' OR "host" = 'b')`, command)
+
+	_, err = NewDropSeriesBuilder().Database("db0").From("m0").build()
+	require.ErrorIs(t, err, ErrEmptyCondition)
+
+	_, err = NewDropSeriesBuilder().From("m0").Where(NewComparisonCondition("host", Equals, "a")).build()
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+}
+
+func TestDeleteBuilder(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	command, err := NewDeleteBuilder().Database("db0").From("m0").
+		Where(NewComparisonCondition("user_id", Equals, "u1")).TimeRange(start, end).build()
+	require.Nil(t, err)
+	require.Equal(t, `DELETE FROM "m0" WHERE ("user_id" = 'u1' AND time >= '2025-01-01T00:00:00Z' AND time < '2025-01-01T01:00:00Z')`, command)
+
+	command, err = NewDeleteBuilder().Database("db0").From("m0").TimeRange(time.Time{}, end).build()
+	require.Nil(t, err)
+	require.Equal(t, `DELETE FROM "m0" WHERE time < '2025-01-01T01:00:00Z'`, command)
+
+	command, err = NewDeleteBuilder().Database("db0").Where(NewComparisonCondition("user_id", Equals, "u1")).build()
+	require.Nil(t, err)
+	require.Equal(t, `DELETE WHERE "user_id" = 'u1'`, command)
+
+	_, err = NewDeleteBuilder().Database("db0").From("m0").build()
+	require.ErrorIs(t, err, ErrEmptyCondition)
+
+	_, err = NewDeleteBuilder().Database("db0").From("m0").TimeRange(end, start).build()
+	require.NotNil(t, err)
+
+	_, err = NewDeleteBuilder().From("m0").TimeRange(start, end).build()
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+}
+
+func TestClientDropSeries(t *testing.T) {
+	c := testDefaultClient(t)
+	databaseName := randomDatabaseName()
+	measurement := randomMeasurement()
+	err := c.CreateDatabase(databaseName)
+	require.Nil(t, err)
+	err = c.WriteBatchPoints(context.Background(), databaseName, []*Point{
+		{Measurement: measurement, Tags: map[string]string{"host": "a"}, Fields: map[string]interface{}{"v": 1}},
+		{Measurement: measurement, Tags: map[string]string{"host": "b"}, Fields: map[string]interface{}{"v": 2}},
+	})
+	require.Nil(t, err)
+	time.Sleep(time.Second * 3)
+
+	err = c.DropSeries(NewDropSeriesBuilder().Database(databaseName).From(measurement).
+		Where(NewComparisonCondition("host", Equals, "a")))
+	require.Nil(t, err)
+
+	series, err := c.ShowSeries(NewShowSeriesBuilder().Database(databaseName).Measurement(measurement))
+	require.Nil(t, err)
+	require.Equal(t, []string{measurement + ",host=b"}, series)
+
+	err = c.DropDatabase(databaseName)
+	require.Nil(t, err)
+}
+
+func TestClientDropSeriesWithoutCondition(t *testing.T) {
+	c := testDefaultClient(t)
+	err := c.DropSeries(NewDropSeriesBuilder().Database("db0").From("m0"))
+	require.ErrorIs(t, err, ErrEmptyCondition)
+	err = c.Delete(NewDeleteBuilder().Database("db0").From("m0"))
+	require.ErrorIs(t, err, ErrEmptyCondition)
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type downsampleDataType string
+
+const (
+	DownsampleTypeFloat   downsampleDataType = "float"
+	DownsampleTypeInteger downsampleDataType = "integer"
+)
+
+// DownsamplePolicy defines the structure for downsample policy info
+type DownsamplePolicy struct {
+	RetentionPolicy string
+	// FieldOperator the aggregate functions for each data type, such as `float{sum,last},integer{max,min}`
+	FieldOperator  string
+	Duration       string
+	SampleInterval string
+	TimeInterval   string
+}
+
+func (d *DownsamplePolicy) SetRetentionPolicy(value SeriesValue) error {
+	rp, ok := value[0].(string)
+	if !ok {
+		return fmt.Errorf("set DownsamplePolicy retentionPolicy: retentionPolicy must be a string")
+	}
+	d.RetentionPolicy = rp
+	return nil
+}
+
+func (d *DownsamplePolicy) SetFieldOperator(value SeriesValue) error {
+	fieldOperator, ok := value[1].(string)
+	if !ok {
+		return fmt.Errorf("set DownsamplePolicy fieldOperator: fieldOperator must be a string")
+	}
+	d.FieldOperator = fieldOperator
+	return nil
+}
+
+func (d *DownsamplePolicy) SetDuration(value SeriesValue) error {
+	duration, ok := value[2].(string)
+	if !ok {
+		return fmt.Errorf("set DownsamplePolicy duration: duration must be a string")
+	}
+	d.Duration = duration
+	return nil
+}
+
+func (d *DownsamplePolicy) SetSampleInterval(value SeriesValue) error {
+	sampleInterval, ok := value[3].(string)
+	if !ok {
+		return fmt.Errorf("set DownsamplePolicy sampleInterval: sampleInterval must be a string")
+	}
+	d.SampleInterval = sampleInterval
+	return nil
+}
+
+func (d *DownsamplePolicy) SetTimeInterval(value SeriesValue) error {
+	timeInterval, ok := value[4].(string)
+	if !ok {
+		return fmt.Errorf("set DownsamplePolicy timeInterval: timeInterval must be a string")
+	}
+	d.TimeInterval = timeInterval
+	return nil
+}
+
+func NewDownsamplePolicy(value SeriesValue) *DownsamplePolicy {
+	d := &DownsamplePolicy{}
+	if !errors.Is(d.SetRetentionPolicy(value), nil) ||
+		!errors.Is(d.SetFieldOperator(value), nil) ||
+		!errors.Is(d.SetDuration(value), nil) ||
+		!errors.Is(d.SetSampleInterval(value), nil) ||
+		!errors.Is(d.SetTimeInterval(value), nil) {
+		return nil
+	}
+	return d
+}
+
+type downsampleBase struct {
+	database        string
+	retentionPolicy string
+}
+
+type downsampleAggregate struct {
+	dataType  downsampleDataType
+	functions []FunctionEnum
+}
+
+type downsampleBuilder struct {
+	downsampleBase
+	aggregates      []downsampleAggregate
+	duration        time.Duration
+	sampleIntervals []time.Duration
+	timeIntervals   []time.Duration
+}
+
+// DownsampleBuilder specify the retention policy which downsample policy works on
+type DownsampleBuilder interface {
+	// Database specify the database of retention policy
+	Database(database string) DownsampleBuilder
+	// RetentionPolicy specify the retention policy which downsample policy works on
+	RetentionPolicy(rp string) DownsampleBuilder
+	// Create use command `CREATE DOWNSAMPLE` to create downsample policy
+	Create() CreateDownsampleBuilder
+}
+
+type CreateDownsampleBuilder interface {
+	// Aggregate specify aggregate functions for the fields of data type, such as
+	// Aggregate(DownsampleTypeFloat, FunctionSum, FunctionLast), it can be called multiple times for different types
+	Aggregate(dataType downsampleDataType, functions ...FunctionEnum) CreateDownsampleBuilder
+	// Duration specify how long the downsampled data will be retained
+	Duration(duration time.Duration) CreateDownsampleBuilder
+	// SampleInterval specify the age of data at which each downsample level is executed, the number of intervals
+	// must be equal to TimeInterval
+	SampleInterval(intervals ...time.Duration) CreateDownsampleBuilder
+	// TimeInterval specify the time granularity of each downsample level
+	TimeInterval(intervals ...time.Duration) CreateDownsampleBuilder
+	build() (string, error)
+	getDownsampleBase() downsampleBase
+}
+
+func NewDownsampleBuilder() DownsampleBuilder {
+	return &downsampleBuilder{}
+}
+
+func (d *downsampleBuilder) Database(database string) DownsampleBuilder {
+	d.database = database
+	return d
+}
+
+func (d *downsampleBuilder) RetentionPolicy(rp string) DownsampleBuilder {
+	d.retentionPolicy = rp
+	return d
+}
+
+func (d *downsampleBuilder) Create() CreateDownsampleBuilder {
+	return d
+}
+
+func (d *downsampleBuilder) Aggregate(dataType downsampleDataType, functions ...FunctionEnum) CreateDownsampleBuilder {
+	d.aggregates = append(d.aggregates, downsampleAggregate{dataType: dataType, functions: functions})
+	return d
+}
+
+func (d *downsampleBuilder) Duration(duration time.Duration) CreateDownsampleBuilder {
+	d.duration = duration
+	return d
+}
+
+func (d *downsampleBuilder) SampleInterval(intervals ...time.Duration) CreateDownsampleBuilder {
+	d.sampleIntervals = intervals
+	return d
+}
+
+func (d *downsampleBuilder) TimeInterval(intervals ...time.Duration) CreateDownsampleBuilder {
+	d.timeIntervals = intervals
+	return d
+}
+
+func (d *downsampleBuilder) build() (string, error) {
+	if err := checkDatabaseAndPolicy(d.database, d.retentionPolicy); err != nil {
+		return "", err
+	}
+	if len(d.aggregates) == 0 {
+		return "", errors.New("empty downsample aggregate functions")
+	}
+	if d.duration <= 0 {
+		return "", errors.New("downsample duration must be greater than 0")
+	}
+	if len(d.sampleIntervals) == 0 || len(d.sampleIntervals) != len(d.timeIntervals) {
+		return "", errors.New("the number of sample intervals and time intervals must be equal and greater than 0")
+	}
+
+	var aggregates = make([]string, 0, len(d.aggregates))
+	for _, aggregate := range d.aggregates {
+		if len(aggregate.functions) == 0 {
+			return "", fmt.Errorf("empty aggregate functions for %s", aggregate.dataType)
+		}
+		functions := make([]string, len(aggregate.functions))
+		for i, function := range aggregate.functions {
+			functions[i] = strings.ToLower(string(function))
+		}
+		aggregates = append(aggregates, string(aggregate.dataType)+"("+strings.Join(functions, ",")+")")
+	}
+
+	var buf strings.Builder
+	buf.WriteString("CREATE DOWNSAMPLE ON " + QuoteIdentifier(d.database) + "." + QuoteIdentifier(d.retentionPolicy))
+	buf.WriteString(" (" + strings.Join(aggregates, ",") + ")")
+	buf.WriteString(" WITH DURATION " + formatDuration(d.duration))
+	buf.WriteString(" SAMPLEINTERVAL(" + joinDurations(d.sampleIntervals) + ")")
+	buf.WriteString(" TIMEINTERVAL(" + joinDurations(d.timeIntervals) + ")")
+	return buf.String(), nil
+}
+
+func (d *downsampleBuilder) getDownsampleBase() downsampleBase {
+	return d.downsampleBase
+}
+
+func joinDurations(durations []time.Duration) string {
+	values := make([]string, len(durations))
+	for i, duration := range durations {
+		values[i] = formatDuration(duration)
+	}
+	return strings.Join(values, ",")
+}
+
+// CreateDownsample use command `CREATE DOWNSAMPLE` to create downsample policy on retention policy
+func (c *client) CreateDownsample(builder CreateDownsampleBuilder) error {
+	command, err := builder.build()
+	if err != nil {
+		return err
+	}
+
+	base := builder.getDownsampleBase()
+	queryResult, err := c.queryPost(Query{Database: base.database, Command: command})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("create downsample %w", err)
+	}
+	return nil
+}
+
+// ShowDownsamples use command `SHOW DOWNSAMPLES` to view downsample policies of database
+func (c *client) ShowDownsamples(database string) ([]DownsamplePolicy, error) {
+	err := checkDatabaseName(database)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult, err := c.Query(Query{Database: database, Command: "SHOW DOWNSAMPLES ON " + QuoteIdentifier(database)})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show downsamples err: %s", err)
+	}
+
+	return queryResult.convertDownsamplePolicyList(), nil
+}
+
+// DropDownsample use command `DROP DOWNSAMPLE` to delete downsample policy on retention policy
+func (c *client) DropDownsample(database, retentionPolicy string) error {
+	err := checkDatabaseAndPolicy(database, retentionPolicy)
+	if err != nil {
+		return err
+	}
+
+	cmd := "DROP DOWNSAMPLE ON " + QuoteIdentifier(database) + "." + QuoteIdentifier(retentionPolicy)
+	queryResult, err := c.queryPost(Query{Database: database, Command: cmd})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("drop downsample %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDownsampleBuilder(t *testing.T) {
+	command, err := NewDownsampleBuilder().Database("db0").RetentionPolicy("rp0").Create().
+		Aggregate(DownsampleTypeFloat, FunctionSum, FunctionLast).
+		Aggregate(DownsampleTypeInteger, FunctionMax, FunctionMin).
+		Duration(24*time.Hour).SampleInterval(24*time.Hour, 48*time.Hour).
+		TimeInterval(time.Minute, 5*time.Minute).build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE DOWNSAMPLE ON "db0"."rp0" (float(sum,last),integer(max,min)) WITH DURATION 1d SAMPLEINTERVAL(1d,2d) TIMEINTERVAL(1m,5m)`, command)
+}
+
+func TestCreateDownsampleBuilderError(t *testing.T) {
+	_, err := NewDownsampleBuilder().RetentionPolicy("rp0").Create().build()
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+
+	_, err = NewDownsampleBuilder().Database("db0").Create().build()
+	require.ErrorIs(t, err, ErrRetentionPolicy)
+
+	_, err = NewDownsampleBuilder().Database("db0").RetentionPolicy("rp0").Create().
+		Duration(time.Hour).SampleInterval(time.Hour).TimeInterval(time.Minute).build()
+	require.NotNil(t, err)
+
+	_, err = NewDownsampleBuilder().Database("db0").RetentionPolicy("rp0").Create().
+		Aggregate(DownsampleTypeFloat, FunctionSum).SampleInterval(time.Hour).TimeInterval(time.Minute).build()
+	require.NotNil(t, err)
+
+	_, err = NewDownsampleBuilder().Database("db0").RetentionPolicy("rp0").Create().
+		Aggregate(DownsampleTypeFloat, FunctionSum).Duration(time.Hour).
+		SampleInterval(time.Hour, 2*time.Hour).TimeInterval(time.Minute).build()
+	require.NotNil(t, err)
+
+	_, err = NewDownsampleBuilder().Database("db0").RetentionPolicy("rp0").Create().
+		Aggregate(DownsampleTypeFloat).Duration(time.Hour).SampleInterval(time.Hour).TimeInterval(time.Minute).build()
+	require.NotNil(t, err)
+}
+
+func TestConvertDownsamplePolicyList(t *testing.T) {
+	result := &QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Columns: []string{"rpName", "field_operator", "duration", "sampleInterval", "timeInterval"},
+		Values:  SeriesValues{{"rp0", "float{sum,last}", "24h0m0s", "24h0m0s,48h0m0s", "1m0s,5m0s"}},
+	}}}}}
+	require.Equal(t, []DownsamplePolicy{{
+		RetentionPolicy: "rp0",
+		FieldOperator:   "float{sum,last}",
+		Duration:        "24h0m0s",
+		SampleInterval:  "24h0m0s,48h0m0s",
+		TimeInterval:    "1m0s,5m0s",
+	}}, result.convertDownsamplePolicyList())
+}
+
+func TestClientDownsample(t *testing.T) {
+	c := testDefaultClient(t)
+	databaseName := randomDatabaseName()
+	retentionPolicy := randomRetentionPolicy()
+	err := c.CreateDatabase(databaseName)
+	require.Nil(t, err)
+	err = c.CreateRetentionPolicy(databaseName, RpConfig{Name: retentionPolicy, Duration: "30d"}, false)
+	require.Nil(t, err)
+
+	err = c.CreateDownsample(NewDownsampleBuilder().Database(databaseName).RetentionPolicy(retentionPolicy).Create().
+		Aggregate(DownsampleTypeFloat, FunctionSum, FunctionLast).Duration(30*24*time.Hour).
+		SampleInterval(24*time.Hour, 48*time.Hour).TimeInterval(time.Minute, 5*time.Minute))
+	require.Nil(t, err)
+
+	policies, err := c.ShowDownsamples(databaseName)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(policies))
+	require.Equal(t, retentionPolicy, policies[0].RetentionPolicy)
+
+	err = c.DropDownsample(databaseName, retentionPolicy)
+	require.Nil(t, err)
+	err = c.DropDatabase(databaseName)
+	require.Nil(t, err)
+}
+
+func TestClientDownsampleEmptyDatabase(t *testing.T) {
+	c := testDefaultClient(t)
+	_, err := c.ShowDownsamples("")
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+	err = c.DropDownsample("db0", "")
+	require.ErrorIs(t, err, ErrRetentionPolicy)
+}
@@ -29,6 +29,16 @@ var (
 	ErrRetentionPolicy           = errors.New("empty retention policy")
 	ErrUnsupportedFieldValueType = errors.New("unsupported field value type")
 	ErrEmptyRecord               = errors.New("empty record")
+	ErrEmptyUserName             = errors.New("empty user name")
+	ErrEmptyUserPassword         = errors.New("empty user password")
+	ErrEmptyContinuousQueryName  = errors.New("empty continuous query name")
+	ErrEmptyStreamName           = errors.New("empty stream name")
+	ErrEmptyCondition            = errors.New("empty condition, refuse to delete all data")
+	ErrInvalidPageSize           = errors.New("page size must be greater than 0")
+	ErrEmptySubQuery             = errors.New("empty subquery")
+	ErrNilQueryBuilder           = errors.New("nil query builder")
+	ErrInvalidFillOption         = errors.New("invalid fill option, expect none, null, previous, linear or number")
+	ErrUnsupportedCompressMethod = errors.New("unsupported compress method")
 )
 
 // checkDatabaseName checks if the database name is empty and returns an error if it is.
@@ -47,6 +57,14 @@ func checkMeasurementName(mst string) error {
 	return nil
 }
 
+// checkUserName checks if the user name is empty and returns an error if it is.
+func checkUserName(username string) error {
+	if len(username) == 0 {
+		return ErrEmptyUserName
+	}
+	return nil
+}
+
 func checkDatabaseAndPolicy(database, retentionPolicy string) error {
 	if len(database) == 0 {
 		return ErrEmptyDatabaseName
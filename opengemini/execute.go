@@ -25,7 +25,7 @@ type StatementType int
 const (
 	StatementTypeUnknown StatementType = iota
 	StatementTypeQuery                 // SELECT, SHOW, EXPLAIN query statements → routed to Query()
-	StatementTypeCommand               // CREATE, DROP, ALTER command statements → routed to Query()
+	StatementTypeCommand               // CREATE, DROP, ALTER command statements → routed to Query() by POST
 	StatementTypeInsert                // INSERT write statements → routed to Write methods
 )
 
@@ -57,6 +57,12 @@ type Statement struct {
 	Command         string
 	Params          map[string]any
 	RetentionPolicy string
+	// Validate parse the command on client side before sending it, the syntax error is returned with position
+	// instead of sending the request, see ValidateCommand
+	Validate bool
+	// Precision the precision of timestamps, it is the epoch of query result, and the precision of timestamps in
+	// INSERT statement which are converted to nanoseconds before writing
+	Precision Precision
 }
 
 // ExecuteResult represents the result of Execute operation
@@ -80,7 +86,13 @@ func (c *client) ExecuteContext(ctx context.Context, stmt Statement) (*ExecuteRe
 		}, err
 	}
 
-	stmtType := parseStatementType(stmt.Command)
+	stmtType, err := resolveStatementType(stmt)
+	if err != nil {
+		return &ExecuteResult{
+			StatementType: stmtType,
+			Error:         err,
+		}, err
+	}
 
 	switch {
 	case stmtType.IsQueryLike():
@@ -104,9 +116,16 @@ func (c *client) routeToQuery(ctx context.Context, stmt Statement, stmtType Stat
 		Command:         stmt.Command,
 		RetentionPolicy: stmt.RetentionPolicy,
 		Params:          stmt.Params,
+		Precision:       stmt.Precision,
 	}
 
-	queryResult, err := c.Query(query)
+	var queryResult *QueryResult
+	var err error
+	if stmtType == StatementTypeCommand {
+		queryResult, err = c.queryPost(query)
+	} else {
+		queryResult, err = c.Query(query)
+	}
 	if err != nil {
 		return &ExecuteResult{
 			StatementType: stmtType,
@@ -148,6 +167,10 @@ func (c *client) routeToWrite(ctx context.Context, stmt Statement, stmtType Stat
 		}
 	}
 
+	for _, point := range points {
+		point.Timestamp = toNanoseconds(point.Timestamp, stmt.Precision)
+	}
+
 	// Use batch write method (supports both single and multiple points)
 	err = c.WriteBatchPointsWithRp(ctx, stmt.Database, stmt.RetentionPolicy, points)
 	if err != nil {
@@ -163,6 +186,28 @@ func (c *client) routeToWrite(ctx context.Context, stmt Statement, stmtType Stat
 	}, nil
 }
 
+// resolveStatementType classify all statements of the command rather than the first keyword only, so that a batch
+// such as `SELECT ...; DROP ...` is routed as command
+func resolveStatementType(stmt Statement) (StatementType, error) {
+	if stmt.Validate {
+		if err := ValidateCommand(stmt.Command); err != nil {
+			return StatementTypeUnknown, err
+		}
+	}
+
+	stmtType := parseStatementType(stmt.Command)
+	if stmtType == StatementTypeInsert {
+		// line protocol is not SQL, it may contain `;` in string fields
+		return stmtType, nil
+	}
+	statements, err := parseSQL(stmt.Command)
+	if err != nil {
+		// server may support the syntax which client parser doesn't know, fall back to the first keyword
+		return stmtType, nil
+	}
+	return classifyStatements(statements)
+}
+
 // validateStatement performs basic validation on the statement
 func validateStatement(statement Statement) error {
 	if statement.Database == "" {
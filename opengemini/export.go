@@ -0,0 +1,322 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+// exportChunkSize the chunk size of query used by Export
+const exportChunkSize = 10000
+
+// ExportConfig configure the encoders of query result
+type ExportConfig struct {
+	// Precision the precision of time column, the numeric time values are interpreted in it, PrecisionRFC3339
+	// writes time as RFC3339 string and treats numeric values as nanoseconds
+	Precision Precision
+	// NullValue the text written for null values in CSV, default is empty string
+	NullValue string
+	// OmitNull omit the keys of null values in NDJSON instead of writing null
+	OmitNull bool
+}
+
+// ResultEncoder write the series of query results into writer, Encode can be called for each chunk of
+// Client.QueryChunked, so the result is exported without being held in memory
+type ResultEncoder interface {
+	// Encode write all series of result
+	Encode(result *QueryResult) error
+	// Flush write the buffered data into the underlying writer
+	Flush() error
+}
+
+// NewResultEncoder create the encoder of format
+func NewResultEncoder(format ExportFormat, w io.Writer, config ExportConfig) (ResultEncoder, error) {
+	switch format {
+	case ExportFormatCSV:
+		return NewCSVEncoder(w, config), nil
+	case ExportFormatNDJSON:
+		return NewNDJSONEncoder(w, config), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// csvEncoder write a header of `name`, the tag keys in order and Series.Columns, the header is written again when
+// the next series has different tag keys or columns
+type csvEncoder struct {
+	writer *csv.Writer
+	config ExportConfig
+	header []string
+	record []string
+}
+
+// NewCSVEncoder create encoder which writes the rows of series as CSV, the tags of series are flattened into columns
+func NewCSVEncoder(w io.Writer, config ExportConfig) ResultEncoder {
+	return &csvEncoder{writer: csv.NewWriter(w), config: config}
+}
+
+func (e *csvEncoder) Encode(result *QueryResult) error {
+	if err := result.hasError(); err != nil {
+		return err
+	}
+	for _, statement := range result.Results {
+		for _, series := range statement.Series {
+			if err := e.encodeSeries(series); err != nil {
+				return err
+			}
+		}
+	}
+	return e.writer.Error()
+}
+
+func (e *csvEncoder) encodeSeries(series *Series) error {
+	tagKeys := sortedTagKeys(series.Tags)
+	header := make([]string, 0, 1+len(tagKeys)+len(series.Columns))
+	header = append(header, "name")
+	header = append(header, tagKeys...)
+	header = append(header, series.Columns...)
+	if !slices.Equal(header, e.header) {
+		if e.header != nil {
+			// a blank line separates the blocks of different headers
+			if err := e.writer.Write(nil); err != nil {
+				return err
+			}
+		}
+		if err := e.writer.Write(header); err != nil {
+			return err
+		}
+		e.header = header
+	}
+
+	for _, values := range series.Values {
+		record := append(e.record[:0], series.Name)
+		for _, key := range tagKeys {
+			record = append(record, series.Tags[key])
+		}
+		for i, column := range series.Columns {
+			var value any
+			if i < len(values) {
+				value = values[i]
+			}
+			text, err := e.format(column, value)
+			if err != nil {
+				return err
+			}
+			record = append(record, text)
+		}
+		if err := e.writer.Write(record); err != nil {
+			return err
+		}
+		e.record = record
+	}
+	return nil
+}
+
+func (e *csvEncoder) format(column string, value any) (string, error) {
+	if value == nil {
+		return e.config.NullValue, nil
+	}
+	if column == "time" {
+		return formatExportTime(value, e.config.Precision)
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return fmt.Sprintf("%v", v), nil
+	}
+}
+
+func (e *csvEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// ndjsonEncoder write each row as a json object in line, the keys are `name`, the tag keys in order and
+// Series.Columns
+type ndjsonEncoder struct {
+	writer *bufio.Writer
+	config ExportConfig
+}
+
+// NewNDJSONEncoder create encoder which writes the rows of series as newline delimited json objects
+func NewNDJSONEncoder(w io.Writer, config ExportConfig) ResultEncoder {
+	return &ndjsonEncoder{writer: bufio.NewWriter(w), config: config}
+}
+
+func (e *ndjsonEncoder) Encode(result *QueryResult) error {
+	if err := result.hasError(); err != nil {
+		return err
+	}
+	for _, statement := range result.Results {
+		for _, series := range statement.Series {
+			if err := e.encodeSeries(series); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *ndjsonEncoder) encodeSeries(series *Series) error {
+	tagKeys := sortedTagKeys(series.Tags)
+	for _, values := range series.Values {
+		buf := []byte(`{"name":`)
+		buf = appendJSONString(buf, series.Name)
+		for _, key := range tagKeys {
+			buf = appendJSONKey(buf, key)
+			buf = appendJSONString(buf, series.Tags[key])
+		}
+		for i, column := range series.Columns {
+			var value any
+			if i < len(values) {
+				value = values[i]
+			}
+			if value == nil && e.config.OmitNull {
+				continue
+			}
+			buf = appendJSONKey(buf, column)
+			if column == "time" && value != nil {
+				text, err := formatExportTime(value, e.config.Precision)
+				if err != nil {
+					return err
+				}
+				if e.config.Precision == PrecisionRFC3339 {
+					buf = appendJSONString(buf, text)
+				} else {
+					buf = append(buf, text...)
+				}
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("encode column %s: %w", column, err)
+			}
+			buf = append(buf, encoded...)
+		}
+		buf = append(buf, '}', '\n')
+		if _, err := e.writer.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendJSONKey(buf []byte, key string) []byte {
+	buf = append(buf, ',')
+	buf = appendJSONString(buf, key)
+	return append(buf, ':')
+}
+
+// appendJSONString append s as json string, the control characters are escaped and the invalid UTF-8 is replaced by
+// U+FFFD, so the line is always valid json
+func appendJSONString(buf []byte, s string) []byte {
+	// marshalling a string never fails
+	encoded, _ := json.Marshal(s)
+	return append(buf, encoded...)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return e.writer.Flush()
+}
+
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatExportTime format the time value in precision, the value is RFC3339 string or number in precision
+func formatExportTime(value any, precision Precision) (string, error) {
+	if s, ok := value.(string); ok {
+		if precision == PrecisionRFC3339 {
+			return s, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return "", fmt.Errorf("parse time %s: %w", s, err)
+		}
+		return strconv.FormatInt(precisionTimestamp(t, precision), 10), nil
+	}
+	n, ok := seriesValueToInt64(value)
+	if !ok {
+		return "", fmt.Errorf("unexpected time value %v", value)
+	}
+	if precision == PrecisionRFC3339 {
+		return time.Unix(0, n).UTC().Format(time.RFC3339Nano), nil
+	}
+	return strconv.FormatInt(n, 10), nil
+}
+
+// precisionTimestamp return the unix timestamp of t in precision
+func precisionTimestamp(t time.Time, precision Precision) int64 {
+	switch precision {
+	case PrecisionMicrosecond:
+		return t.UnixMicro()
+	case PrecisionMillisecond:
+		return t.UnixMilli()
+	case PrecisionSecond:
+		return t.Unix()
+	case PrecisionMinute:
+		return t.Unix() / 60
+	case PrecisionHour:
+		return t.Unix() / 3600
+	default:
+		return t.UnixNano()
+	}
+}
+
+// Export run the query in chunks and write the rows into w in format, the time column follows query.Precision,
+// use QueryChunked with NewResultEncoder to customize ExportConfig such as the null representation
+func (c *client) Export(ctx context.Context, query Query, format ExportFormat, w io.Writer) error {
+	encoder, err := NewResultEncoder(format, w, ExportConfig{Precision: query.Precision})
+	if err != nil {
+		return err
+	}
+	for chunk, err := range c.QueryChunked(ctx, query, exportChunkSize) {
+		if err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+		if err = encoder.Encode(chunk); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+	}
+	return encoder.Flush()
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testExportResult() *QueryResult {
+	return &QueryResult{Results: []*SeriesResult{{Series: []*Series{
+		{
+			Name:    "cpu",
+			Tags:    map[string]string{"region": "east", "host": "a"},
+			Columns: []string{"time", "usage", "msg"},
+			Values: SeriesValues{
+				{json.Number("1735689600000000001"), 0.5, "x,y"},
+				{json.Number("1735689600000000002"), nil, nil},
+			},
+		},
+		{
+			Name:    "cpu",
+			Tags:    map[string]string{"region": "west", "host": "b"},
+			Columns: []string{"time", "usage", "msg"},
+			Values:  SeriesValues{{json.Number("1735689600000000003"), float64(2), "z"}},
+		},
+		{
+			Name:    "mem",
+			Columns: []string{"time", "free"},
+			Values:  SeriesValues{{"2025-01-01T00:00:00Z", json.Number("1024")}},
+		},
+	}}}}
+}
+
+func TestCSVEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := NewCSVEncoder(&buf, ExportConfig{NullValue: "NULL"})
+	require.Nil(t, encoder.Encode(testExportResult()))
+	require.Nil(t, encoder.Flush())
+	require.Equal(t, `name,host,region,time,usage,msg
+cpu,a,east,1735689600000000001,0.5,"x,y"
+cpu,a,east,1735689600000000002,NULL,NULL
+cpu,b,west,1735689600000000003,2,z
+
+name,time,free
+mem,1735689600000000000,1024
+`, buf.String())
+
+	encoder = NewCSVEncoder(&buf, ExportConfig{})
+	require.NotNil(t, encoder.Encode(&QueryResult{Error: "database not found"}))
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	encoder, err := NewResultEncoder(ExportFormatNDJSON, &buf, ExportConfig{Precision: PrecisionRFC3339, OmitNull: true})
+	require.Nil(t, err)
+	require.Nil(t, encoder.Encode(testExportResult()))
+	require.Nil(t, encoder.Flush())
+	require.Equal(t, `{"name":"cpu","host":"a","region":"east","time":"2025-01-01T00:00:00.000000001Z","usage":0.5,"msg":"x,y"}
+{"name":"cpu","host":"a","region":"east","time":"2025-01-01T00:00:00.000000002Z"}
+{"name":"cpu","host":"b","region":"west","time":"2025-01-01T00:00:00.000000003Z","usage":2,"msg":"z"}
+{"name":"mem","time":"2025-01-01T00:00:00Z","free":1024}
+`, buf.String())
+
+	buf.Reset()
+	encoder = NewNDJSONEncoder(&buf, ExportConfig{Precision: PrecisionSecond})
+	require.Nil(t, encoder.Encode(&QueryResult{Results: []*SeriesResult{{Series: []*Series{
+		{Name: "mem", Columns: []string{"time", "free"}, Values: SeriesValues{{"2025-01-01T00:00:00Z", nil}}},
+	}}}}))
+	require.Nil(t, encoder.Flush())
+	require.Equal(t, `{"name":"mem","time":1735689600,"free":null}`+"\n", buf.String())
+
+	_, err = NewResultEncoder("parquet", &buf, ExportConfig{})
+	require.NotNil(t, err)
+}
+
+func TestNDJSONEncoderEscape(t *testing.T) {
+	const text = "a\x01b\vc\xff\"d\\"
+	var buf bytes.Buffer
+	encoder := NewNDJSONEncoder(&buf, ExportConfig{})
+	require.Nil(t, encoder.Encode(&QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Name:    text,
+		Tags:    map[string]string{text: text},
+		Columns: []string{text},
+		Values:  SeriesValues{{text}},
+	}}}}}))
+	require.Nil(t, encoder.Flush())
+
+	var row map[string]string
+	require.Nil(t, json.Unmarshal(buf.Bytes(), &row))
+	// the invalid UTF-8 is replaced, the control characters are kept
+	const decoded = "a\x01b\vc\ufffd\"d\\"
+	require.Equal(t, map[string]string{"name": decoded, decoded: decoded}, row)
+}
+
+func TestClientExport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "ms", request.URL.Query().Get("epoch"))
+		writer.Header().Set("Content-Type", HttpContentTypeJSON)
+		_, _ = writer.Write([]byte(`{"results":[{"series":[{"name":"cpu","columns":["time","v"],` +
+			`"values":[[1735689600000,1]],"partial":true}],"partial":true}]}` + "\n"))
+		_, _ = writer.Write([]byte(`{"results":[{"series":[{"name":"cpu","columns":["time","v"],` +
+			`"values":[[1735689600001,2.5]]}]}]}` + "\n"))
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}})
+
+	var buf bytes.Buffer
+	query := Query{Database: "db", Command: "SELECT v FROM cpu", Precision: PrecisionMillisecond}
+	require.Nil(t, c.Export(context.Background(), query, ExportFormatCSV, &buf))
+	require.Equal(t, "name,time,v\ncpu,1735689600000,1\ncpu,1735689600001,2.5\n", buf.String())
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AttributeRpcSystem         = "rpc.system"
+	AttributeRpcService        = "rpc.service"
+	AttributeRpcMethod         = "rpc.method"
+	AttributeRpcGrpcStatusCode = "rpc.grpc.status_code"
+)
+
+// NewOtelGrpcInterceptor create a unary interceptor for GrpcConfig.UnaryInterceptors, which traces every gRPC call
+// and propagates the trace context to the server through the metadata. A nil config uses the global providers
+func NewOtelGrpcInterceptor(config *OtelConfig) grpc.UnaryClientInterceptor {
+	if config == nil {
+		config = &OtelConfig{}
+	}
+	o := newOtelClient(config)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := strings.TrimPrefix(method, "/")
+		service, rpcMethod, _ := strings.Cut(name, "/")
+		attrs := []attribute.KeyValue{
+			attribute.String(AttributeDbSystem, DbSystemOpenGemini),
+			attribute.String(AttributeRpcSystem, "grpc"),
+			attribute.String(AttributeRpcService, service),
+			attribute.String(AttributeRpcMethod, rpcMethod),
+		}
+		attrs = append(attrs, serverAttributes(cc.Target())...)
+		ctx, span := o.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		otel.GetTextMapPropagator().Inject(ctx, &metadataCarrier{md: md})
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		code := status.Code(err)
+		span.SetAttributes(attribute.Int(AttributeRpcGrpcStatusCode, int(code)))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, code.String())
+			span.SetAttributes(attribute.String(AttributeErrorType, code.String()))
+		}
+		return err
+	}
+}
+
+// metadataCarrier adapts the gRPC metadata to propagation.TextMapCarrier
+type metadataCarrier struct {
+	md metadata.MD
+}
+
+func (c *metadataCarrier) Get(key string) string {
+	values := c.md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c *metadataCarrier) Set(key string, value string) {
+	c.md.Set(key, value)
+}
+
+func (c *metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.md))
+	for key := range c.md {
+		keys = append(keys, key)
+	}
+	return keys
+}
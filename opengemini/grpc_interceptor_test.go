@@ -0,0 +1,127 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+type testMetadataWriteServer struct {
+	proto.UnimplementedWriteServiceServer
+	md chan metadata.MD
+}
+
+func (s *testMetadataWriteServer) Write(ctx context.Context, _ *proto.WriteRequest) (*proto.WriteResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.md <- md
+	return &proto.WriteResponse{Code: proto.ResponseCode_Success}, nil
+}
+
+// sampledSpan give the test span a valid span context so that it can be propagated
+type sampledSpan struct {
+	*testSpan
+}
+
+func (s sampledSpan) SpanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+type sampledTracerProvider struct {
+	*testTracerProvider
+}
+
+func (p sampledTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return sampledTracer{Tracer: p.testTracerProvider.Tracer(name, opts...)}
+}
+
+type sampledTracer struct {
+	trace.Tracer
+}
+
+func (t sampledTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx, span := t.Tracer.Start(ctx, name, opts...)
+	sampled := sampledSpan{testSpan: span.(*testSpan)}
+	return trace.ContextWithSpan(ctx, sampled), sampled
+}
+
+func TestGrpcConfigInterceptors(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(previous)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	server := grpc.NewServer()
+	writeServer := &testMetadataWriteServer{md: make(chan metadata.MD, 1)}
+	proto.RegisterWriteServiceServer(server, writeServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	provider := &testTracerProvider{}
+	var called []string
+	auth := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		called = append(called, method)
+		return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token"), method, req, reply, cc, opts...)
+	}
+	address := listener.Addr().(*net.TCPAddr)
+	c := testNewClient(t, &Config{
+		Addresses: []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{
+			Addresses:         []Address{{Host: "127.0.0.1", Port: address.Port}},
+			UnaryInterceptors: []grpc.UnaryClientInterceptor{auth, NewOtelGrpcInterceptor(&OtelConfig{TracerProvider: sampledTracerProvider{provider}})},
+			DialOptions:       []grpc.DialOption{grpc.WithUserAgent("opengemini-test")},
+		},
+	})
+	defer c.Close()
+
+	recordBuilder, err := NewRecordBuilder("cpu")
+	require.Nil(t, err)
+	builder, err := NewWriteRequestBuilder("db0", "")
+	require.Nil(t, err)
+	request, err := builder.AddRecord(recordBuilder.AddField("v", 1.5).Build(time.Now().UnixNano())).Build()
+	require.Nil(t, err)
+	require.Nil(t, c.WriteByGrpc(context.Background(), request))
+
+	md := <-writeServer.md
+	require.Equal(t, []string{"/proto.WriteService/Write"}, called)
+	require.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+	require.Equal(t, []string{"00-01000000000000000000000000000000-0200000000000000-01"}, md.Get("traceparent"))
+	require.Contains(t, md.Get("user-agent")[0], "opengemini-test")
+
+	span := provider.span(t, "proto.WriteService/Write")
+	require.True(t, span.ended)
+	require.Equal(t, codes.Unset, span.status)
+	require.Equal(t, "Write", span.attrs[AttributeRpcMethod].AsString())
+	require.Equal(t, "proto.WriteService", span.attrs[AttributeRpcService].AsString())
+	require.Equal(t, int64(0), span.attrs[AttributeRpcGrpcStatusCode].AsInt64())
+	require.Equal(t, int64(address.Port), span.attrs[AttributeServerPort].AsInt64())
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+// writeByGrpcRetry send the attempts one after another with exponential backoff, each one to another endpoint
+func (c *client) writeByGrpcRetry(ctx context.Context, req *proto.WriteRequest, retry *GrpcRetryConfig) error {
+	var tried []*grpcEndpoint
+	var err error
+	backoff := retry.InitialBackoff
+	for attempt := 0; attempt < retry.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(withJitter(backoff))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff = min(backoff*2, retry.MaxBackoff)
+			c.metrics.grpcWriteRetries.WithLabelValues(req.Database).Inc()
+		}
+		ep := c.rpcClient.lb.nextEndpoint(tried)
+		tried = append(tried, ep)
+		err = c.writeByGrpcAttempt(ctx, req, ep)
+		if err == nil || !isRetryable(err, retry.RetryableCodes) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// writeByGrpcHedging send another attempt whenever the attempts in flight don't answer within the hedging delay or
+// fail with a retryable code, the first success or the first non retryable error is returned
+func (c *client) writeByGrpcHedging(ctx context.Context, req *proto.WriteRequest, retry *GrpcRetryConfig) error {
+	// the attempts still in flight are cancelled once the result is known
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan error, retry.MaxAttempts)
+	var tried []*grpcEndpoint
+	start := func() {
+		ep := c.rpcClient.lb.nextEndpoint(tried)
+		tried = append(tried, ep)
+		if len(tried) > 1 {
+			c.metrics.grpcWriteRetries.WithLabelValues(req.Database).Inc()
+		}
+		go func() {
+			results <- c.writeByGrpcAttempt(ctx, req, ep)
+		}()
+	}
+
+	start()
+	inFlight := 1
+	timer := time.NewTimer(retry.HedgingDelay)
+	defer timer.Stop()
+	var err error
+	for inFlight > 0 {
+		select {
+		case <-timer.C:
+			if len(tried) < retry.MaxAttempts {
+				start()
+				inFlight++
+				timer.Reset(retry.HedgingDelay)
+			}
+		case err = <-results:
+			inFlight--
+			if err == nil || !isRetryable(err, retry.RetryableCodes) {
+				return err
+			}
+			if len(tried) < retry.MaxAttempts && ctx.Err() == nil {
+				start()
+				inFlight++
+				timer.Reset(retry.HedgingDelay)
+			}
+		}
+	}
+	return err
+}
+
+func isRetryable(err error, retryableCodes []codes.Code) bool {
+	return slices.Contains(retryableCodes, status.Code(err))
+}
+
+// withJitter spread the retries of the clients over ±20% of delay, so that they don't hit a restarted server at once
+func withJitter(delay time.Duration) time.Duration {
+	spread := int64(delay) / 5
+	if spread <= 0 {
+		return delay
+	}
+	return delay - time.Duration(spread) + time.Duration(rand.Int64N(2*spread))
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+type testRetryWriteServer struct {
+	proto.UnimplementedWriteServiceServer
+	code  codes.Code
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (s *testRetryWriteServer) Write(ctx context.Context, _ *proto.WriteRequest) (*proto.WriteResponse, error) {
+	s.calls.Add(1)
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if s.code != codes.OK {
+		return nil, status.Error(s.code, "injected failure")
+	}
+	return &proto.WriteResponse{Code: proto.ResponseCode_Success}, nil
+}
+
+func (s *testRetryWriteServer) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	return &proto.PingResponse{Status: proto.ServerStatus_Up}, nil
+}
+
+func testRetryRequest(t *testing.T) *proto.WriteRequest {
+	recordBuilder, err := NewRecordBuilder("cpu")
+	require.Nil(t, err)
+	builder, err := NewWriteRequestBuilder("db0", "")
+	require.Nil(t, err)
+	request, err := builder.AddRecord(recordBuilder.AddField("v", 1.5).Build(time.Now().UnixNano())).Build()
+	require.Nil(t, err)
+	return request
+}
+
+func TestWriteByGrpcRetry(t *testing.T) {
+	healthy := &testRetryWriteServer{}
+	unavailable := &testRetryWriteServer{code: codes.Unavailable}
+	c := testNewClient(t, &Config{
+		Addresses: []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{
+			Addresses:         []Address{testStartGrpcServer(t, healthy), testStartGrpcServer(t, unavailable)},
+			EjectionThreshold: 2,
+			RetryConfig:       &GrpcRetryConfig{InitialBackoff: time.Millisecond},
+		},
+	})
+	defer c.Close()
+
+	request := testRetryRequest(t)
+	for i := 0; i < 6; i++ {
+		require.Nil(t, c.WriteByGrpc(context.Background(), request))
+	}
+	// the unavailable endpoint is ejected after two failures, the other writes go to the healthy one
+	require.Equal(t, int32(2), unavailable.calls.Load())
+	require.Equal(t, int32(6), healthy.calls.Load())
+
+	retries := testGatherMetric(t, c.ExposeMetrics(), "grpc_write_retries_total", map[string]string{"database": "db0"})
+	require.Equal(t, float64(2), retries.GetCounter().GetValue())
+}
+
+func TestWriteByGrpcRetryNotRetryable(t *testing.T) {
+	invalid := &testRetryWriteServer{code: codes.InvalidArgument}
+	c := testNewClient(t, &Config{
+		Addresses: []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{
+			Addresses:   []Address{testStartGrpcServer(t, invalid)},
+			RetryConfig: &GrpcRetryConfig{MaxAttempts: 5, InitialBackoff: time.Millisecond},
+		},
+	})
+	defer c.Close()
+
+	err := c.WriteByGrpc(context.Background(), testRetryRequest(t))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, int32(1), invalid.calls.Load())
+
+	unavailable := &testRetryWriteServer{code: codes.Unavailable}
+	c = testNewClient(t, &Config{
+		Addresses: []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{
+			Addresses:         []Address{testStartGrpcServer(t, unavailable)},
+			EjectionThreshold: -1,
+			RetryConfig:       &GrpcRetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		},
+	})
+	defer c.Close()
+
+	err = c.WriteByGrpc(context.Background(), testRetryRequest(t))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, int32(3), unavailable.calls.Load())
+}
+
+func TestWriteByGrpcHedging(t *testing.T) {
+	fast := &testRetryWriteServer{}
+	slow := &testRetryWriteServer{delay: 5 * time.Second}
+	c := testNewClient(t, &Config{
+		Addresses: []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{
+			// the round robin starts with the second endpoint
+			Addresses:   []Address{testStartGrpcServer(t, fast), testStartGrpcServer(t, slow)},
+			RetryConfig: &GrpcRetryConfig{HedgingDelay: 20 * time.Millisecond},
+		},
+	})
+	defer c.Close()
+
+	startAt := time.Now()
+	require.Nil(t, c.WriteByGrpc(context.Background(), testRetryRequest(t)))
+	require.Less(t, time.Since(startAt), time.Second)
+	require.Equal(t, int32(1), slow.calls.Load())
+	require.Equal(t, int32(1), fast.calls.Load())
+}
+
+func TestWriteByGrpcAbandonedAttempt(t *testing.T) {
+	fast := &testRetryWriteServer{}
+	slow := &testRetryWriteServer{delay: 5 * time.Second}
+	c := testNewClient(t, &Config{
+		Addresses: []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{
+			Addresses:         []Address{testStartGrpcServer(t, fast), testStartGrpcServer(t, slow)},
+			EjectionThreshold: 1,
+			RetryConfig:       &GrpcRetryConfig{HedgingDelay: 20 * time.Millisecond},
+		},
+	})
+	defer c.Close()
+	slowEndpoint := c.(*client).rpcClient.lb.endpoints[1]
+
+	// the attempt to the slow endpoint is cancelled once the hedged attempt succeeds
+	require.Nil(t, c.WriteByGrpc(context.Background(), testRetryRequest(t)))
+	require.Never(t, func() bool {
+		return slowEndpoint.failures.Load() != 0 || slowEndpoint.ejectedUntil.Load() != 0
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	// the deadline of the caller is exceeded
+	c = testNewClient(t, &Config{
+		Addresses: []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{
+			Addresses:         []Address{testStartGrpcServer(t, slow)},
+			EjectionThreshold: 1,
+		},
+	})
+	defer c.Close()
+	slowEndpoint = c.(*client).rpcClient.lb.endpoints[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := c.WriteByGrpc(ctx, testRetryRequest(t))
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.Equal(t, int32(0), slowEndpoint.failures.Load())
+	require.Equal(t, int64(0), slowEndpoint.ejectedUntil.Load())
+}
+
+func TestGrpcEndpointEjection(t *testing.T) {
+	// the connection is idle until the first call
+	conn, err := grpc.NewClient("127.0.0.1:1", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.Nil(t, err)
+	defer conn.Close()
+	lb := &grpcLoadBalance{
+		ejectionThreshold: 2, ejectionDuration: time.Hour, healthCheck: true, retryableCodes: defaultGrpcRetryableCodes,
+	}
+	ep := &grpcEndpoint{conn: conn}
+	lb.report(ep, status.Error(codes.Unavailable, "down"))
+	lb.report(ep, nil)
+	lb.report(ep, status.Error(codes.Unavailable, "down"))
+	require.True(t, lb.isAvailable(ep))
+	lb.report(ep, status.Error(codes.DeadlineExceeded, "slow"))
+	require.False(t, lb.isAvailable(ep))
+
+	ep.ejectedUntil.Store(time.Now().Add(-time.Second).UnixNano())
+	require.True(t, lb.isAvailable(ep))
+}
+
+func TestGrpcEndpointEjectionRetryableCodes(t *testing.T) {
+	lb, err := newRPCLoadBalance(grpcConfigWithDefaults(&GrpcConfig{
+		Addresses:           []Address{{Host: "127.0.0.1", Port: 1}},
+		HealthCheckInterval: -1,
+		EjectionThreshold:   1,
+		RetryConfig:         &GrpcRetryConfig{RetryableCodes: []codes.Code{codes.Internal}},
+	}))
+	require.Nil(t, err)
+	defer lb.Close()
+	ep := lb.endpoints[0]
+
+	// only the configured codes count towards the ejection
+	lb.report(ep, status.Error(codes.Unavailable, "down"))
+	require.Equal(t, int64(0), ep.ejectedUntil.Load())
+	lb.report(ep, status.Error(codes.Internal, "failed"))
+	require.Greater(t, ep.ejectedUntil.Load(), time.Now().UnixNano())
+}
@@ -15,12 +15,17 @@
 package opengemini
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"errors"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type requestDetails struct {
@@ -31,7 +36,7 @@ type requestDetails struct {
 
 func (req *requestDetails) toQuery() *InterceptorQuery {
 	if req.queryValues == nil {
-		return &InterceptorQuery{}
+		return &InterceptorQuery{Query: &Query{}}
 	}
 	return &InterceptorQuery{
 		Query: &Query{
@@ -47,10 +52,22 @@ func (req *requestDetails) toWrite() *InterceptorWrite {
 	if req.queryValues == nil {
 		return &InterceptorWrite{}
 	}
-	body, err := io.ReadAll(req.body)
-	if err != nil {
+	// the request shares the buffer, peek at it without consuming
+	buffer, ok := req.body.(*bytes.Buffer)
+	if !ok {
 		return &InterceptorWrite{}
 	}
+	body := buffer.Bytes()
+	if req.header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return &InterceptorWrite{}
+		}
+		body, err = io.ReadAll(reader)
+		if err != nil {
+			return &InterceptorWrite{}
+		}
+	}
 	return &InterceptorWrite{
 		Database:        req.queryValues.Get("db"),
 		RetentionPolicy: req.queryValues.Get("rp"),
@@ -152,27 +169,61 @@ func (c *client) executeHttpRequestInner(ctx context.Context, method, serverUrl,
 		}
 	}
 
+	operation := strings.TrimPrefix(urlPath, "/")
+	endpoint := u.Host
+
 	var closures []InterceptorClosure
+	var errorClosures []InterceptorErrorClosure
 	for _, interceptor := range c.interceptors {
 		var closure InterceptorClosure
+		var errorClosure InterceptorErrorClosure
+		errorInterceptor, observeError := interceptor.(TransportErrorInterceptor)
 		switch urlPath {
 		case UrlWrite:
 			var data = details.toWrite()
-			closure = interceptor.Write(ctx, data)
+			data.ServerAddress = endpoint
+			if observeError {
+				closure, errorClosure = errorInterceptor.WriteWithError(ctx, data)
+			} else {
+				closure = interceptor.Write(ctx, data)
+			}
 		default:
 			var data = details.toQuery()
-			closure = interceptor.Query(ctx, data)
+			data.ServerAddress = endpoint
+			if observeError {
+				closure, errorClosure = errorInterceptor.QueryWithError(ctx, data)
+			} else {
+				closure = interceptor.Query(ctx, data)
+			}
 		}
 		closures = append(closures, closure)
+		if errorClosure != nil {
+			errorClosures = append(errorClosures, errorClosure)
+		}
 	}
 
+	c.metrics.countSent(operation, endpoint, request.ContentLength)
+	startAt := time.Now()
+
 	response, err := c.cli.Do(request)
 	if err != nil {
+		c.metrics.countError(operation, endpoint, transportErrorType(err), "")
+		for _, fn := range errorClosures {
+			fn(ctx, err)
+		}
 		return nil, err
 	}
 
+	c.metrics.observeRequest(operation, details.queryValues.Get("db"), endpoint, time.Since(startAt))
+	response.Body = c.metrics.countReceived(operation, endpoint, response.Body)
+	code := strconv.Itoa(response.StatusCode)
+	if response.StatusCode >= http.StatusBadRequest {
+		c.metrics.countError(operation, endpoint, errorTypeStatus, code)
+	}
+
 	for _, fn := range closures {
 		if err := fn(ctx, response); err != nil {
+			c.metrics.countError(operation, endpoint, errorTypeInterceptor, code)
 			return nil, err
 		}
 	}
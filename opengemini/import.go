@@ -0,0 +1,495 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ImportFormat string
+
+const (
+	ImportFormatLineProtocol ImportFormat = "line_protocol"
+	// ImportFormatCSV the annotated CSV, the `#datatype` annotation specifies the role of columns, such as
+	//
+	//	#datatype measurement,tag,double,long,dateTime:RFC3339
+	//	m,host,usage,count,time
+	//	cpu,a,0.5,3,2025-01-01T00:00:00Z
+	//
+	// the types are measurement, tag, double, long, unsignedLong, boolean, string, field (inferred),
+	// dateTime[:RFC3339|:number] and ignored, `#constant` adds a column to all rows, such as `#constant tag,region,east`.
+	// Without `#datatype`, column `measurement` or `_measurement` is the measurement, column `time` or `_time` is the
+	// time, the others are fields with inferred type
+	ImportFormatCSV ImportFormat = "csv"
+)
+
+const defaultImportBatchSize = 5000
+
+// ImportConfig configure Client.Import
+type ImportConfig struct {
+	// Database the database to write, it is required
+	Database string
+	// RetentionPolicy the retention policy to write, use default retention policy if empty
+	RetentionPolicy string
+	// Format the format of input, default is ImportFormatLineProtocol
+	Format ImportFormat
+	// Precision the precision of numeric timestamps in input, default is PrecisionNanosecond
+	Precision Precision
+	// BatchSize the number of points of each write request, default is 5000
+	BatchSize int
+	// UseGrpc write the batches as gRPC records by WriteByGrpc, GrpcConfig of client is required
+	UseGrpc bool
+	// Checkpoint the offset of the uncompressed input to resume from, usually ImportProgress.Offset reported by
+	// the interrupted import, the lines before it are skipped
+	Checkpoint int64
+	// DeadLetter receive the rejected lines, each line is preceded by a `# reason` comment line
+	DeadLetter io.Writer
+	// Progress is called after each batch is written
+	Progress func(progress ImportProgress)
+}
+
+// ImportProgress the progress of Client.Import
+type ImportProgress struct {
+	// Offset the offset of the uncompressed input after the last written batch, it is the checkpoint to resume from
+	Offset int64
+	// Lines the number of data lines read, including the skipped and rejected lines
+	Lines int64
+	// Points the number of points written
+	Points int64
+	// Rejected the number of lines which can't be parsed
+	Rejected int64
+}
+
+type importer struct {
+	client   *client
+	ctx      context.Context
+	config   ImportConfig
+	progress ImportProgress
+	batch    []*Point
+	// offset the offset after the last line which is added to batch or rejected
+	offset int64
+}
+
+// Import read line protocol or annotated CSV from r and write it in batches, the gzip input is detected by magic
+// number. The lines which can't be parsed are written to ImportConfig.DeadLetter, an error of writing batch stops the
+// import, resume it by ImportProgress.Offset as ImportConfig.Checkpoint
+func (c *client) Import(ctx context.Context, r io.Reader, config ImportConfig) (ImportProgress, error) {
+	if err := checkDatabaseName(config.Database); err != nil {
+		return ImportProgress{}, err
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultImportBatchSize
+	}
+	if config.UseGrpc && c.rpcClient == nil {
+		return ImportProgress{}, errors.New("import by gRPC requires GrpcConfig")
+	}
+
+	reader, err := decompressImport(r)
+	if err != nil {
+		return ImportProgress{}, err
+	}
+
+	im := &importer{client: c, ctx: ctx, config: config, offset: config.Checkpoint}
+	im.progress.Offset = config.Checkpoint
+	switch config.Format {
+	case ImportFormatLineProtocol, "":
+		err = im.importLineProtocol(reader)
+	case ImportFormatCSV:
+		err = im.importCSV(reader)
+	default:
+		err = fmt.Errorf("unsupported import format: %s", config.Format)
+	}
+	if err != nil {
+		return im.progress, err
+	}
+	return im.progress, im.flush()
+}
+
+// decompressImport detect gzip input by the magic number
+func decompressImport(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		reader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("open gzip input: %w", err)
+		}
+		return reader, nil
+	}
+	return buffered, nil
+}
+
+func (im *importer) importLineProtocol(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	var offset int64
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			offset += int64(len(line))
+			if err := im.importLine(line, offset); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (im *importer) importLine(line string, end int64) error {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+	im.progress.Lines++
+	if end <= im.config.Checkpoint {
+		return nil
+	}
+	point, err := parseLineProtocolStrict(line)
+	if err != nil {
+		return im.reject(line, err, end)
+	}
+	point.Timestamp = toNanoseconds(point.Timestamp, im.config.Precision)
+	return im.add(point, end)
+}
+
+// importCSV read the CSV by physical lines rather than csv.Reader, because a blank line separates the tables which
+// have their own annotations and header, and csv.Reader skips it silently
+func (im *importer) importCSV(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	var schema csvImportSchema
+	var offset int64
+	var pending strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		offset += int64(len(line))
+		pending.WriteString(line)
+		// a quoted value may contain line breaks, the record completes when the quotes are balanced
+		if strings.Count(pending.String(), `"`)%2 == 1 && err == nil {
+			continue
+		}
+		text := strings.TrimRight(pending.String(), "\r\n")
+		pending.Reset()
+
+		if strings.TrimSpace(text) == "" {
+			schema = csvImportSchema{}
+		} else if err := im.importCSVRecord(&schema, text, offset); err != nil {
+			return err
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+func (im *importer) importCSVRecord(schema *csvImportSchema, text string, end int64) error {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	record, err := reader.Read()
+	if err != nil {
+		im.progress.Lines++
+		if end <= im.config.Checkpoint {
+			return nil
+		}
+		return im.reject(text, err, end)
+	}
+	if strings.HasPrefix(record[0], "#") {
+		if schema.header != nil {
+			// annotations of the next table without blank line
+			*schema = csvImportSchema{}
+		}
+		return schema.annotate(record)
+	}
+	if schema.header == nil {
+		return schema.setHeader(record)
+	}
+
+	im.progress.Lines++
+	if end <= im.config.Checkpoint {
+		return nil
+	}
+	point, err := schema.point(record, im.config.Precision)
+	if err != nil {
+		return im.reject(text, err, end)
+	}
+	return im.add(point, end)
+}
+
+func (im *importer) add(point *Point, end int64) error {
+	im.batch = append(im.batch, point)
+	im.offset = end
+	if len(im.batch) >= im.config.BatchSize {
+		return im.flush()
+	}
+	return nil
+}
+
+func (im *importer) reject(line string, reason error, end int64) error {
+	im.progress.Rejected++
+	im.offset = end
+	if im.config.DeadLetter == nil {
+		return nil
+	}
+	comment := strings.ReplaceAll(reason.Error(), "\n", " ")
+	_, err := io.WriteString(im.config.DeadLetter, "# "+comment+"\n"+line+"\n")
+	return err
+}
+
+func (im *importer) flush() error {
+	if len(im.batch) > 0 {
+		if err := im.ctx.Err(); err != nil {
+			return err
+		}
+		var err error
+		if im.config.UseGrpc {
+			err = im.writeRecords(im.batch)
+		} else {
+			err = im.client.WriteBatchPointsWithRp(im.ctx, im.config.Database, im.config.RetentionPolicy, im.batch)
+		}
+		if err != nil {
+			return fmt.Errorf("import batch after offset %d: %w", im.progress.Offset, err)
+		}
+		im.progress.Points += int64(len(im.batch))
+		im.batch = nil
+	}
+	im.progress.Offset = im.offset
+	if im.config.Progress != nil {
+		im.config.Progress(im.progress)
+	}
+	return nil
+}
+
+func (im *importer) writeRecords(points []*Point) error {
+	writer := &grpcWriter{client: im.client}
+	return writer.WritePoints(im.ctx, im.config.Database, im.config.RetentionPolicy, points)
+}
+
+// toNanoseconds convert the timestamp in precision to nanoseconds, zero means the server time
+func toNanoseconds(timestamp int64, precision Precision) int64 {
+	switch precision {
+	case PrecisionMicrosecond:
+		return timestamp * int64(time.Microsecond)
+	case PrecisionMillisecond:
+		return timestamp * int64(time.Millisecond)
+	case PrecisionSecond:
+		return timestamp * int64(time.Second)
+	case PrecisionMinute:
+		return timestamp * int64(time.Minute)
+	case PrecisionHour:
+		return timestamp * int64(time.Hour)
+	default:
+		return timestamp
+	}
+}
+
+const (
+	csvColumnMeasurement = "measurement"
+	csvColumnTag         = "tag"
+	csvColumnField       = "field"
+	csvColumnDouble      = "double"
+	csvColumnLong        = "long"
+	csvColumnUnsigned    = "unsignedLong"
+	csvColumnBoolean     = "boolean"
+	csvColumnString      = "string"
+	csvColumnTime        = "dateTime"
+	csvColumnIgnored     = "ignored"
+)
+
+type csvImportColumn struct {
+	name     string
+	dataType string
+	// format the format of dateTime, RFC3339 or number, empty means detected by value
+	format string
+}
+
+type csvImportSchema struct {
+	dataTypes []string
+	constants []csvImportConstant
+	header    []string
+	columns   []csvImportColumn
+}
+
+type csvImportConstant struct {
+	column csvImportColumn
+	value  string
+}
+
+// annotate parse the annotation row, the first cell is the annotation followed by a space and the first value
+func (s *csvImportSchema) annotate(record []string) error {
+	name, first, _ := strings.Cut(record[0], " ")
+	values := append([]string{first}, record[1:]...)
+	switch name {
+	case "#datatype":
+		s.dataTypes = values
+	case "#constant":
+		column, err := parseCSVColumnType(values[0])
+		if err != nil {
+			return err
+		}
+		// the name of measurement constant is optional, such as `#constant measurement,cpu`
+		if column.dataType == csvColumnMeasurement && len(values) == 2 {
+			values = []string{values[0], "", values[1]}
+		}
+		if len(values) != 3 {
+			return fmt.Errorf("invalid annotation %s", strings.Join(record, ","))
+		}
+		column.name = values[1]
+		s.constants = append(s.constants, csvImportConstant{column: column, value: values[2]})
+	}
+	// the other annotations such as #group and #default are ignored
+	return nil
+}
+
+// setHeader resolve the columns by the header and the #datatype annotation, the table with unsupported data type is
+// rejected rather than importing the column as field
+func (s *csvImportSchema) setHeader(record []string) error {
+	s.header = append([]string(nil), record...)
+	s.columns = make([]csvImportColumn, len(record))
+	for i, name := range s.header {
+		column := csvImportColumn{name: name, dataType: csvColumnField}
+		if i < len(s.dataTypes) && s.dataTypes[i] != "" {
+			parsed, err := parseCSVColumnType(s.dataTypes[i])
+			if err != nil {
+				return fmt.Errorf("column %s: %w", name, err)
+			}
+			column.dataType, column.format = parsed.dataType, parsed.format
+		} else {
+			switch name {
+			case "measurement", "_measurement":
+				column.dataType = csvColumnMeasurement
+			case "time", "_time":
+				column.dataType = csvColumnTime
+			}
+		}
+		s.columns[i] = column
+	}
+	return nil
+}
+
+func parseCSVColumnType(dataType string) (csvImportColumn, error) {
+	dataType, format, _ := strings.Cut(dataType, ":")
+	switch dataType {
+	case csvColumnMeasurement, csvColumnTag, csvColumnField, csvColumnDouble, csvColumnLong, csvColumnUnsigned,
+		csvColumnBoolean, csvColumnString, csvColumnIgnored:
+		return csvImportColumn{dataType: dataType}, nil
+	case "ignore":
+		return csvImportColumn{dataType: csvColumnIgnored}, nil
+	case csvColumnTime:
+		return csvImportColumn{dataType: dataType, format: format}, nil
+	default:
+		return csvImportColumn{}, fmt.Errorf("unsupported csv data type %s", dataType)
+	}
+}
+
+func (s *csvImportSchema) point(record []string, precision Precision) (*Point, error) {
+	point := &Point{Tags: make(map[string]string), Fields: make(map[string]any)}
+	for _, constant := range s.constants {
+		if err := setCSVValue(point, constant.column, constant.value, precision); err != nil {
+			return nil, err
+		}
+	}
+	for i, value := range record {
+		if i >= len(s.columns) {
+			return nil, fmt.Errorf("expect %d columns, got %d", len(s.columns), len(record))
+		}
+		if value == "" {
+			continue
+		}
+		if err := setCSVValue(point, s.columns[i], value, precision); err != nil {
+			return nil, err
+		}
+	}
+	if point.Measurement == "" {
+		return nil, errors.New("measurement name is required")
+	}
+	if len(point.Fields) == 0 {
+		return nil, errors.New("at least one field is required")
+	}
+	return point, nil
+}
+
+func setCSVValue(point *Point, column csvImportColumn, value string, precision Precision) error {
+	var err error
+	switch column.dataType {
+	case csvColumnMeasurement:
+		point.Measurement = value
+	case csvColumnTag:
+		point.Tags[column.name] = value
+	case csvColumnTime:
+		point.Timestamp, err = parseCSVTime(value, column.format, precision)
+	case csvColumnDouble:
+		point.Fields[column.name], err = strconv.ParseFloat(value, 64)
+	case csvColumnLong:
+		point.Fields[column.name], err = strconv.ParseInt(value, 10, 64)
+	case csvColumnUnsigned:
+		point.Fields[column.name], err = strconv.ParseUint(value, 10, 64)
+	case csvColumnBoolean:
+		point.Fields[column.name], err = strconv.ParseBool(value)
+	case csvColumnString:
+		point.Fields[column.name] = value
+	case csvColumnField:
+		point.Fields[column.name] = inferCSVValue(value)
+	}
+	if err != nil {
+		return fmt.Errorf("column %s: %w", column.name, err)
+	}
+	return nil
+}
+
+func parseCSVTime(value, format string, precision Precision) (int64, error) {
+	if format != "RFC3339" && format != "RFC3339Nano" {
+		timestamp, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
+			return toNanoseconds(timestamp, precision), nil
+		}
+		if format == "number" {
+			return 0, err
+		}
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixNano(), nil
+}
+
+// inferCSVValue infer the type of field without annotation, numbers are float because `1` may be a float field
+func inferCSVValue(value string) any {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b
+	}
+	return value
+}
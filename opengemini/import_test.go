@@ -0,0 +1,199 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testImportClient start a server which records the body of each write request, the nth request fails when
+// fail(n) returns true
+func testImportClient(t *testing.T, fail func(batch int) bool) (Client, *[]string) {
+	var batches []string
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, UrlWrite, request.URL.Path)
+		body, err := io.ReadAll(request.Body)
+		require.Nil(t, err)
+		requests++
+		if fail != nil && fail(requests) {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		batches = append(batches, string(body))
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	return testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}}), &batches
+}
+
+func TestImportLineProtocol(t *testing.T) {
+	c, batches := testImportClient(t, nil)
+	input := "# comment\ncpu,host=a v=1 1\n\ncpu,host=b v=2 2\nbad line\ncpu,host=c v=3 3\n"
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write([]byte(input))
+	require.Nil(t, err)
+	require.Nil(t, gz.Close())
+
+	var deadLetter bytes.Buffer
+	var reports []ImportProgress
+	progress, err := c.Import(context.Background(), &compressed, ImportConfig{
+		Database:   "db0",
+		Precision:  PrecisionSecond,
+		BatchSize:  2,
+		DeadLetter: &deadLetter,
+		Progress:   func(progress ImportProgress) { reports = append(reports, progress) },
+	})
+	require.Nil(t, err)
+	require.Equal(t, ImportProgress{Offset: int64(len(input)), Lines: 4, Points: 3, Rejected: 1}, progress)
+	require.Equal(t, []string{
+		"cpu,host=a v=1 1000000000\ncpu,host=b v=2 2000000000",
+		"cpu,host=c v=3 3000000000",
+	}, trimBatches(*batches))
+	require.Len(t, reports, 2)
+	require.Equal(t, int64(strings.Index(input, "bad")), reports[0].Offset)
+	require.True(t, strings.HasPrefix(deadLetter.String(), "# "))
+	require.True(t, strings.HasSuffix(deadLetter.String(), "\nbad line\n"))
+}
+
+func TestImportKeepsFieldTypes(t *testing.T) {
+	c, batches := testImportClient(t, nil)
+	input := "cpu s=\"42\" 1\ncpu b=\"true\" 2\ncpu v=abc 3\ncpu v=1i -1\n"
+
+	var deadLetter bytes.Buffer
+	progress, err := c.Import(context.Background(), strings.NewReader(input), ImportConfig{
+		Database:   "db0",
+		DeadLetter: &deadLetter,
+	})
+	require.Nil(t, err)
+	require.Equal(t, ImportProgress{Offset: int64(len(input)), Lines: 4, Points: 3, Rejected: 1}, progress)
+	require.Equal(t, []string{"cpu s=\"42\" 1\ncpu b=\"true\" 2\ncpu v=1i -1"}, trimBatches(*batches))
+	require.True(t, strings.HasSuffix(deadLetter.String(), "\ncpu v=abc 3\n"))
+}
+
+func TestImportResumeFromCheckpoint(t *testing.T) {
+	c, batches := testImportClient(t, func(request int) bool { return request == 2 })
+	input := "cpu v=1 1\ncpu v=2 2\ncpu v=3 3\n"
+	config := ImportConfig{Database: "db0", BatchSize: 1}
+
+	progress, err := c.Import(context.Background(), strings.NewReader(input), config)
+	require.NotNil(t, err)
+	require.Equal(t, int64(len("cpu v=1 1\n")), progress.Offset)
+	require.Equal(t, int64(1), progress.Points)
+
+	config.Checkpoint = progress.Offset
+	progress, err = c.Import(context.Background(), strings.NewReader(input), config)
+	require.Nil(t, err)
+	require.Equal(t, int64(len(input)), progress.Offset)
+	require.Equal(t, int64(2), progress.Points)
+	require.Equal(t, []string{"cpu v=1 1", "cpu v=2 2", "cpu v=3 3"}, trimBatches(*batches))
+}
+
+func TestImportAnnotatedCSV(t *testing.T) {
+	c, batches := testImportClient(t, nil)
+	input := `#datatype measurement,tag,double,long,boolean,ignored,dateTime:RFC3339
+#constant tag,region,east
+m,host,usage,count,ok,note,time
+cpu,a,0.5,3,true,x,2025-01-01T00:00:00Z
+cpu,b,bad,4,false,y,2025-01-01T00:00:01Z
+cpu,c,,5,,z,2025-01-01T00:00:02Z
+
+measurement,time,free,state
+mem,1735689600,1024,up
+`
+	var deadLetter bytes.Buffer
+	progress, err := c.Import(context.Background(), strings.NewReader(input), ImportConfig{
+		Database:   "db0",
+		Format:     ImportFormatCSV,
+		Precision:  PrecisionSecond,
+		DeadLetter: &deadLetter,
+	})
+	require.Nil(t, err)
+	require.Equal(t, ImportProgress{Offset: int64(len(input)), Lines: 4, Points: 3, Rejected: 1}, progress)
+	require.Len(t, *batches, 1)
+	var points []*Point
+	for _, line := range strings.Split(trimBatches(*batches)[0], "\n") {
+		point, err := parseLineProtocolToPoint(line)
+		require.Nil(t, err)
+		points = append(points, point)
+	}
+	require.Equal(t, []*Point{
+		{
+			Measurement: "cpu",
+			Tags:        map[string]string{"host": "a", "region": "east"},
+			Fields:      map[string]any{"usage": 0.5, "count": int64(3), "ok": true},
+			Timestamp:   1735689600000000000,
+		},
+		{
+			Measurement: "cpu",
+			Tags:        map[string]string{"host": "c", "region": "east"},
+			Fields:      map[string]any{"count": int64(5)},
+			Timestamp:   1735689602000000000,
+		},
+		{
+			Measurement: "mem",
+			Tags:        map[string]string{},
+			Fields:      map[string]any{"free": float64(1024), "state": "up"},
+			Timestamp:   1735689600000000000,
+		},
+	}, points)
+	require.Contains(t, deadLetter.String(), "column usage")
+	require.True(t, strings.HasSuffix(deadLetter.String(), "\ncpu,b,bad,4,false,y,2025-01-01T00:00:01Z\n"))
+}
+
+func TestImportCSVUnsupportedDataType(t *testing.T) {
+	c, batches := testImportClient(t, nil)
+	input := `#datatype measurement,duoble,dateTime:number
+m,usage,time
+cpu,0.5,1735689600
+`
+	_, err := c.Import(context.Background(), strings.NewReader(input), ImportConfig{
+		Database:  "db0",
+		Format:    ImportFormatCSV,
+		Precision: PrecisionSecond,
+	})
+	require.ErrorContains(t, err, "column usage: unsupported csv data type duoble")
+	require.Empty(t, *batches)
+}
+
+func TestImportInvalidConfig(t *testing.T) {
+	c, _ := testImportClient(t, nil)
+	_, err := c.Import(context.Background(), strings.NewReader(""), ImportConfig{})
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+	_, err = c.Import(context.Background(), strings.NewReader(""), ImportConfig{Database: "db0", Format: "xml"})
+	require.NotNil(t, err)
+	_, err = c.Import(context.Background(), strings.NewReader(""), ImportConfig{Database: "db0", UseGrpc: true})
+	require.NotNil(t, err)
+}
+
+func trimBatches(batches []string) []string {
+	trimmed := make([]string, len(batches))
+	for i, batch := range batches {
+		trimmed[i] = strings.TrimSpace(batch)
+	}
+	return trimmed
+}
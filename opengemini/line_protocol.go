@@ -0,0 +1,192 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseLineProtocolStrict parse one line of line protocol as the server does, the type of field value follows its
+// syntax only: `1i` is integer, `1u` is unsigned, `1` and `1.5` are float, `"1"` is string and `t` is boolean, the
+// values of other syntax such as bare identifiers are rejected rather than guessed
+func parseLineProtocolStrict(line string) (*Point, error) {
+	s := &lineScanner{line: line}
+	measurement, stop := s.scanUntil(", ", ", ")
+	if measurement == "" {
+		return nil, errors.New("missing measurement")
+	}
+	point := &Point{Measurement: measurement}
+
+	for stop == ',' {
+		var key, value string
+		key, stop = s.scanUntil("=, ", ",= ")
+		if stop != '=' {
+			return nil, fmt.Errorf("tag %q: missing value", key)
+		}
+		value, stop = s.scanUntil(", ", ",= ")
+		if key == "" || value == "" {
+			return nil, fmt.Errorf("tag %q: empty key or value", key)
+		}
+		point.AddTag(key, value)
+	}
+
+	s.skipSpaces()
+	for {
+		key, stop := s.scanUntil("=, ", ",= ")
+		if stop != '=' || key == "" {
+			return nil, fmt.Errorf("field %q: missing value", key)
+		}
+		value, err := s.scanFieldValue()
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", key, err)
+		}
+		point.AddField(key, value)
+		if !s.consume(',') {
+			break
+		}
+	}
+
+	if s.skipSpaces() && !s.done() {
+		timestamp, _ := s.scanUntil(" ", "")
+		t, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q", timestamp)
+		}
+		point.Timestamp = t
+		s.skipSpaces()
+	}
+	if !s.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d", s.line[s.pos:], s.pos)
+	}
+	return point, nil
+}
+
+type lineScanner struct {
+	line string
+	pos  int
+}
+
+func (s *lineScanner) done() bool {
+	return s.pos >= len(s.line)
+}
+
+func (s *lineScanner) consume(c byte) bool {
+	if !s.done() && s.line[s.pos] == c {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+// skipSpaces report whether any space is skipped
+func (s *lineScanner) skipSpaces() bool {
+	start := s.pos
+	for !s.done() && s.line[s.pos] == ' ' {
+		s.pos++
+	}
+	return s.pos > start
+}
+
+// scanUntil read the text until an unescaped byte of stops and consume it, the backslash before a byte of escapes is
+// removed, the other backslashes are kept. The stop byte is 0 at the end of line
+func (s *lineScanner) scanUntil(stops, escapes string) (string, byte) {
+	var text strings.Builder
+	for !s.done() {
+		c := s.line[s.pos]
+		if c == '\\' && s.pos+1 < len(s.line) && strings.IndexByte(escapes, s.line[s.pos+1]) >= 0 {
+			text.WriteByte(s.line[s.pos+1])
+			s.pos += 2
+			continue
+		}
+		s.pos++
+		if strings.IndexByte(stops, c) >= 0 {
+			return text.String(), c
+		}
+		text.WriteByte(c)
+	}
+	return text.String(), 0
+}
+
+func (s *lineScanner) scanFieldValue() (any, error) {
+	if s.consume('"') {
+		return s.scanString()
+	}
+	start := s.pos
+	for !s.done() && s.line[s.pos] != ',' && s.line[s.pos] != ' ' {
+		s.pos++
+	}
+	return parseStrictFieldValue(s.line[start:s.pos])
+}
+
+// scanString read the string field after the opening quote, `\"` and `\\` are unescaped
+func (s *lineScanner) scanString() (string, error) {
+	var text strings.Builder
+	for !s.done() {
+		c := s.line[s.pos]
+		s.pos++
+		switch {
+		case c == '"':
+			return text.String(), nil
+		case c == '\\' && !s.done() && (s.line[s.pos] == '"' || s.line[s.pos] == '\\'):
+			text.WriteByte(s.line[s.pos])
+			s.pos++
+		default:
+			text.WriteByte(c)
+		}
+	}
+	return "", errors.New("unterminated string")
+}
+
+func parseStrictFieldValue(value string) (any, error) {
+	switch value {
+	case "":
+		return nil, errors.New("missing value")
+	case "t", "T", "true", "True", "TRUE":
+		return true, nil
+	case "f", "F", "false", "False", "FALSE":
+		return false, nil
+	}
+	if value[0] != '-' && (value[0] < '0' || value[0] > '9') && value[0] != '.' {
+		return nil, fmt.Errorf("invalid value %q, the strings must be quoted", value)
+	}
+	switch value[len(value)-1] {
+	case 'i':
+		n, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		return n, nil
+	case 'u':
+		n, err := strconv.ParseUint(value[:len(value)-1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		return n, nil
+	}
+	// ParseFloat accepts hex, Inf and NaN which aren't valid in line protocol
+	if strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == '-' || r == '+' || r == 'e' || r == 'E')
+	}) >= 0 {
+		return nil, fmt.Errorf("invalid value %q, the strings must be quoted", value)
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid float %q", value)
+	}
+	return f, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLineProtocolStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected *Point
+	}{
+		{
+			name: "typed fields",
+			line: `cpu,host=a f=1.5,g=2,i=-3i,u=4u,b=t,B=FALSE,s="x" 10`,
+			expected: &Point{
+				Measurement: "cpu",
+				Tags:        map[string]string{"host": "a"},
+				Fields: map[string]any{
+					"f": 1.5, "g": 2.0, "i": int64(-3), "u": uint64(4), "b": true, "B": false, "s": "x",
+				},
+				Timestamp: 10,
+			},
+		},
+		{
+			name: "quoted number and boolean stay strings",
+			line: `cpu s="42",t="true"`,
+			expected: &Point{
+				Measurement: "cpu",
+				Fields:      map[string]any{"s": "42", "t": "true"},
+			},
+		},
+		{
+			name: "negative timestamp",
+			line: "cpu v=1i -1000",
+			expected: &Point{
+				Measurement: "cpu",
+				Fields:      map[string]any{"v": int64(1)},
+				Timestamp:   -1000,
+			},
+		},
+		{
+			name: "escaped names and string",
+			line: `c\ p\,u,h\=o\ st=a\,b w\ x="say \"hi\" \\ ok, bye" 1`,
+			expected: &Point{
+				Measurement: "c p,u",
+				Tags:        map[string]string{"h=o st": "a,b"},
+				Fields:      map[string]any{"w x": `say "hi" \ ok, bye`},
+				Timestamp:   1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			point, err := parseLineProtocolStrict(tt.line)
+			require.Nil(t, err)
+			require.Equal(t, tt.expected, point)
+		})
+	}
+}
+
+func TestParseLineProtocolStrictRejects(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"cpu",
+		"cpu v=abc",
+		"cpu v=",
+		"cpu v=1x",
+		"cpu v=0x10",
+		"cpu v=NaN",
+		"cpu v=1.5i",
+		"cpu v=-1u",
+		`cpu s="open`,
+		"cpu,host v=1",
+		"cpu,host= v=1",
+		"cpu v=1 abc",
+		"cpu v=1 1 1",
+		",host=a v=1",
+	} {
+		_, err := parseLineProtocolStrict(line)
+		require.NotNil(t, err, line)
+	}
+}
+
+func TestParseLineProtocolStrictEncoded(t *testing.T) {
+	point := &Point{
+		Measurement: "m e,a",
+		Tags:        map[string]string{"k,=y": "v al=ue"},
+		Fields: map[string]any{
+			"f i=eld": `quote " and \ back`, "i": int64(-7), "u": uint64(7), "f": 0.25, "b": true,
+		},
+		Timestamp: 123,
+	}
+	var buf strings.Builder
+	require.Nil(t, NewLineProtocolEncoder(&buf).Encode(point))
+
+	parsed, err := parseLineProtocolStrict(buf.String())
+	require.Nil(t, err)
+	require.Equal(t, point, parsed)
+}
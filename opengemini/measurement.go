@@ -71,7 +71,7 @@ func (c *client) DropMeasurement(database, retentionPolicy, measurement string)
 	}
 	req.queryValues.Add("db", database)
 	req.queryValues.Add("rp", retentionPolicy)
-	req.queryValues.Add("q", `DROP MEASUREMENT "`+measurement+`"`)
+	req.queryValues.Add("q", "DROP MEASUREMENT "+QuoteIdentifier(measurement))
 	resp, err := c.executeHttpPost("/query", req)
 	if err != nil {
 		return err
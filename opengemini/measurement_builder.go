@@ -85,14 +85,14 @@ type measurementBuilder struct {
 
 func (m *measurementBuilder) Tags(tagList []string) CreateMeasurementBuilder {
 	for _, tag := range tagList {
-		m.tags = append(m.tags, tag+" TAG")
+		m.tags = append(m.tags, QuoteIdentifier(tag)+" TAG")
 	}
 	return m
 }
 
 func (m *measurementBuilder) FieldMap(fields map[string]fieldType) CreateMeasurementBuilder {
 	for key, value := range fields {
-		m.fields = append(m.fields, key+" "+string(value)+" FIELD")
+		m.fields = append(m.fields, QuoteIdentifier(key)+" "+string(value)+" FIELD")
 	}
 	return m
 }
@@ -148,7 +148,7 @@ func (m *measurementBuilder) build() (string, error) {
 			return "", ErrEmptyTagOrField
 		}
 		var buffer strings.Builder
-		buffer.WriteString(`CREATE MEASUREMENT ` + m.measurement + " (")
+		buffer.WriteString("CREATE MEASUREMENT " + QuoteIdentifier(m.measurement) + " (")
 		if len(m.tags) != 0 {
 			buffer.WriteString(strings.Join(m.tags, ","))
 		}
@@ -167,7 +167,7 @@ func (m *measurementBuilder) build() (string, error) {
 			withIdentifier = true
 			buffer.WriteString(" WITH ")
 			buffer.WriteString(" INDEXTYPE " + m.indexType)
-			buffer.WriteString(" INDEXLIST " + strings.Join(m.indexList, ","))
+			buffer.WriteString(" INDEXLIST " + quoteIdentifiers(m.indexList, ","))
 		}
 		if m.engineType != "" {
 			if !withIdentifier {
@@ -181,7 +181,7 @@ func (m *measurementBuilder) build() (string, error) {
 				withIdentifier = true
 				buffer.WriteString(" WITH ")
 			}
-			buffer.WriteString(" SHARDKEY " + strings.Join(m.shardKeys, ","))
+			buffer.WriteString(" SHARDKEY " + quoteIdentifiers(m.shardKeys, ","))
 		}
 		if m.shardType != "" {
 			if !withIdentifier {
@@ -195,13 +195,13 @@ func (m *measurementBuilder) build() (string, error) {
 				withIdentifier = true
 				buffer.WriteString(" WITH ")
 			}
-			buffer.WriteString(" PRIMARYKEY " + strings.Join(m.primaryKey, ","))
+			buffer.WriteString(" PRIMARYKEY " + quoteIdentifiers(m.primaryKey, ","))
 		}
 		if len(m.sortKeys) != 0 {
 			if !withIdentifier {
 				buffer.WriteString(" WITH ")
 			}
-			buffer.WriteString(" SORTKEY " + strings.Join(m.sortKeys, ","))
+			buffer.WriteString(" SORTKEY " + quoteIdentifiers(m.sortKeys, ","))
 		}
 		return buffer.String(), nil
 	case MeasureShow:
@@ -209,7 +209,13 @@ func (m *measurementBuilder) build() (string, error) {
 		buf.WriteString(`SHOW MEASUREMENTS`)
 		if m.filter != nil {
 			// m.filter.Value can only be of string type due to Filter API
-			buf.WriteString(" WITH MEASUREMENT " + string(m.filter.Operator) + " " + m.filter.Value.(string))
+			value := m.filter.Value.(string)
+			if m.filter.Operator == Match || m.filter.Operator == NotMatch {
+				value = quoteRegexValue(value)
+			} else {
+				value = QuoteIdentifier(value)
+			}
+			buf.WriteString(" WITH MEASUREMENT " + string(m.filter.Operator) + " " + value)
 		}
 		return buf.String(), nil
 	default:
@@ -323,6 +329,7 @@ type ShowTagKeysBuilder interface {
 	Offset(offset int) ShowTagKeysBuilder
 	build() (string, error)
 	getMeasurementBase() measurementBase
+	Pageable
 }
 
 type showTagKeysBuilder struct {
@@ -365,7 +372,7 @@ func (s *showTagKeysBuilder) build() (string, error) {
 	buf.WriteString("SHOW TAG KEYS")
 
 	if s.measurement != "" {
-		buf.WriteString(fmt.Sprintf(" FROM %s", s.measurement))
+		buf.WriteString(" FROM " + QuoteIdentifier(s.measurement))
 	}
 	if s.limit > 0 {
 		buf.WriteString(" LIMIT " + strconv.Itoa(s.limit))
@@ -380,6 +387,20 @@ func (s *showTagKeysBuilder) getMeasurementBase() measurementBase {
 	return s.measurementBase
 }
 
+func (s *showTagKeysBuilder) pageWindow() (int, int) {
+	return s.limit, s.offset
+}
+
+func (s *showTagKeysBuilder) buildPage(limit, offset int) (Query, error) {
+	page := *s
+	page.limit, page.offset = limit, offset
+	command, err := page.build()
+	if err != nil {
+		return Query{}, err
+	}
+	return Query{Database: s.database, RetentionPolicy: s.retentionPolicy, Command: command}, nil
+}
+
 func NewShowTagKeysBuilder() ShowTagKeysBuilder {
 	return &showTagKeysBuilder{}
 }
@@ -405,6 +426,7 @@ type ShowTagValuesBuilder interface {
 	Where(key string, operator ComparisonOperator, value string) ShowTagValuesBuilder
 	build() (string, error)
 	getMeasurementBase() measurementBase
+	Pageable
 }
 
 type showTagValuesBuilder struct {
@@ -418,7 +440,7 @@ type showTagValuesBuilder struct {
 }
 
 func (s *showTagValuesBuilder) OrderBy(field string, order SortOrder) ShowTagValuesBuilder {
-	s.orders = append(s.orders, fmt.Sprintf("%s %s", field, order))
+	s.orders = append(s.orders, QuoteIdentifier(field)+" "+string(order))
 	return s
 }
 
@@ -468,24 +490,21 @@ func (s *showTagValuesBuilder) build() (string, error) {
 	var buff strings.Builder
 	buff.WriteString("SHOW TAG VALUES")
 	if s.measurement != "" {
-		buff.WriteString(" FROM " + s.measurement)
+		buff.WriteString(" FROM " + QuoteIdentifier(s.measurement))
 	}
 	// must be set
 	if len(s.withKey) == 1 {
 		key := s.withKey[0]
 		if strings.HasPrefix(key, "/") && strings.HasSuffix(key, "/") {
-			buff.WriteString(" WITH KEY =~ " + key)
+			buff.WriteString(" WITH KEY =~ " + quoteRegexValue(key))
 		} else {
-			buff.WriteString(" WITH KEY = \"" + key + "\"")
+			buff.WriteString(" WITH KEY = " + QuoteIdentifier(key))
 		}
 	}
 
 	if len(s.withKey) > 1 {
 		// append double quote, void keyword
-		for i := range s.withKey {
-			s.withKey[i] = "\"" + s.withKey[i] + "\""
-		}
-		buff.WriteString(" WITH KEY IN (" + strings.Join(s.withKey, ",") + ")")
+		buff.WriteString(" WITH KEY IN (" + quoteIdentifiers(s.withKey, ",") + ")")
 	}
 
 	if s.where != nil {
@@ -511,6 +530,20 @@ func (s *showTagValuesBuilder) getMeasurementBase() measurementBase {
 	return s.measurementBase
 }
 
+func (s *showTagValuesBuilder) pageWindow() (int, int) {
+	return s.limit, s.offset
+}
+
+func (s *showTagValuesBuilder) buildPage(limit, offset int) (Query, error) {
+	page := *s
+	page.limit, page.offset = limit, offset
+	command, err := page.build()
+	if err != nil {
+		return Query{}, err
+	}
+	return Query{Database: s.database, RetentionPolicy: s.retentionPolicy, Command: command}, nil
+}
+
 type ShowSeriesBuilder interface {
 	// Database specify measurement in database
 	Database(database string) ShowSeriesBuilder
@@ -526,6 +559,7 @@ type ShowSeriesBuilder interface {
 	Where(key string, operator ComparisonOperator, value string) ShowSeriesBuilder
 	build() (string, error)
 	getMeasurementBase() measurementBase
+	Pageable
 }
 
 type showSeriesBuilder struct {
@@ -573,7 +607,7 @@ func (s *showSeriesBuilder) build() (string, error) {
 	var buff strings.Builder
 	buff.WriteString("SHOW SERIES")
 	if s.measurement != "" {
-		buff.WriteString(" FROM " + s.measurement)
+		buff.WriteString(" FROM " + QuoteIdentifier(s.measurement))
 	}
 	if s.where != nil {
 		buff.WriteString(" WHERE " + s.where.build())
@@ -592,6 +626,20 @@ func (s *showSeriesBuilder) getMeasurementBase() measurementBase {
 	return s.measurementBase
 }
 
+func (s *showSeriesBuilder) pageWindow() (int, int) {
+	return s.limit, s.offset
+}
+
+func (s *showSeriesBuilder) buildPage(limit, offset int) (Query, error) {
+	page := *s
+	page.limit, page.offset = limit, offset
+	command, err := page.build()
+	if err != nil {
+		return Query{}, err
+	}
+	return Query{Database: s.database, RetentionPolicy: s.retentionPolicy, Command: command}, nil
+}
+
 func NewShowSeriesBuilder() ShowSeriesBuilder {
 	return &showSeriesBuilder{}
 }
@@ -223,3 +223,21 @@ func TestClient_CreateMeasurementWithFullTextIndex(t *testing.T) {
 	err = c.DropDatabase(databaseName)
 	require.Nil(t, err)
 }
+
+func TestMeasurementBuilderEscape(t *testing.T) {
+	command, err := NewMeasurementBuilder().Database("db0").Measurement(`m"0`).Create().
+		Tags([]string{`tag"0`}).FieldMap(map[string]fieldType{"field0": FieldTypeInt64}).
+		EngineType(EngineTypeColumnStore).ShardKeys([]string{`tag"0`}).
+		PrimaryKey([]string{`tag"0`}).SortKeys([]string{"time"}).build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE MEASUREMENT "m\"0" ("tag\"0" TAG,"field0" INT64 FIELD) WITH  ENGINETYPE = columnstore SHARDKEY "tag\"0" PRIMARYKEY "tag\"0" SORTKEY "time"`, command)
+
+	command, err = NewMeasurementBuilder().Database("db0").Show().Filter(Match, "/cpu/.*/").build()
+	require.Nil(t, err)
+	require.Equal(t, `SHOW MEASUREMENTS WITH MEASUREMENT =~ /cpu\/.*/`, command)
+
+	command, err = NewShowTagValuesBuilder().Database("db0").Measurement(`m"0`).With("k0", `k"1`).
+		Where("k0", Equals, `v'0`).build()
+	require.Nil(t, err)
+	require.Equal(t, `SHOW TAG VALUES FROM "m\"0" WITH KEY IN ("k0","k\"1") WHERE "k0" = 'v\'0'`, command)
+}
@@ -14,13 +14,61 @@
 
 package opengemini
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
 
 const (
 	MetricsNamespace = "opengemini"
 	MetricsSubsystem = "client"
 )
 
+// HistogramType determines how the latency histograms are exposed
+type HistogramType int
+
+const (
+	// HistogramTypeClassic expose the histograms with fixed buckets, this is the default
+	HistogramTypeClassic HistogramType = iota
+	// HistogramTypeNative expose sparse native histograms only, the prometheus server must enable native histograms
+	HistogramTypeNative
+	// HistogramTypeBoth expose both, so that dashboards built on classic buckets keep working while migrating
+	HistogramTypeBoth
+)
+
+const defaultNativeHistogramBucketFactor = 1.1
+
+// error types of the errors_total counter
+const (
+	errorTypeTimeout     = "timeout"
+	errorTypeCanceled    = "canceled"
+	errorTypeNetwork     = "network"
+	errorTypeStatus      = "status"
+	errorTypeInterceptor = "interceptor"
+	errorTypeGrpc        = "grpc"
+	errorTypeResponse    = "response"
+)
+
+const operationGrpcWrite = "grpc_write"
+
+// MetricsConfig represents the configuration information for the prometheus metrics
+type MetricsConfig struct {
+	// HistogramType determines whether the latency histograms are classic, native or both, default classic
+	HistogramType HistogramType
+	// Buckets upper bounds in seconds of the classic histograms, default prometheus.DefBuckets
+	Buckets []float64
+	// NativeHistogramBucketFactor growth factor between the native histogram buckets, must be greater than 1,
+	// default 1.1
+	NativeHistogramBucketFactor float64
+}
+
 var _ prometheus.Collector = (*metrics)(nil)
 
 // metrics custom indicators, implementing the prometheus.Collector interface
@@ -41,6 +89,22 @@ type metrics struct {
 	queryDatabaseLatency *prometheus.SummaryVec
 	// writeDatabaseLatency calculate the average of the writes for database, unit milliseconds
 	writeDatabaseLatency *prometheus.SummaryVec
+	// requestDuration histogram of the request latency classified by operation, database and endpoint, unit seconds
+	requestDuration *prometheus.HistogramVec
+	// errorCounter count failed requests classified by operation, error type, status code and endpoint
+	errorCounter *prometheus.CounterVec
+	// sentBytes count bytes of the request bodies sent to each endpoint
+	sentBytes *prometheus.CounterVec
+	// receivedBytes count bytes of the response bodies received from each endpoint
+	receivedBytes *prometheus.CounterVec
+	// batchQueueDepth number of points waiting in the batch queue of each database and retention policy
+	batchQueueDepth *prometheus.GaugeVec
+	// grpcWriteCounter count write requests by gRPC and classify using database
+	grpcWriteCounter *prometheus.CounterVec
+	// grpcWriteRecords count records written by gRPC and classify using database
+	grpcWriteRecords *prometheus.CounterVec
+	// grpcWriteRetries count the retried and hedged attempts of the writes by gRPC and classify using database
+	grpcWriteRetries *prometheus.CounterVec
 }
 
 func (m *metrics) Describe(chan<- *prometheus.Desc) {}
@@ -54,10 +118,18 @@ func (m *metrics) Collect(ch chan<- prometheus.Metric) {
 	m.writeDatabaseCounter.Collect(ch)
 	m.queryDatabaseLatency.Collect(ch)
 	m.writeDatabaseLatency.Collect(ch)
+	m.requestDuration.Collect(ch)
+	m.errorCounter.Collect(ch)
+	m.sentBytes.Collect(ch)
+	m.receivedBytes.Collect(ch)
+	m.batchQueueDepth.Collect(ch)
+	m.grpcWriteCounter.Collect(ch)
+	m.grpcWriteRecords.Collect(ch)
+	m.grpcWriteRetries.Collect(ch)
 }
 
 // newMetricsProvider returns metrics registered to registerer.
-func newMetricsProvider(customLabels map[string]string) *metrics {
+func newMetricsProvider(customLabels map[string]string, config *MetricsConfig) *metrics {
 	constLabels := map[string]string{
 		"client": "go", // distinguish from other language client
 	}
@@ -67,6 +139,9 @@ func newMetricsProvider(customLabels map[string]string) *metrics {
 
 	constQuantiles := map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
 	labelNames := []string{"database"}
+	if config == nil {
+		config = &MetricsConfig{}
+	}
 
 	m := &metrics{
 		queryCounter: prometheus.NewCounter(prometheus.CounterOpts{
@@ -129,11 +204,153 @@ func newMetricsProvider(customLabels map[string]string) *metrics {
 			ConstLabels: constLabels,
 			Objectives:  constQuantiles,
 		}, labelNames),
+		requestDuration: prometheus.NewHistogramVec(histogramOpts(config, prometheus.HistogramOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of the requests until the response headers are received",
+			ConstLabels: constLabels,
+		}), []string{"operation", "database", "endpoint"}),
+		errorCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "errors_total",
+			Help:        "Count of failed requests and classify using operation, error type and status code",
+			ConstLabels: constLabels,
+		}, []string{"operation", "type", "code", "endpoint"}),
+		sentBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "sent_bytes_total",
+			Help:        "Count of bytes sent in the request bodies",
+			ConstLabels: constLabels,
+		}, []string{"operation", "endpoint"}),
+		receivedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "received_bytes_total",
+			Help:        "Count of bytes received in the response bodies",
+			ConstLabels: constLabels,
+		}, []string{"operation", "endpoint"}),
+		batchQueueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "batch_queue_depth",
+			Help:        "Number of points waiting in the batch queue",
+			ConstLabels: constLabels,
+		}, []string{"database", "retention_policy"}),
+		grpcWriteCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "grpc_write_total",
+			Help:        "Count of opengemini writes by gRPC and classify using database",
+			ConstLabels: constLabels,
+		}, labelNames),
+		grpcWriteRecords: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "grpc_write_records_total",
+			Help:        "Count of records written by gRPC and classify using database",
+			ConstLabels: constLabels,
+		}, labelNames),
+		grpcWriteRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystem,
+			Name:        "grpc_write_retries_total",
+			Help:        "Count of retried and hedged attempts of the writes by gRPC and classify using database",
+			ConstLabels: constLabels,
+		}, labelNames),
 	}
 
 	return m
 }
 
+// histogramOpts fill the buckets of opts according to the HistogramType of config
+func histogramOpts(config *MetricsConfig, opts prometheus.HistogramOpts) prometheus.HistogramOpts {
+	factor := config.NativeHistogramBucketFactor
+	if factor <= 1 {
+		factor = defaultNativeHistogramBucketFactor
+	}
+	buckets := config.Buckets
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+	switch config.HistogramType {
+	case HistogramTypeNative:
+		opts.NativeHistogramBucketFactor = factor
+	case HistogramTypeBoth:
+		opts.Buckets = buckets
+		opts.NativeHistogramBucketFactor = factor
+	default:
+		opts.Buckets = buckets
+	}
+	return opts
+}
+
+// observeRequest record the latency of a request to endpoint
+func (m *metrics) observeRequest(operation, database, endpoint string, elapsed time.Duration) {
+	m.requestDuration.WithLabelValues(operation, database, endpoint).Observe(elapsed.Seconds())
+}
+
+// countError count a failed request, code is the status code of the response if there is one
+func (m *metrics) countError(operation, endpoint, errorType, code string) {
+	m.errorCounter.WithLabelValues(operation, errorType, code, endpoint).Inc()
+}
+
+// countSent add the size of a request body
+func (m *metrics) countSent(operation, endpoint string, size int64) {
+	if size > 0 {
+		m.sentBytes.WithLabelValues(operation, endpoint).Add(float64(size))
+	}
+}
+
+// countReceived wrap body so that the bytes are counted while the caller reads the response
+func (m *metrics) countReceived(operation, endpoint string, body io.ReadCloser) io.ReadCloser {
+	return &countingReadCloser{ReadCloser: body, counter: m.receivedBytes.WithLabelValues(operation, endpoint)}
+}
+
+// grpcErrorType classify the errors returned by a gRPC call, the status code is reported separately
+func grpcErrorType(err error) string {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		return errorTypeTimeout
+	case codes.Canceled:
+		return errorTypeCanceled
+	case codes.Unavailable:
+		return errorTypeNetwork
+	default:
+		return errorTypeGrpc
+	}
+}
+
+// transportErrorType classify the errors returned before any response is received
+func transportErrorType(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorTypeTimeout
+	case errors.Is(err, context.Canceled):
+		return errorTypeCanceled
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return errorTypeTimeout
+	default:
+		return errorTypeNetwork
+	}
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	counter prometheus.Counter
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	if n > 0 {
+		c.counter.Add(float64(n))
+	}
+	return n, err
+}
+
 // ExposeMetrics expose prometheus metrics
 func (c *client) ExposeMetrics() prometheus.Collector {
 	return c.metrics
@@ -0,0 +1,177 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+// testGatherMetric return the metric of family name whose labels contain all of labels
+func testGatherMetric(t *testing.T, collector prometheus.Collector, name string, labels map[string]string) *dto.Metric {
+	registry := prometheus.NewRegistry()
+	require.Nil(t, registry.Register(collector))
+	families, err := registry.Gather()
+	require.Nil(t, err)
+	for _, family := range families {
+		if family.GetName() != MetricsNamespace+"_"+MetricsSubsystem+"_"+name {
+			continue
+		}
+	next:
+		for _, metric := range family.GetMetric() {
+			pairs := make(map[string]string)
+			for _, pair := range metric.GetLabel() {
+				pairs[pair.GetName()] = pair.GetValue()
+			}
+			for k, v := range labels {
+				if pairs[k] != v {
+					continue next
+				}
+			}
+			return metric
+		}
+	}
+	t.Fatalf("metric %s %v not found", name, labels)
+	return nil
+}
+
+func TestMetricsHistogramType(t *testing.T) {
+	for _, histogramType := range []HistogramType{HistogramTypeClassic, HistogramTypeNative, HistogramTypeBoth} {
+		m := newMetricsProvider(nil, &MetricsConfig{HistogramType: histogramType, Buckets: []float64{0.1, 1}})
+		m.observeRequest("query", "db0", "127.0.0.1:8086", 50*time.Millisecond)
+
+		histogram := testGatherMetric(t, m, "request_duration_seconds", map[string]string{"operation": "query"}).GetHistogram()
+		require.Equal(t, uint64(1), histogram.GetSampleCount())
+		require.Equal(t, histogramType != HistogramTypeNative, len(histogram.GetBucket()) == 2, histogramType)
+		require.Equal(t, histogramType != HistogramTypeClassic, histogram.Schema != nil, histogramType)
+	}
+}
+
+func TestClientMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case UrlWrite:
+			writer.WriteHeader(http.StatusNoContent)
+		default:
+			writer.WriteHeader(http.StatusInternalServerError)
+			_, _ = writer.Write([]byte("internal error"))
+		}
+	}))
+	defer server.Close()
+
+	address := testServerAddress(t, server.URL)
+	c := testNewClient(t, &Config{
+		Addresses:   []Address{address},
+		BatchConfig: &BatchConfig{BatchInterval: 10 * time.Millisecond, BatchSize: 10},
+	})
+	defer c.Close()
+
+	point := &Point{Measurement: "cpu", Fields: map[string]any{"v": 1}}
+	require.Nil(t, c.WriteBatchPoints(context.Background(), "db0", []*Point{point}))
+	_, err := c.Query(Query{Database: "db0", Command: "SELECT * FROM cpu"})
+	require.NotNil(t, err)
+
+	written := make(chan error, 1)
+	require.Nil(t, c.WritePoint("db1", point, func(err error) { written <- err }))
+	require.Nil(t, <-written)
+
+	collector := c.ExposeMetrics()
+	endpoint := address.String()
+	writeDuration := testGatherMetric(t, collector, "request_duration_seconds",
+		map[string]string{"operation": "write", "database": "db0", "endpoint": endpoint})
+	require.Equal(t, uint64(1), writeDuration.GetHistogram().GetSampleCount())
+	sent := testGatherMetric(t, collector, "sent_bytes_total", map[string]string{"operation": "write", "endpoint": endpoint})
+	require.Greater(t, sent.GetCounter().GetValue(), float64(0))
+	received := testGatherMetric(t, collector, "received_bytes_total", map[string]string{"operation": "query", "endpoint": endpoint})
+	require.Equal(t, float64(len("internal error")), received.GetCounter().GetValue())
+	errorsTotal := testGatherMetric(t, collector, "errors_total",
+		map[string]string{"operation": "query", "type": "status", "code": "500", "endpoint": endpoint})
+	require.Equal(t, float64(1), errorsTotal.GetCounter().GetValue())
+	queueDepth := testGatherMetric(t, collector, "batch_queue_depth", map[string]string{"database": "db1"})
+	require.Equal(t, float64(0), queueDepth.GetGauge().GetValue())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	closedPort := listener.Addr().(*net.TCPAddr).Port
+	require.Nil(t, listener.Close())
+	unreachable := testNewClient(t, &Config{Addresses: []Address{{Host: "127.0.0.1", Port: closedPort}}})
+	require.NotNil(t, unreachable.Ping(0))
+	testGatherMetric(t, unreachable.ExposeMetrics(), "errors_total",
+		map[string]string{"operation": "ping", "type": "network", "code": ""})
+}
+
+type testMetricsWriteServer struct {
+	proto.UnimplementedWriteServiceServer
+}
+
+func (s *testMetricsWriteServer) Write(_ context.Context, req *proto.WriteRequest) (*proto.WriteResponse, error) {
+	if req.Database == "readonly" {
+		return &proto.WriteResponse{Code: proto.ResponseCode_Failed}, nil
+	}
+	return &proto.WriteResponse{Code: proto.ResponseCode_Success}, nil
+}
+
+func TestClientGrpcWriteMetrics(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	server := grpc.NewServer()
+	proto.RegisterWriteServiceServer(server, &testMetricsWriteServer{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	address := listener.Addr().(*net.TCPAddr)
+	c := testNewClient(t, &Config{
+		Addresses:  []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{Addresses: []Address{{Host: "127.0.0.1", Port: address.Port}}},
+	})
+	defer c.Close()
+
+	recordBuilder, err := NewRecordBuilder("cpu")
+	require.Nil(t, err)
+	record := recordBuilder.AddTag("host", "a").AddField("v", 1.5).Build(time.Now().UnixNano())
+	builder, err := NewWriteRequestBuilder("db0", "")
+	require.Nil(t, err)
+	request, err := builder.AddRecord(record).Build()
+	require.Nil(t, err)
+	require.Nil(t, c.WriteByGrpc(context.Background(), request))
+	request.Database = "readonly"
+	require.NotNil(t, c.WriteByGrpc(context.Background(), request))
+
+	collector := c.ExposeMetrics()
+	endpoint := address.String()
+	writes := testGatherMetric(t, collector, "grpc_write_total", map[string]string{"database": "db0"})
+	require.Equal(t, float64(1), writes.GetCounter().GetValue())
+	records := testGatherMetric(t, collector, "grpc_write_records_total", map[string]string{"database": "db0"})
+	require.Equal(t, float64(1), records.GetCounter().GetValue())
+	duration := testGatherMetric(t, collector, "request_duration_seconds",
+		map[string]string{"operation": "grpc_write", "database": "db0", "endpoint": endpoint})
+	require.Equal(t, uint64(1), duration.GetHistogram().GetSampleCount())
+	sent := testGatherMetric(t, collector, "sent_bytes_total", map[string]string{"operation": "grpc_write", "endpoint": endpoint})
+	require.Greater(t, sent.GetCounter().GetValue(), float64(0))
+	errorsTotal := testGatherMetric(t, collector, "errors_total",
+		map[string]string{"operation": "grpc_write", "type": "response", "code": "Failed", "endpoint": endpoint})
+	require.Equal(t, float64(1), errorsTotal.GetCounter().GetValue())
+}
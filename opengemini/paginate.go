@@ -0,0 +1,299 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// Pageable is implemented by the builders which Paginate supports, they are ShowTagKeysBuilder,
+// ShowTagValuesBuilder, ShowSeriesBuilder and the query returned by NewPageableQuery
+type Pageable interface {
+	// pageWindow return the limit and offset set on builder, limit 0 means no limit
+	pageWindow() (int, int)
+	// buildPage build the query of one page, limit and offset override the ones set on builder
+	buildPage(limit, offset int) (Query, error)
+}
+
+// Row is a row of result yielded by Paginate, the rows of the same series share Tags and Columns, don't modify them
+type Row struct {
+	// Name the name of series which row belongs to, such as measurement name
+	Name    string
+	Tags    map[string]string
+	Columns []string
+	Values  SeriesValue
+}
+
+// pageableQuery bind QueryBuilder to database, because the `SELECT` built by QueryBuilder doesn't carry database
+type pageableQuery struct {
+	database        string
+	retentionPolicy string
+	builder         *QueryBuilder
+}
+
+// NewPageableQuery make the `SELECT` built by QueryBuilder pageable on database, the raw queries of one measurement
+// without GROUP BY and functions are paginated by time cursor `WHERE time > lastSeen`, which stays correct when data is
+// written between pages, the other queries are paginated by LIMIT and OFFSET. The limit and offset set on builder
+// restrict the whole pagination rather than one page
+func NewPageableQuery(database, retentionPolicy string, builder *QueryBuilder) Pageable {
+	return &pageableQuery{database: database, retentionPolicy: retentionPolicy, builder: builder}
+}
+
+func (p *pageableQuery) pageWindow() (int, int) {
+	return int(p.builder.limit), int(p.builder.offset)
+}
+
+func (p *pageableQuery) buildPage(limit, offset int) (Query, error) {
+	page := *p.builder
+	page.limit, page.offset = int64(limit), int64(offset)
+	return p.query(&page), nil
+}
+
+// buildCursorPage build the query of page which starts from cursor inclusively, zero cursor means the first page
+func (p *pageableQuery) buildCursorPage(limit, offset int, cursor time.Time) Query {
+	page := *p.builder
+	page.limit, page.offset = int64(limit), int64(offset)
+	if page.order == "" {
+		page.order = Asc
+	}
+	if !cursor.IsZero() {
+		operator := GreaterThanOrEquals
+		if page.order == Desc {
+			operator = LessThanOrEquals
+		}
+		condition := Condition(NewTimeCondition(operator, cursor))
+		if page.where != nil {
+			condition = NewCompositeCondition(And, page.where, condition)
+		}
+		page.where = condition
+	}
+	return p.query(&page)
+}
+
+func (p *pageableQuery) query(builder *QueryBuilder) Query {
+	query := builder.Build()
+	query.Database = p.database
+	query.RetentionPolicy = p.retentionPolicy
+	return *query
+}
+
+// timeCursor report whether the query can be paginated by time cursor, the rows of raw query without GROUP BY are
+// ordered by time, the aggregations and selectors would be changed by the time condition. The cursor is shared by the
+// query, so the sources which may return several series, such as several measurements, regex or subquery, are
+// paginated by OFFSET which the server applies to each series
+func (p *pageableQuery) timeCursor() bool {
+	q := p.builder
+	if len(q.from) != 1 {
+		return false
+	}
+	if _, ok := q.from[0].(measurementSource); !ok {
+		return false
+	}
+	if q.groupByTime != 0 || len(q.groupBy) != 0 || q.sLimit != 0 || q.sOffset != 0 {
+		return false
+	}
+	for _, expr := range q.selectExprs {
+		if hasFunction(expr) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasFunction(expr Expression) bool {
+	switch e := expr.(type) {
+	case *FunctionExpression:
+		return true
+	case *AsExpression:
+		return hasFunction(e.OriginExpr)
+	case *ArithmeticExpression:
+		for _, operand := range e.Operands {
+			if hasFunction(operand) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Paginate issue successive requests of pageSize rows and yield the rows until exhausted, the error of request is
+// yielded as the last element, breaking the loop stops the requests
+func (c *client) Paginate(ctx context.Context, builder Pageable, pageSize int) iter.Seq2[Row, error] {
+	return func(yield func(Row, error) bool) {
+		if pageSize <= 0 {
+			yield(Row{}, ErrInvalidPageSize)
+			return
+		}
+		if query, ok := builder.(*pageableQuery); ok {
+			if len(query.builder.intoMeasurement) != 0 {
+				yield(Row{}, errors.New("paginate: SELECT INTO is not supported"))
+				return
+			}
+			if query.timeCursor() {
+				c.paginateByTime(ctx, query, pageSize, yield)
+				return
+			}
+		}
+		c.paginateByOffset(ctx, builder, pageSize, yield)
+	}
+}
+
+// paginateByOffset advance OFFSET by page size, LIMIT and OFFSET of `SHOW` and `GROUP BY` queries apply to each
+// series, so the pagination ends when no series fills the page
+func (c *client) paginateByOffset(ctx context.Context, builder Pageable, pageSize int, yield func(Row, error) bool) {
+	limit, offset := builder.pageWindow()
+	end := offset + limit
+	for {
+		size := pageSize
+		if limit > 0 && end-offset < size {
+			size = end - offset
+		}
+		if size <= 0 {
+			return
+		}
+		query, err := builder.buildPage(size, offset)
+		if err != nil {
+			yield(Row{}, err)
+			return
+		}
+		result, err := c.queryPage(ctx, query)
+		if err != nil {
+			yield(Row{}, err)
+			return
+		}
+
+		var filled bool
+		for _, series := range resultSeries(result) {
+			filled = filled || len(series.Values) >= size
+			for _, values := range series.Values {
+				if !yield(Row{Name: series.Name, Tags: series.Tags, Columns: series.Columns, Values: values}, nil) {
+					return
+				}
+			}
+		}
+		if !filled {
+			return
+		}
+		offset += size
+	}
+}
+
+// paginateByTime query the rows from the time of the last row, the rows at the same time may be split by the page,
+// so the page starts from the cursor inclusively and skips the rows which have been yielded at the cursor
+func (c *client) paginateByTime(ctx context.Context, query *pageableQuery, pageSize int, yield func(Row, error) bool) {
+	limit, offset := query.pageWindow()
+	var cursor time.Time
+	var seenAtCursor, yielded int
+	for {
+		size := pageSize + seenAtCursor
+		result, err := c.queryPage(ctx, query.buildCursorPage(size, offset, cursor))
+		if err != nil {
+			yield(Row{}, err)
+			return
+		}
+		offset = 0
+
+		var received int
+		skip := seenAtCursor
+		for _, series := range resultSeries(result) {
+			timeIndex := columnIndex(series.Columns, "time")
+			if timeIndex < 0 {
+				yield(Row{}, errors.New("paginate: time column is missing in result"))
+				return
+			}
+			received += len(series.Values)
+			for _, values := range series.Values {
+				t, err := rowTime(values, timeIndex)
+				if err != nil {
+					yield(Row{}, err)
+					return
+				}
+				if t.Equal(cursor) && skip > 0 {
+					skip--
+					continue
+				}
+				if !yield(Row{Name: series.Name, Tags: series.Tags, Columns: series.Columns, Values: values}, nil) {
+					return
+				}
+				if t.Equal(cursor) {
+					seenAtCursor++
+				} else {
+					cursor, seenAtCursor = t, 1
+				}
+				yielded++
+				if limit > 0 && yielded >= limit {
+					return
+				}
+			}
+		}
+		if received < size {
+			return
+		}
+	}
+}
+
+func (c *client) queryPage(ctx context.Context, query Query) (*QueryResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := c.queryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = result.hasError(); err != nil {
+		return nil, fmt.Errorf("paginate %w", err)
+	}
+	return result, nil
+}
+
+func resultSeries(result *QueryResult) []*Series {
+	if len(result.Results) == 0 {
+		return nil
+	}
+	return result.Results[0].Series
+}
+
+func columnIndex(columns []string, column string) int {
+	for i, c := range columns {
+		if c == column {
+			return i
+		}
+	}
+	return -1
+}
+
+func rowTime(values SeriesValue, index int) (time.Time, error) {
+	if index >= len(values) {
+		return time.Time{}, errors.New("paginate: time column is missing in row")
+	}
+	switch v := values[index].(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("paginate: parse time %s: %w", v, err)
+		}
+		return t, nil
+	default:
+		n, ok := seriesValueToInt64(v)
+		if !ok {
+			return time.Time{}, fmt.Errorf("paginate: unexpected time value %v", v)
+		}
+		return time.Unix(0, n).UTC(), nil
+	}
+}
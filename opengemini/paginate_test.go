@@ -0,0 +1,216 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testPaginateClient(t *testing.T, handler func(command string) *QueryResult) (Client, *[]string) {
+	var commands []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		command := request.URL.Query().Get("q")
+		commands = append(commands, command)
+		writer.Header().Set("Content-Type", HttpContentTypeJSON)
+		require.Nil(t, json.NewEncoder(writer).Encode(handler(command)))
+	}))
+	t.Cleanup(server.Close)
+
+	return testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}}), &commands
+}
+
+func TestPaginateShowSeries(t *testing.T) {
+	var keys = []string{"cpu,host=a", "cpu,host=b", "cpu,host=c", "cpu,host=d", "cpu,host=e"}
+	pattern := regexp.MustCompile(`LIMIT (\d+)(?: OFFSET (\d+))?`)
+	c, commands := testPaginateClient(t, func(command string) *QueryResult {
+		matches := pattern.FindStringSubmatch(command)
+		limit, _ := strconv.Atoi(matches[1])
+		offset, _ := strconv.Atoi(matches[2])
+		series := &Series{Columns: []string{"key"}}
+		for i := offset; i < len(keys) && i < offset+limit; i++ {
+			series.Values = append(series.Values, SeriesValue{keys[i]})
+		}
+		return &QueryResult{Results: []*SeriesResult{{Series: []*Series{series}}}}
+	})
+
+	var got []string
+	for row, err := range c.Paginate(context.Background(), NewShowSeriesBuilder().Database("db").Measurement("cpu"), 2) {
+		require.Nil(t, err)
+		got = append(got, row.Values[0].(string))
+	}
+	require.Equal(t, keys, got)
+	require.Equal(t, []string{
+		`SHOW SERIES FROM "cpu" LIMIT 2`,
+		`SHOW SERIES FROM "cpu" LIMIT 2 OFFSET 2`,
+		`SHOW SERIES FROM "cpu" LIMIT 2 OFFSET 4`,
+	}, *commands)
+
+	// limit and offset of builder restrict the whole pagination
+	*commands = nil
+	got = nil
+	builder := NewShowSeriesBuilder().Database("db").Measurement("cpu").Limit(3).Offset(1)
+	for row, err := range c.Paginate(context.Background(), builder, 2) {
+		require.Nil(t, err)
+		got = append(got, row.Values[0].(string))
+	}
+	require.Equal(t, keys[1:4], got)
+	require.Equal(t, []string{
+		`SHOW SERIES FROM "cpu" LIMIT 2 OFFSET 1`,
+		`SHOW SERIES FROM "cpu" LIMIT 1 OFFSET 3`,
+	}, *commands)
+}
+
+func TestPaginateByTimeCursor(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// two rows share the same time, they are split by the first page
+	var times = []time.Time{base, base.Add(time.Second), base.Add(time.Second), base.Add(2 * time.Second),
+		base.Add(3 * time.Second)}
+	pattern := regexp.MustCompile(`time >= '([^']+)'.* LIMIT (\d+)`)
+	c, commands := testPaginateClient(t, func(command string) *QueryResult {
+		start := time.Time{}
+		limit := 2
+		if matches := pattern.FindStringSubmatch(command); matches != nil {
+			start, _ = time.Parse(time.RFC3339Nano, matches[1])
+			limit, _ = strconv.Atoi(matches[2])
+		}
+		series := &Series{Name: "cpu", Columns: []string{"time", "v"}}
+		for i, ts := range times {
+			if ts.Before(start) || len(series.Values) == limit {
+				continue
+			}
+			series.Values = append(series.Values, SeriesValue{ts.Format(time.RFC3339Nano), float64(i)})
+		}
+		return &QueryResult{Results: []*SeriesResult{{Series: []*Series{series}}}}
+	})
+
+	query := CreateQueryBuilder().Select(NewFieldExpression("v")).From("cpu").
+		Where(NewComparisonCondition("host", Equals, "a"))
+	var got []float64
+	for row, err := range c.Paginate(context.Background(), NewPageableQuery("db", "", query), 2) {
+		require.Nil(t, err)
+		got = append(got, row.Values[1].(float64))
+	}
+	require.Equal(t, []float64{0, 1, 2, 3, 4}, got)
+	require.Equal(t, []string{
+		`SELECT "v" FROM "cpu" WHERE "host" = 'a' ORDER BY time ASC LIMIT 2`,
+		`SELECT "v" FROM "cpu" WHERE ("host" = 'a' AND time >= '2025-01-01T00:00:01Z') ORDER BY time ASC LIMIT 3`,
+		`SELECT "v" FROM "cpu" WHERE ("host" = 'a' AND time >= '2025-01-01T00:00:02Z') ORDER BY time ASC LIMIT 3`,
+	}, *commands)
+
+	// breaking the loop stops the requests
+	*commands = nil
+	for _, err := range c.Paginate(context.Background(), NewPageableQuery("db", "", query), 2) {
+		require.Nil(t, err)
+		break
+	}
+	require.Len(t, *commands, 1)
+}
+
+func TestPaginateSeveralMeasurements(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// the rows of measurements interleave in time, the server applies LIMIT and OFFSET to each series
+	var data = map[string][]time.Duration{
+		"cpu": {0, 2 * time.Second, 4 * time.Second, 6 * time.Second},
+		"mem": {time.Second, 3 * time.Second, 5 * time.Second},
+	}
+	pattern := regexp.MustCompile(`LIMIT (\d+)(?: OFFSET (\d+))?`)
+	c, commands := testPaginateClient(t, func(command string) *QueryResult {
+		matches := pattern.FindStringSubmatch(command)
+		limit, _ := strconv.Atoi(matches[1])
+		offset, _ := strconv.Atoi(matches[2])
+		result := &SeriesResult{}
+		for _, name := range []string{"cpu", "mem"} {
+			series := &Series{Name: name, Columns: []string{"time", "v"}}
+			for i, d := range data[name] {
+				if i >= offset && i < offset+limit {
+					series.Values = append(series.Values, SeriesValue{base.Add(d).Format(time.RFC3339Nano), name + strconv.Itoa(i)})
+				}
+			}
+			if len(series.Values) > 0 {
+				result.Series = append(result.Series, series)
+			}
+		}
+		return &QueryResult{Results: []*SeriesResult{result}}
+	})
+
+	query := CreateQueryBuilder().Select(NewFieldExpression("v")).From("cpu", "mem")
+	var got []string
+	for row, err := range c.Paginate(context.Background(), NewPageableQuery("db", "", query), 2) {
+		require.Nil(t, err)
+		got = append(got, row.Values[1].(string))
+	}
+	require.Equal(t, []string{"cpu0", "cpu1", "mem0", "mem1", "cpu2", "cpu3", "mem2"}, got)
+	require.Equal(t, []string{
+		`SELECT "v" FROM "cpu", "mem" LIMIT 2`,
+		`SELECT "v" FROM "cpu", "mem" LIMIT 2 OFFSET 2`,
+		`SELECT "v" FROM "cpu", "mem" LIMIT 2 OFFSET 4`,
+	}, *commands)
+}
+
+func TestPaginateError(t *testing.T) {
+	c, commands := testPaginateClient(t, func(command string) *QueryResult {
+		return &QueryResult{Error: "database not found"}
+	})
+
+	for _, err := range c.Paginate(context.Background(), NewShowTagKeysBuilder().Database("db"), 0) {
+		require.ErrorIs(t, err, ErrInvalidPageSize)
+	}
+
+	var errs []error
+	for _, err := range c.Paginate(context.Background(), NewShowTagKeysBuilder().Database("db"), 10) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "database not found")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range c.Paginate(ctx, NewShowTagKeysBuilder().Database("db"), 10) {
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	require.Len(t, *commands, 1)
+}
+
+func TestPageableQueryTimeCursor(t *testing.T) {
+	raw := CreateQueryBuilder().Select(NewArithmeticExpression(Multiply, NewFieldExpression("v"), NewConstantExpression(2))).
+		From("cpu")
+	require.True(t, NewPageableQuery("db", "", raw).(*pageableQuery).timeCursor())
+
+	for _, builder := range []*QueryBuilder{
+		CreateQueryBuilder().Select(NewFunctionExpression(FunctionMean, NewFieldExpression("v"))).From("cpu"),
+		CreateQueryBuilder().From("cpu").GroupBy(NewFieldExpression("host")),
+		CreateQueryBuilder().From("cpu").GroupByTime(time.Minute, 0),
+		CreateQueryBuilder().From("cpu").SLimit(1),
+		CreateQueryBuilder().From("cpu", "mem"),
+		CreateQueryBuilder().FromRegex("cpu.*"),
+		CreateQueryBuilder().FromSubQuery(CreateQueryBuilder().From("cpu", "mem")),
+	} {
+		require.False(t, NewPageableQuery("db", "", builder).(*pageableQuery).timeCursor())
+	}
+
+	desc := NewPageableQuery("db", "rp", CreateQueryBuilder().From("cpu").OrderBy(Desc)).(*pageableQuery)
+	query := desc.buildCursorPage(10, 0, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, `SELECT * FROM "cpu" WHERE time <= '2025-01-01T00:00:00Z' ORDER BY time DESC LIMIT 10`, query.Command)
+	require.Equal(t, "rp", query.RetentionPolicy)
+}
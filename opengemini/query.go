@@ -15,6 +15,7 @@
 package opengemini
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -46,11 +47,25 @@ type Query struct {
 	// `weather,location=us-midwest temperature=82`, the client can use `select * from mst where v1=$var` to query data,
 	// and specify params as `var:82`. For more cases, please refer to `ExampleQuery`
 	Params map[string]any
+	// err is recorded by QueryBuilder.Build if the builder is invalid, the query is rejected before sending it
+	err error
+}
+
+// check reject the query built from an invalid builder or without command
+func (q *Query) check() error {
+	if q.err != nil {
+		return q.err
+	}
+	return checkCommand(q.Command)
 }
 
 // Query sends a command to the server
 func (c *client) Query(q Query) (*QueryResult, error) {
-	if err := checkCommand(q.Command); err != nil {
+	return c.queryContext(context.TODO(), q)
+}
+
+func (c *client) queryContext(ctx context.Context, q Query) (*QueryResult, error) {
+	if err := q.check(); err != nil {
 		return nil, err
 	}
 
@@ -80,7 +95,7 @@ func (c *client) Query(q Query) (*QueryResult, error) {
 	c.metrics.queryDatabaseCounter.WithLabelValues(q.Database).Add(1)
 	startAt := time.Now()
 
-	resp, err := c.executeHttpGet(UrlQuery, req)
+	resp, err := c.executeHttpRequestWithContext(ctx, http.MethodGet, UrlQuery, req)
 
 	cost := float64(time.Since(startAt).Milliseconds())
 	c.metrics.queryLatency.Observe(cost)
@@ -97,10 +112,29 @@ func (c *client) Query(q Query) (*QueryResult, error) {
 }
 
 func (c *client) queryPost(q Query) (*QueryResult, error) {
+	if err := q.check(); err != nil {
+		return nil, err
+	}
+	var err error
 	req := buildRequestDetails(c.config, func(req *requestDetails) {
 		req.queryValues.Add("db", q.Database)
 		req.queryValues.Add("q", q.Command)
+		if q.RetentionPolicy != "" {
+			req.queryValues.Add("rp", q.RetentionPolicy)
+		}
+		if len(q.Params) != 0 {
+			var params []byte
+			params, err = json.Marshal(q.Params)
+			if err != nil {
+				err = fmt.Errorf("marshal query bound parameter failed: %w", err)
+				return
+			}
+			req.queryValues.Add("params", string(params))
+		}
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.executeHttpPost(UrlQuery, req)
 	if err != nil {
@@ -15,20 +15,96 @@
 package opengemini
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// FillOption specify how GROUP BY time() reports the intervals without data
+type FillOption string
+
+const (
+	// FillNone omit the intervals without data
+	FillNone FillOption = "none"
+	// FillNull report null for the intervals without data, it is the default behavior of server
+	FillNull FillOption = "null"
+	// FillPrevious report the value of previous interval
+	FillPrevious FillOption = "previous"
+	// FillLinear report the result of linear interpolation
+	FillLinear FillOption = "linear"
+)
+
+// FillValue report the given number for the intervals without data
+func FillValue(value float64) FillOption {
+	return FillOption(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+var fillNumberPattern = regexp.MustCompile(`^-?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// valid report whether the option is one of the constants or a number literal, since it is written into the
+// command as is
+func (o FillOption) valid() bool {
+	switch o {
+	case FillNone, FillNull, FillPrevious, FillLinear:
+		return true
+	default:
+		return fillNumberPattern.MatchString(string(o))
+	}
+}
+
+// IntoMeasurementBackReference write the results into the measurements with the same name as source measurements,
+// it is usually used with regex sources, such as `SELECT * INTO "db"."rp".:MEASUREMENT FROM /.*/`
+const IntoMeasurementBackReference = ":MEASUREMENT"
+
+// querySource the data source of FROM clause
+type querySource interface {
+	// build render the source, params is not nil if the values of conditions are bound to params
+	build(params map[string]any) string
+}
+
+type measurementSource string
+
+func (m measurementSource) build(_ map[string]any) string {
+	return QuoteIdentifier(string(m))
+}
+
+type regexSource string
+
+func (r regexSource) build(_ map[string]any) string {
+	return QuoteRegex(string(r))
+}
+
+type subQuerySource struct {
+	query *QueryBuilder
+}
+
+func (s subQuerySource) build(params map[string]any) string {
+	return "(" + s.query.buildCommand(params) + ")"
+}
+
 type QueryBuilder struct {
-	selectExprs []Expression
-	from        []string
-	where       Condition
-	groupBy     []Expression
-	order       SortOrder
-	limit       int64
-	offset      int64
-	timezone    *time.Location
+	selectExprs     []Expression
+	intoDatabase    string
+	intoRp          string
+	intoMeasurement string
+	from            []querySource
+	where           Condition
+	groupByTime     time.Duration
+	groupByOffset   time.Duration
+	groupBy         []Expression
+	fill            FillOption
+	order           SortOrder
+	limit           int64
+	offset          int64
+	sLimit          int64
+	sOffset         int64
+	timezone        *time.Location
+	bindParams      bool
+	// err records the invalid argument of builder methods, it is returned by Validate and the query of Build
+	err error
 }
 
 func CreateQueryBuilder() *QueryBuilder {
@@ -40,8 +116,43 @@ func (q *QueryBuilder) Select(selectExpressions ...Expression) *QueryBuilder {
 	return q
 }
 
+// From specify the measurements to query, it replaces the sources set by FromRegex or FromSubQuery
 func (q *QueryBuilder) From(tables ...string) *QueryBuilder {
-	q.from = tables
+	q.from = make([]querySource, 0, len(tables))
+	for _, table := range tables {
+		q.from = append(q.from, measurementSource(table))
+	}
+	return q
+}
+
+// FromRegex specify the measurements matching the regular expressions to query, such as `/cpu.*/`, the slash in
+// pattern is escaped automatically, it replaces the sources set by From or FromSubQuery
+func (q *QueryBuilder) FromRegex(patterns ...string) *QueryBuilder {
+	q.from = make([]querySource, 0, len(patterns))
+	for _, pattern := range patterns {
+		q.from = append(q.from, regexSource(pattern))
+	}
+	return q
+}
+
+// FromSubQuery query the results of another query, such as `SELECT MAX("mean") FROM (SELECT MEAN("v") ...)`, it
+// replaces the sources set by From or FromRegex
+func (q *QueryBuilder) FromSubQuery(subQuery *QueryBuilder) *QueryBuilder {
+	if subQuery == nil {
+		q.err = fmt.Errorf("from: %w", ErrEmptySubQuery)
+		q.from = nil
+		return q
+	}
+	q.from = []querySource{subQuerySource{query: subQuery}}
+	return q
+}
+
+// Into write the query results into measurement, database and retention policy are optional, use
+// IntoMeasurementBackReference as measurement to keep the name of source measurements
+func (q *QueryBuilder) Into(database, retentionPolicy, measurement string) *QueryBuilder {
+	q.intoDatabase = database
+	q.intoRp = retentionPolicy
+	q.intoMeasurement = measurement
 	return q
 }
 
@@ -55,6 +166,20 @@ func (q *QueryBuilder) GroupBy(groupByExpressions ...Expression) *QueryBuilder {
 	return q
 }
 
+// GroupByTime group data into time intervals, offset shifts the boundaries of intervals and can be negative,
+// such as GroupByTime(time.Hour, 15*time.Minute) is rendered as `GROUP BY time(1h, 15m)`
+func (q *QueryBuilder) GroupByTime(interval, offset time.Duration) *QueryBuilder {
+	q.groupByTime = interval
+	q.groupByOffset = offset
+	return q
+}
+
+// Fill specify how GROUP BY time() reports the intervals without data
+func (q *QueryBuilder) Fill(option FillOption) *QueryBuilder {
+	q.fill = option
+	return q
+}
+
 func (q *QueryBuilder) OrderBy(order SortOrder) *QueryBuilder {
 	q.order = order
 	return q
@@ -70,52 +195,83 @@ func (q *QueryBuilder) Offset(offset int64) *QueryBuilder {
 	return q
 }
 
+// SLimit limit the number of series returned
+func (q *QueryBuilder) SLimit(limit int64) *QueryBuilder {
+	q.sLimit = limit
+	return q
+}
+
+// SOffset skip the first N series returned
+func (q *QueryBuilder) SOffset(offset int64) *QueryBuilder {
+	q.sOffset = offset
+	return q
+}
+
 func (q *QueryBuilder) Timezone(location *time.Location) *QueryBuilder {
 	q.timezone = location
 	return q
 }
 
+// BindParams send the values of conditions through Query.Params instead of rendering them into the command, such
+// as `"host" = $p0` with params `{"p0": "server01"}`, the conditions of subqueries are bound as well, regex values
+// can't be bound and are quoted as regex literal
+func (q *QueryBuilder) BindParams() *QueryBuilder {
+	q.bindParams = true
+	return q
+}
+
 func (q *QueryBuilder) Build() *Query {
-	var commandBuilder strings.Builder
+	var params map[string]any
+	if q.bindParams {
+		params = make(map[string]any)
+	}
+	return &Query{
+		Command: q.buildCommand(params),
+		Params:  params,
+		err:     q.buildError(),
+	}
+}
 
-	// Build the SELECT part
-	if len(q.selectExprs) > 0 {
-		commandBuilder.WriteString("SELECT ")
-		for i, expr := range q.selectExprs {
-			if i > 0 {
-				commandBuilder.WriteString(", ")
+// buildError return the error recorded by the builder or its subqueries
+func (q *QueryBuilder) buildError() error {
+	if q.err != nil {
+		return q.err
+	}
+	if q.fill != "" && !q.fill.valid() {
+		return fmt.Errorf("fill(%s): %w", q.fill, ErrInvalidFillOption)
+	}
+	for _, source := range q.from {
+		if s, ok := source.(subQuerySource); ok {
+			if err := s.query.buildError(); err != nil {
+				return fmt.Errorf("subquery: %w", err)
 			}
-			commandBuilder.WriteString(expr.build())
 		}
-	} else {
-		commandBuilder.WriteString("SELECT *")
 	}
+	return nil
+}
+
+// buildCommand render the statement, the values of conditions are bound to params if it is not nil
+func (q *QueryBuilder) buildCommand(params map[string]any) string {
+	var commandBuilder strings.Builder
+
+	// Build the SELECT part
+	commandBuilder.WriteString(q.buildSelect())
+
+	// Build the INTO part
+	commandBuilder.WriteString(q.buildInto())
 
 	// Build the FROM part
-	if len(q.from) > 0 {
-		commandBuilder.WriteString(" FROM ")
-		quotedTables := make([]string, len(q.from))
-		for i, table := range q.from {
-			quotedTables[i] = `"` + table + `"`
-		}
-		commandBuilder.WriteString(strings.Join(quotedTables, ", "))
-	}
+	commandBuilder.WriteString(q.buildFrom(params))
 
 	// Build the WHERE part
-	if q.where != nil {
-		commandBuilder.WriteString(" WHERE ")
-		commandBuilder.WriteString(q.where.build())
-	}
+	commandBuilder.WriteString(q.buildWhere(params))
 
 	// Build the GROUP BY part
-	if len(q.groupBy) > 0 {
-		commandBuilder.WriteString(" GROUP BY ")
-		for i, expr := range q.groupBy {
-			if i > 0 {
-				commandBuilder.WriteString(", ")
-			}
-			commandBuilder.WriteString(expr.build())
-		}
+	commandBuilder.WriteString(q.buildGroupBy())
+
+	// Build the FILL part
+	if q.fill != "" {
+		commandBuilder.WriteString(" fill(" + string(q.fill) + ")")
 	}
 
 	// Build the ORDER BY part
@@ -134,13 +290,193 @@ func (q *QueryBuilder) Build() *Query {
 		commandBuilder.WriteString(fmt.Sprintf(" OFFSET %d", q.offset))
 	}
 
+	// Build the SLIMIT part
+	if q.sLimit > 0 {
+		commandBuilder.WriteString(fmt.Sprintf(" SLIMIT %d", q.sLimit))
+	}
+
+	// Build the SOFFSET part
+	if q.sOffset > 0 {
+		commandBuilder.WriteString(fmt.Sprintf(" SOFFSET %d", q.sOffset))
+	}
+
 	// Build the TIMEZONE part
 	if q.timezone != nil {
-		commandBuilder.WriteString(fmt.Sprintf(" TZ('%s')", q.timezone.String()))
+		commandBuilder.WriteString(" TZ(" + QuoteString(q.timezone.String()) + ")")
+	}
+
+	return commandBuilder.String()
+}
+
+func (q *QueryBuilder) buildSelect() string {
+	if len(q.selectExprs) == 0 {
+		return "SELECT *"
+	}
+	var buf strings.Builder
+	buf.WriteString("SELECT ")
+	for i, expr := range q.selectExprs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(expr.build())
 	}
+	return buf.String()
+}
+
+// buildInto render the INTO part, such as ` INTO "db"."rp"."mst"`, the database is omitted if empty, the
+// retention policy is left empty if only the database is specified
+func (q *QueryBuilder) buildInto() string {
+	if len(q.intoMeasurement) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	buf.WriteString(" INTO ")
+	if len(q.intoDatabase) != 0 {
+		buf.WriteString(QuoteIdentifier(q.intoDatabase) + ".")
+		if len(q.intoRp) != 0 {
+			buf.WriteString(QuoteIdentifier(q.intoRp))
+		}
+		buf.WriteString(".")
+	} else if len(q.intoRp) != 0 {
+		buf.WriteString(QuoteIdentifier(q.intoRp) + ".")
+	}
+	if q.intoMeasurement == IntoMeasurementBackReference {
+		buf.WriteString(q.intoMeasurement)
+	} else {
+		buf.WriteString(QuoteIdentifier(q.intoMeasurement))
+	}
+	return buf.String()
+}
+
+func (q *QueryBuilder) buildFrom(params map[string]any) string {
+	if len(q.from) == 0 {
+		return ""
+	}
+	sources := make([]string, len(q.from))
+	for i, source := range q.from {
+		sources[i] = source.build(params)
+	}
+	return " FROM " + strings.Join(sources, ", ")
+}
+
+func (q *QueryBuilder) buildWhere(params map[string]any) string {
+	if q.where == nil {
+		return ""
+	}
+	if params != nil {
+		return " WHERE " + q.where.bind(params)
+	}
+	return " WHERE " + q.where.build()
+}
 
-	// Return the final query
+// Validate check the query before sending it to server, such as the arguments of functions in catalogue, Build
+// doesn't validate the query, call Validate first if the query is assembled from user input
+func (q *QueryBuilder) Validate() error {
+	if q.err != nil {
+		return q.err
+	}
+	for _, expr := range q.selectExprs {
+		if err := validateExpression(expr); err != nil {
+			return err
+		}
+	}
+	if len(q.intoMeasurement) == 0 && (len(q.intoDatabase) != 0 || len(q.intoRp) != 0) {
+		return fmt.Errorf("into: %w", ErrEmptyMeasurement)
+	}
+	for _, source := range q.from {
+		switch s := source.(type) {
+		case regexSource:
+			if _, err := regexp.Compile(string(s)); err != nil {
+				return fmt.Errorf("from: invalid regex /%s/: %w", string(s), err)
+			}
+		case subQuerySource:
+			if err := s.query.Validate(); err != nil {
+				return fmt.Errorf("subquery: %w", err)
+			}
+		}
+	}
+	if q.groupByTime < 0 {
+		return errors.New("group by time interval must be greater than 0")
+	}
+	if q.groupByTime == 0 && q.groupByOffset != 0 {
+		return errors.New("group by time offset requires group by time interval")
+	}
+	if q.fill != "" && !q.fill.valid() {
+		return fmt.Errorf("fill(%s): %w", q.fill, ErrInvalidFillOption)
+	}
+	if q.fill != "" && q.groupByTime == 0 && len(q.groupBy) == 0 {
+		return errors.New("fill requires group by")
+	}
+	if q.limit < 0 || q.offset < 0 || q.sLimit < 0 || q.sOffset < 0 {
+		return errors.New("limit and offset must not be negative")
+	}
+	return nil
+}
+
+// validateExpression validate the functions in expression recursively
+func validateExpression(expr Expression) error {
+	switch e := expr.(type) {
+	case *FunctionExpression:
+		return e.Validate()
+	case *AsExpression:
+		return validateExpression(e.OriginExpr)
+	case *ArithmeticExpression:
+		for _, operand := range e.Operands {
+			if err := validateExpression(operand); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// buildGroupBy render the GROUP BY part, time() is placed in front of the other group by expressions
+func (q *QueryBuilder) buildGroupBy() string {
+	var parts []string
+	if q.groupByTime > 0 {
+		if q.groupByOffset != 0 {
+			parts = append(parts, "time("+formatDuration(q.groupByTime)+", "+formatDuration(q.groupByOffset)+")")
+		} else {
+			parts = append(parts, "time("+formatDuration(q.groupByTime)+")")
+		}
+	}
+	for _, expr := range q.groupBy {
+		parts = append(parts, expr.build())
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " GROUP BY " + strings.Join(parts, ", ")
+}
+
+// ComposeQuery compose the statements built by builders into one Query separated by `;`, server executes them in
+// order and returns a result for each statement in QueryResult.Results, the params of builders with BindParams are
+// merged into Query.Params. The errors of builders and nil builders are joined into the error returned by the client
+// instead of sending the Query
+func ComposeQuery(builders ...*QueryBuilder) *Query {
+	var params map[string]any
+	var errs []error
+	statements := make([]string, 0, len(builders))
+	for i, builder := range builders {
+		if builder == nil {
+			errs = append(errs, fmt.Errorf("statement %d: %w", i, ErrNilQueryBuilder))
+			continue
+		}
+		if err := builder.buildError(); err != nil {
+			errs = append(errs, fmt.Errorf("statement %d: %w", i, err))
+		}
+		if !builder.bindParams {
+			statements = append(statements, builder.buildCommand(nil))
+			continue
+		}
+		if params == nil {
+			params = make(map[string]any)
+		}
+		statements = append(statements, builder.buildCommand(params))
+	}
 	return &Query{
-		Command: commandBuilder.String(),
+		Command: strings.Join(statements, "; "),
+		Params:  params,
+		err:     errors.Join(errs...),
 	}
 }
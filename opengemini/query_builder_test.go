@@ -15,6 +15,7 @@
 package opengemini
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -273,3 +274,245 @@ func TestQueryBuilderSelectWithAggregate(t *testing.T) {
 
 	require.Equal(t, expectedQuery, query.Command)
 }
+
+func TestQueryBuilderSelectWithGroupByTimeAndFill(t *testing.T) {
+	qb := CreateQueryBuilder()
+
+	meanFunction := NewFunctionExpression(FunctionMean, NewFieldExpression("water_level"))
+
+	query := qb.Select(meanFunction).
+		From("h2o_feet").
+		Where(NewRelativeTimeCondition(GreaterThanOrEquals, time.Hour)).
+		GroupByTime(12*time.Minute, 6*time.Minute).
+		GroupBy(NewFieldExpression("location")).
+		Fill(FillPrevious).Build()
+
+	expectedQuery := `SELECT MEAN("water_level") FROM "h2o_feet" WHERE time >= now() - 1h GROUP BY time(12m, 6m), "location" fill(previous)`
+
+	require.Equal(t, expectedQuery, query.Command)
+}
+
+func TestQueryBuilderSelectWithFillValueAndSLimit(t *testing.T) {
+	qb := CreateQueryBuilder()
+
+	start := time.Date(2019, 8, 18, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+
+	query := qb.Select(NewFunctionExpression(FunctionMax, NewFieldExpression("water_level"))).
+		From("h2o_feet").
+		Where(NewTimeRangeCondition(start, end)).
+		GroupByTime(12*time.Minute, -time.Minute).
+		GroupBy(NewStarExpression()).
+		Fill(FillValue(-1.5)).
+		Limit(2).SLimit(1).SOffset(1).Build()
+
+	expectedQuery := `SELECT MAX("water_level") FROM "h2o_feet" WHERE (time >= '2019-08-18T00:00:00Z' AND time < '2019-08-18T00:30:00Z') GROUP BY time(12m, -1m), * fill(-1.5) LIMIT 2 SLIMIT 1 SOFFSET 1`
+
+	require.Equal(t, expectedQuery, query.Command)
+}
+
+func TestTimeConditions(t *testing.T) {
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.FixedZone("UTC+8", 8*3600))
+	require.Equal(t, `time >= '2025-01-01T00:00:00Z'`, NewTimeCondition(GreaterThanOrEquals, start).build())
+	require.Equal(t, `time < now()`, NewRelativeTimeCondition(LessThan, 0).build())
+	require.Equal(t, `time <= now() + 1d`, NewRelativeTimeCondition(LessThanOrEquals, -24*time.Hour).build())
+	require.Equal(t, `time >= '2025-01-01T00:00:00Z'`, NewTimeRangeCondition(start, time.Time{}).build())
+	require.Nil(t, NewTimeRangeCondition(time.Time{}, time.Time{}))
+}
+
+func TestFunctionExpressionValidate(t *testing.T) {
+	field := NewFieldExpression("value")
+	valid := []*FunctionExpression{
+		NewFunctionExpression(FunctionCount, NewStarExpression()),
+		NewFunctionExpression(FunctionCount, NewFunctionExpression(FunctionDistinct, field)),
+		NewFunctionExpression(FunctionPercentile, field, NewConstantExpression(99.9)),
+		NewFunctionExpression(FunctionDerivative, field),
+		NewFunctionExpression(FunctionNonNegativeDerivative, NewFunctionExpression(FunctionMean, field), NewDurationExpression(time.Second)),
+		NewFunctionExpression(FunctionMovingAverage, field, NewConstantExpression(3)),
+		NewFunctionExpression(FunctionTop, field, NewFieldExpression("host"), NewFieldExpression("region"), NewConstantExpression(3)),
+		NewFunctionExpression(FunctionHoltWinters, NewFunctionExpression(FunctionFirst, field), NewConstantExpression(10), NewConstantExpression(4)),
+		NewFunctionExpression(FunctionHoltWinters, NewFunctionExpression(FunctionFirst, field), NewConstantExpression(10), NewConstantExpression(0)),
+		NewFunctionExpression(FunctionTime, NewConstantExpression("12m")),
+	}
+	for _, function := range valid {
+		require.Nil(t, function.Validate(), function.build())
+	}
+
+	invalid := []*FunctionExpression{
+		NewFunctionExpression(FunctionMean),
+		NewFunctionExpression(FunctionSum, field, field),
+		NewFunctionExpression(FunctionPercentile, field, NewConstantExpression(101)),
+		NewFunctionExpression(FunctionPercentile, field, NewConstantExpression("99")),
+		NewFunctionExpression(FunctionDerivative, field, NewConstantExpression("1m")),
+		NewFunctionExpression(FunctionIntegral, field, NewDurationExpression(0)),
+		NewFunctionExpression(FunctionMovingAverage, field, NewConstantExpression(0)),
+		NewFunctionExpression(FunctionMovingAverage, field, NewConstantExpression(1)),
+		NewFunctionExpression(FunctionHoltWinters, NewFunctionExpression(FunctionFirst, field), NewConstantExpression(10), NewConstantExpression(-1)),
+		NewFunctionExpression(FunctionTop, field),
+		NewFunctionExpression(FunctionHoltWinters, field, NewConstantExpression(10), NewConstantExpression(4)),
+		NewFunctionExpression(FunctionCount, NewFunctionExpression(FunctionStddev)),
+	}
+	for _, function := range invalid {
+		require.NotNil(t, function.Validate(), function.build())
+	}
+}
+
+func TestQueryBuilderValidate(t *testing.T) {
+	field := NewFieldExpression("value")
+
+	qb := CreateQueryBuilder().
+		Select(NewAsExpression("p95", NewFunctionExpression(FunctionPercentile, field, NewConstantExpression(95)))).
+		From("cpu").GroupByTime(time.Minute, 0).Fill(FillNone)
+	require.Nil(t, qb.Validate())
+
+	qb = CreateQueryBuilder().
+		Select(NewArithmeticExpression(Multiply, NewFunctionExpression(FunctionSpread), NewConstantExpression(2))).From("cpu")
+	require.NotNil(t, qb.Validate())
+
+	require.ErrorContains(t, CreateQueryBuilder().From("cpu").Fill(FillLinear).Validate(), "fill requires group by")
+	require.Nil(t, CreateQueryBuilder().From("cpu").GroupBy(NewFieldExpression("host")).Fill(FillNone).Validate())
+	require.Nil(t, CreateQueryBuilder().From("cpu").GroupBy(NewFieldExpression("host")).Fill(FillValue(-1.5)).Validate())
+	require.Nil(t, CreateQueryBuilder().From("cpu").GroupBy(NewFieldExpression("host")).Fill("1e3").Validate())
+	for _, option := range []FillOption{"0); DROP DATABASE db0; --", "Previous", "nan", "1.2.3", "-"} {
+		qb := CreateQueryBuilder().From("cpu").GroupBy(NewFieldExpression("host")).Fill(option)
+		require.ErrorIs(t, qb.Validate(), ErrInvalidFillOption, option)
+		require.ErrorIs(t, qb.Build().err, ErrInvalidFillOption, option)
+	}
+	require.NotNil(t, CreateQueryBuilder().From("cpu").GroupByTime(-time.Minute, 0).Validate())
+	require.NotNil(t, CreateQueryBuilder().From("cpu").GroupByTime(0, time.Minute).Validate())
+	require.NotNil(t, CreateQueryBuilder().From("cpu").SLimit(-1).Validate())
+}
+
+func TestQueryBuilderSelectFromSubQuery(t *testing.T) {
+	subQuery := CreateQueryBuilder().
+		Select(NewAsExpression("mean", NewFunctionExpression(FunctionMean, NewFieldExpression("water_level")))).
+		From("h2o_feet").
+		GroupByTime(12*time.Minute, 0).
+		GroupBy(NewFieldExpression("location"))
+
+	query := CreateQueryBuilder().
+		Select(NewFunctionExpression(FunctionMax, NewFieldExpression("mean"))).
+		FromSubQuery(subQuery).
+		GroupBy(NewFieldExpression("location")).Build()
+
+	expectedQuery := `SELECT MAX("mean") FROM (SELECT MEAN("water_level") AS "mean" FROM "h2o_feet" GROUP BY time(12m), "location") GROUP BY "location"`
+
+	require.Equal(t, expectedQuery, query.Command)
+}
+
+func TestQueryBuilderSelectIntoFromRegex(t *testing.T) {
+	query := CreateQueryBuilder().
+		Select(NewFunctionExpression(FunctionMean, NewStarExpression())).
+		Into("db1", "rp1", IntoMeasurementBackReference).
+		FromRegex("cpu.*", "mem/.*").
+		GroupByTime(time.Hour, 0).
+		GroupBy(NewStarExpression()).Build()
+
+	expectedQuery := `SELECT MEAN(*) INTO "db1"."rp1".:MEASUREMENT FROM /cpu.*/, /mem\/.*/ GROUP BY time(1h), *`
+
+	require.Equal(t, expectedQuery, query.Command)
+
+	query = CreateQueryBuilder().Into("db1", "", "cpu_copy").From("cpu").Build()
+	require.Equal(t, `SELECT * INTO "db1".."cpu_copy" FROM "cpu"`, query.Command)
+
+	query = CreateQueryBuilder().Into("", "rp1", "cpu_copy").From("cpu").Build()
+	require.Equal(t, `SELECT * INTO "rp1"."cpu_copy" FROM "cpu"`, query.Command)
+}
+
+func TestComposeQuery(t *testing.T) {
+	query := ComposeQuery(
+		CreateQueryBuilder().Select(NewFunctionExpression(FunctionCount, NewFieldExpression("v"))).From("cpu"),
+		CreateQueryBuilder().From("mem").Limit(1),
+	)
+
+	require.Equal(t, `SELECT COUNT("v") FROM "cpu"; SELECT * FROM "mem" LIMIT 1`, query.Command)
+	require.Nil(t, query.check())
+
+	query = ComposeQuery(CreateQueryBuilder().From("cpu"), nil, CreateQueryBuilder().FromSubQuery(nil))
+	require.ErrorIs(t, query.check(), ErrNilQueryBuilder)
+	require.ErrorIs(t, query.check(), ErrEmptySubQuery)
+	c := testNewClient(t, &Config{Addresses: []Address{{Host: "127.0.0.1", Port: 0}}})
+	_, err := c.Query(*query)
+	require.ErrorIs(t, err, ErrEmptySubQuery)
+}
+
+func TestQueryBuilderValidateSources(t *testing.T) {
+	require.NotNil(t, CreateQueryBuilder().FromRegex("cpu(").Validate())
+	require.ErrorIs(t, CreateQueryBuilder().FromSubQuery(nil).Validate(), ErrEmptySubQuery)
+	require.NotNil(t, CreateQueryBuilder().Into("db", "rp", "").From("cpu").Validate())
+
+	invalidSubQuery := CreateQueryBuilder().Select(NewFunctionExpression(FunctionMean)).From("cpu")
+	require.NotNil(t, CreateQueryBuilder().FromSubQuery(invalidSubQuery).Validate())
+	require.Nil(t, CreateQueryBuilder().FromSubQuery(CreateQueryBuilder().From("cpu")).FromRegex("cpu.*").Validate())
+}
+
+func TestQueryBuilderBuildNilSubQuery(t *testing.T) {
+	nested := CreateQueryBuilder().Select(NewStarExpression()).FromSubQuery(nil)
+	for _, builder := range []*QueryBuilder{nested, CreateQueryBuilder().FromSubQuery(nested)} {
+		query := builder.Build()
+		require.ErrorIs(t, query.check(), ErrEmptySubQuery)
+		require.ErrorIs(t, builder.Validate(), ErrEmptySubQuery)
+	}
+
+	c := testNewClient(t, &Config{Addresses: []Address{{Host: "127.0.0.1", Port: 0}}})
+	_, err := c.Query(*nested.Build())
+	require.ErrorIs(t, err, ErrEmptySubQuery)
+	for _, err := range c.QueryChunked(context.Background(), *nested.Build(), 10) {
+		require.ErrorIs(t, err, ErrEmptySubQuery)
+	}
+	_, err = c.(*client).queryPost(*nested.Build())
+	require.ErrorIs(t, err, ErrEmptySubQuery)
+}
+
+func TestQueryBuilderEscapeIdentifierAndLiteral(t *testing.T) {
+	query := CreateQueryBuilder().
+		Select(NewAsExpression(`a"b`, NewFieldExpression(`water"level`))).
+		From(`h2o"; DROP DATABASE "db`).
+		Where(NewCompositeCondition(And,
+			NewComparisonCondition("location", Equals, "' OR 1=1 --"),
+			NewComparisonCondition("location", Match, "/santa/monica/"),
+		)).Build()
+
+	expectedQuery := `SELECT "water\"level" AS "a\"b" FROM "h2o\"; DROP DATABASE \"db" WHERE ("location" = '\' OR 1=1 --' AND "location" =~ /santa\/monica/)`
+
+	require.Equal(t, expectedQuery, query.Command)
+}
+
+func TestQueryBuilderBindParams(t *testing.T) {
+	start := time.Date(2019, 8, 18, 0, 0, 0, 0, time.UTC)
+
+	subQuery := CreateQueryBuilder().
+		Select(NewAsExpression("mean", NewFunctionExpression(FunctionMean, NewFieldExpression("water_level")))).
+		From("h2o_feet").
+		Where(NewCompositeCondition(And,
+			NewComparisonCondition("location", Equals, "santa_monica"),
+			NewTimeCondition(GreaterThanOrEquals, start),
+			NewRelativeTimeCondition(LessThan, 0),
+		)).
+		GroupByTime(12*time.Minute, 0)
+
+	query := CreateQueryBuilder().
+		Select(NewFunctionExpression(FunctionMax, NewFieldExpression("mean"))).
+		FromSubQuery(subQuery).
+		Where(NewCompositeCondition(Or,
+			NewComparisonCondition("mean", GreaterThan, 2.5),
+			NewComparisonCondition("location", Match, "santa.*"),
+		)).
+		BindParams().Build()
+
+	expectedQuery := `SELECT MAX("mean") FROM (SELECT MEAN("water_level") AS "mean" FROM "h2o_feet" WHERE ("location" = $p0 AND time >= $p1 AND time < now()) GROUP BY time(12m)) WHERE ("mean" > $p2 OR "location" =~ /santa.*/)`
+
+	require.Equal(t, expectedQuery, query.Command)
+	require.Equal(t, map[string]any{"p0": "santa_monica", "p1": "2019-08-18T00:00:00Z", "p2": 2.5}, query.Params)
+}
+
+func TestComposeQueryBindParams(t *testing.T) {
+	query := ComposeQuery(
+		CreateQueryBuilder().From("cpu").Where(NewComparisonCondition("host", Equals, "a")).BindParams(),
+		CreateQueryBuilder().From("mem").Where(NewComparisonCondition("host", Equals, "b")),
+		CreateQueryBuilder().From("disk").Where(NewComparisonCondition("host", Equals, "c")).BindParams(),
+	)
+
+	require.Equal(t, `SELECT * FROM "cpu" WHERE "host" = $p0; SELECT * FROM "mem" WHERE "host" = 'b'; SELECT * FROM "disk" WHERE "host" = $p1`, query.Command)
+	require.Equal(t, map[string]any{"p0": "a", "p1": "c"}, query.Params)
+}
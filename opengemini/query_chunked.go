@@ -0,0 +1,111 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+)
+
+// QueryChunked sends a command to the server with `chunked=true`, the server splits the result into chunks of at
+// most chunkSize rows, each chunk is decoded from the response stream and yielded as a QueryResult, so the whole
+// result is never held in memory. A series larger than chunkSize spans several chunks with the same name and tags,
+// SeriesResult.Partial reports whether more chunks of the statement follow. The numbers are decoded as json.Number
+func (c *client) QueryChunked(ctx context.Context, q Query, chunkSize int) iter.Seq2[*QueryResult, error] {
+	return func(yield func(*QueryResult, error) bool) {
+		if err := q.check(); err != nil {
+			yield(nil, err)
+			return
+		}
+		if chunkSize <= 0 {
+			yield(nil, errors.New("chunk size must be greater than 0"))
+			return
+		}
+
+		var err error
+		req := buildRequestDetails(c.config, func(req *requestDetails) {
+			req.queryValues.Add("db", q.Database)
+			req.queryValues.Add("q", q.Command)
+			req.queryValues.Add("rp", q.RetentionPolicy)
+			req.queryValues.Add("epoch", q.Precision.Epoch())
+			req.queryValues.Add("chunked", "true")
+			req.queryValues.Add("chunk_size", strconv.Itoa(chunkSize))
+			if len(q.Params) != 0 {
+				var params []byte
+				params, err = json.Marshal(q.Params)
+				if err != nil {
+					err = fmt.Errorf("marshal query bound parameter failed: %w", err)
+					return
+				}
+				req.queryValues.Add("params", string(params))
+			}
+			// chunks are json documents, the compression is negotiated by http transport transparently
+			req.header.Set("Accept", HttpContentTypeJSON)
+			req.header.Del("Accept-Encoding")
+		})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		c.metrics.queryCounter.Add(1)
+		c.metrics.queryDatabaseCounter.WithLabelValues(q.Database).Add(1)
+
+		resp, err := c.executeHttpRequestWithContext(ctx, http.MethodGet, UrlQuery, req)
+		if err != nil {
+			yield(nil, errors.New("query request failed, error: "+err.Error()))
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				yield(nil, errors.New("read resp failed, error: "+err.Error()))
+				return
+			}
+			yield(nil, errors.New("error resp, code: "+resp.Status+"body: "+string(body)))
+			return
+		}
+
+		decoder := json.NewDecoder(resp.Body)
+		// keep integers and nanosecond timestamps exact, they don't fit in float64
+		decoder.UseNumber()
+		for {
+			var chunk = new(QueryResult)
+			err = decoder.Decode(chunk)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, errors.New("unmarshal json chunk failed, error: "+err.Error()))
+				return
+			}
+			if err = chunk.hasError(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+	}
+}
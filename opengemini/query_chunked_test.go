@@ -0,0 +1,78 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryChunked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "true", request.URL.Query().Get("chunked"))
+		require.Equal(t, "2", request.URL.Query().Get("chunk_size"))
+		writer.Header().Set("Content-Type", HttpContentTypeJSON)
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","v"],` +
+			`"values":[[1,1],[2,2]],"partial":true}],"partial":true}]}` + "\n"))
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","v"],` +
+			`"values":[[3,3]]}]}]}` + "\n"))
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":1,"error":"measurement not found"}]}` + "\n"))
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}})
+
+	var chunks []*QueryResult
+	var errs []error
+	for chunk, err := range c.QueryChunked(context.Background(), Query{Database: "db", Command: "SELECT v FROM cpu"}, 2) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		chunks = append(chunks, chunk)
+	}
+	require.Len(t, chunks, 2)
+	require.True(t, chunks[0].Results[0].Partial)
+	require.True(t, chunks[0].Results[0].Series[0].Partial)
+	require.Len(t, chunks[0].Results[0].Series[0].Values, 2)
+	require.False(t, chunks[1].Results[0].Series[0].Partial)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "measurement not found")
+
+	for _, err := range c.QueryChunked(context.Background(), Query{Database: "db", Command: "SELECT v FROM cpu"}, 0) {
+		require.NotNil(t, err)
+	}
+}
+
+func TestQueryChunkedNumbers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", HttpContentTypeJSON)
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","v","f"],` +
+			`"values":[[1735689600000000001,9007199254740993,0.5]]}]}]}` + "\n"))
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}})
+	for chunk, err := range c.QueryChunked(context.Background(), Query{Database: "db", Command: "SELECT * FROM cpu"}, 10) {
+		require.Nil(t, err)
+		require.Equal(t, SeriesValue{json.Number("1735689600000000001"), json.Number("9007199254740993"), json.Number("0.5")},
+			chunk.Results[0].Series[0].Values[0])
+	}
+}
@@ -16,11 +16,25 @@ package opengemini
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Condition interface {
 	build() string
+	// bind render the condition like build, but the values are added to params and referenced as `$name`
+	bind(params map[string]any) string
+}
+
+// bindParam add value to params and return the placeholder referencing it
+func bindParam(params map[string]any, value any) string {
+	name := "p" + strconv.Itoa(len(params))
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC().Format(time.RFC3339Nano)
+	}
+	params[name] = value
+	return "$" + name
 }
 
 type ComparisonCondition struct {
@@ -30,12 +44,27 @@ type ComparisonCondition struct {
 }
 
 func (c *ComparisonCondition) build() string {
-	switch c.Value.(type) {
-	case string:
-		return fmt.Sprintf(`"%s" %s '%v'`, c.Column, c.Operator, c.Value)
-	default:
-		return fmt.Sprintf(`"%s" %s %v`, c.Column, c.Operator, c.Value)
+	return QuoteIdentifier(c.Column) + " " + string(c.Operator) + " " + c.formatValue()
+}
+
+func (c *ComparisonCondition) bind(params map[string]any) string {
+	if c.isRegex() {
+		// regex can't be bound, it is quoted as regex literal
+		return c.build()
 	}
+	return QuoteIdentifier(c.Column) + " " + string(c.Operator) + " " + bindParam(params, c.Value)
+}
+
+func (c *ComparisonCondition) isRegex() bool {
+	_, ok := c.Value.(string)
+	return ok && (c.Operator == Match || c.Operator == NotMatch)
+}
+
+func (c *ComparisonCondition) formatValue() string {
+	if c.isRegex() {
+		return quoteRegexValue(c.Value.(string))
+	}
+	return formatLiteral(c.Value)
 }
 
 func NewComparisonCondition(column string, operator ComparisonOperator, value interface{}) *ComparisonCondition {
@@ -59,9 +88,95 @@ func (c *CompositeCondition) build() string {
 	return fmt.Sprintf("(%s)", strings.Join(parts, fmt.Sprintf(" %s ", c.LogicalOperator)))
 }
 
+func (c *CompositeCondition) bind(params map[string]any) string {
+	var parts []string
+	for _, condition := range c.Conditions {
+		parts = append(parts, condition.bind(params))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(parts, fmt.Sprintf(" %s ", c.LogicalOperator)))
+}
+
 func NewCompositeCondition(logicalOperator LogicalOperator, conditions ...Condition) *CompositeCondition {
 	return &CompositeCondition{
 		LogicalOperator: logicalOperator,
 		Conditions:      conditions,
 	}
 }
+
+// TimeCondition compare time with an absolute time, such as `time >= '2025-01-01T00:00:00Z'`, the time is
+// formatted as RFC3339 in UTC
+type TimeCondition struct {
+	Operator ComparisonOperator
+	Time     time.Time
+}
+
+func (t *TimeCondition) build() string {
+	return "time " + string(t.Operator) + " " + formatLiteral(t.Time)
+}
+
+func (t *TimeCondition) bind(params map[string]any) string {
+	return "time " + string(t.Operator) + " " + bindParam(params, t.Time)
+}
+
+func NewTimeCondition(operator ComparisonOperator, t time.Time) *TimeCondition {
+	return &TimeCondition{
+		Operator: operator,
+		Time:     t,
+	}
+}
+
+// RelativeTimeCondition compare time with a time relative to now(), such as `time >= now() - 1h`, a negative Ago
+// means the time in the future
+type RelativeTimeCondition struct {
+	Operator ComparisonOperator
+	Ago      time.Duration
+}
+
+func (r *RelativeTimeCondition) build() string {
+	switch {
+	case r.Ago > 0:
+		return fmt.Sprintf("time %s now() - %s", r.Operator, formatDuration(r.Ago))
+	case r.Ago < 0:
+		return fmt.Sprintf("time %s now() + %s", r.Operator, formatDuration(-r.Ago))
+	default:
+		return fmt.Sprintf("time %s now()", r.Operator)
+	}
+}
+
+func (r *RelativeTimeCondition) bind(_ map[string]any) string {
+	// the duration is formatted by client, it is safe to render it directly
+	return r.build()
+}
+
+func NewRelativeTimeCondition(operator ComparisonOperator, ago time.Duration) *RelativeTimeCondition {
+	return &RelativeTimeCondition{
+		Operator: operator,
+		Ago:      ago,
+	}
+}
+
+// NewTimeRangeCondition filter data in time range [start, end), zero value means unbounded, return nil if both
+// start and end are zero
+func NewTimeRangeCondition(start, end time.Time) Condition {
+	conditions := timeRangeConditions(start, end)
+	switch len(conditions) {
+	case 0:
+		return nil
+	case 1:
+		return conditions[0]
+	default:
+		return NewCompositeCondition(And, conditions...)
+	}
+}
+
+// timeRangeConditions build the conditions of time range [start, end), zero value means unbounded
+func timeRangeConditions(start, end time.Time) []Condition {
+	var conditions []Condition
+	if !start.IsZero() {
+		conditions = append(conditions, NewTimeCondition(GreaterThanOrEquals, start))
+	}
+	if !end.IsZero() {
+		conditions = append(conditions, NewTimeCondition(LessThan, end))
+	}
+	return conditions
+}
@@ -17,6 +17,7 @@ package opengemini
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type Expression interface {
@@ -27,6 +28,8 @@ type AllowedConstantTypes interface {
 	bool | int | int64 | float64 | string
 }
 
+// ConstantExpression render the value as is, a string value is not quoted so that it can carry a literal such as
+// the interval of TIME(12m), never pass user input as string constant
 type ConstantExpression[T AllowedConstantTypes] struct {
 	Value T
 }
@@ -41,12 +44,20 @@ func NewConstantExpression[T AllowedConstantTypes](value T) *ConstantExpression[
 
 type StarExpression struct{}
 
+func (s *StarExpression) build() string {
+	return "*"
+}
+
+func NewStarExpression() *StarExpression {
+	return &StarExpression{}
+}
+
 type FieldExpression struct {
 	Field string
 }
 
 func (f *FieldExpression) build() string {
-	return `"` + f.Field + `"`
+	return QuoteIdentifier(f.Field)
 }
 
 func NewFieldExpression(field string) *FieldExpression {
@@ -68,6 +79,16 @@ func (f *FunctionExpression) build() string {
 	return fmt.Sprintf("%s(%s)", f.Function, strings.Join(args, ", "))
 }
 
+// Validate check the number and type of arguments for the functions in catalogue, such as PERCENTILE requires a
+// field and a number between 0 and 100, the nested functions are validated as well
+func (f *FunctionExpression) Validate() error {
+	signature, ok := functionCatalogue[f.Function]
+	if !ok {
+		return nil
+	}
+	return signature.validate(f.Function, f.Arguments)
+}
+
 func NewFunctionExpression(function FunctionEnum, arguments ...Expression) *FunctionExpression {
 	return &FunctionExpression{
 		Function:  function,
@@ -75,13 +96,26 @@ func NewFunctionExpression(function FunctionEnum, arguments ...Expression) *Func
 	}
 }
 
+// DurationExpression render duration literal, such as the unit of DERIVATIVE("v", 1m)
+type DurationExpression struct {
+	Duration time.Duration
+}
+
+func (d *DurationExpression) build() string {
+	return formatDuration(d.Duration)
+}
+
+func NewDurationExpression(duration time.Duration) *DurationExpression {
+	return &DurationExpression{Duration: duration}
+}
+
 type AsExpression struct {
 	Alias      string
 	OriginExpr Expression
 }
 
 func (a *AsExpression) build() string {
-	return fmt.Sprintf("%s AS \"%s\"", a.OriginExpr.build(), a.Alias)
+	return a.OriginExpr.build() + " AS " + QuoteIdentifier(a.Alias)
 }
 
 func NewAsExpression(alias string, expr Expression) *AsExpression {
@@ -14,6 +14,11 @@
 
 package opengemini
 
+import (
+	"errors"
+	"fmt"
+)
+
 type FunctionEnum string
 
 const (
@@ -25,4 +30,286 @@ const (
 	FunctionTime  FunctionEnum = "TIME"
 	FunctionTop   FunctionEnum = "TOP"
 	FunctionLast  FunctionEnum = "LAST"
+
+	// aggregations
+	FunctionDistinct FunctionEnum = "DISTINCT"
+	FunctionIntegral FunctionEnum = "INTEGRAL"
+	FunctionMedian   FunctionEnum = "MEDIAN"
+	FunctionMode     FunctionEnum = "MODE"
+	FunctionSpread   FunctionEnum = "SPREAD"
+	FunctionStddev   FunctionEnum = "STDDEV"
+
+	// selectors
+	FunctionBottom     FunctionEnum = "BOTTOM"
+	FunctionFirst      FunctionEnum = "FIRST"
+	FunctionPercentile FunctionEnum = "PERCENTILE"
+	FunctionSample     FunctionEnum = "SAMPLE"
+
+	// transformations
+	FunctionAbs                   FunctionEnum = "ABS"
+	FunctionAcos                  FunctionEnum = "ACOS"
+	FunctionAsin                  FunctionEnum = "ASIN"
+	FunctionAtan                  FunctionEnum = "ATAN"
+	FunctionAtan2                 FunctionEnum = "ATAN2"
+	FunctionCeil                  FunctionEnum = "CEIL"
+	FunctionCos                   FunctionEnum = "COS"
+	FunctionCumulativeSum         FunctionEnum = "CUMULATIVE_SUM"
+	FunctionDerivative            FunctionEnum = "DERIVATIVE"
+	FunctionDifference            FunctionEnum = "DIFFERENCE"
+	FunctionElapsed               FunctionEnum = "ELAPSED"
+	FunctionExp                   FunctionEnum = "EXP"
+	FunctionFloor                 FunctionEnum = "FLOOR"
+	FunctionLn                    FunctionEnum = "LN"
+	FunctionLog                   FunctionEnum = "LOG"
+	FunctionLog2                  FunctionEnum = "LOG2"
+	FunctionLog10                 FunctionEnum = "LOG10"
+	FunctionMovingAverage         FunctionEnum = "MOVING_AVERAGE"
+	FunctionNonNegativeDerivative FunctionEnum = "NON_NEGATIVE_DERIVATIVE"
+	FunctionNonNegativeDifference FunctionEnum = "NON_NEGATIVE_DIFFERENCE"
+	FunctionPow                   FunctionEnum = "POW"
+	FunctionRound                 FunctionEnum = "ROUND"
+	FunctionSin                   FunctionEnum = "SIN"
+	FunctionSqrt                  FunctionEnum = "SQRT"
+	FunctionTan                   FunctionEnum = "TAN"
+
+	// predictors
+	FunctionHoltWinters        FunctionEnum = "HOLT_WINTERS"
+	FunctionHoltWintersWithFit FunctionEnum = "HOLT_WINTERS_WITH_FIT"
 )
+
+// argumentKind the kind of function argument
+type argumentKind int
+
+const (
+	// argumentField a field, `*` or the result of another function, such as MEAN("v")
+	argumentField argumentKind = iota
+	// argumentAggregate the result of an aggregate or selector function, such as MEAN("v")
+	argumentAggregate
+	// argumentTag a tag key, only used by TOP and BOTTOM
+	argumentTag
+	// argumentPositiveInteger an integer literal greater than 0
+	argumentPositiveInteger
+	// argumentNonNegativeInteger an integer literal greater than or equal to 0
+	argumentNonNegativeInteger
+	// argumentNumber an integer or float literal
+	argumentNumber
+	// argumentDuration a duration literal, such as 1m
+	argumentDuration
+)
+
+func (k argumentKind) String() string {
+	switch k {
+	case argumentField:
+		return "field"
+	case argumentAggregate:
+		return "aggregate function"
+	case argumentTag:
+		return "tag"
+	case argumentPositiveInteger:
+		return "positive integer"
+	case argumentNonNegativeInteger:
+		return "non-negative integer"
+	case argumentNumber:
+		return "number"
+	case argumentDuration:
+		return "duration"
+	default:
+		return "unknown"
+	}
+}
+
+type functionSignature struct {
+	required []argumentKind
+	optional []argumentKind
+	// tags allow any number of tag arguments between the field and the last argument, such as TOP("v", "host", 3)
+	tags bool
+	// check the extra constraint on the argument values
+	check func(args []Expression) error
+}
+
+var (
+	singleFieldSignature       = functionSignature{required: []argumentKind{argumentField}}
+	fieldWithDurationSignature = functionSignature{
+		required: []argumentKind{argumentField},
+		optional: []argumentKind{argumentDuration},
+	}
+	fieldWithCountSignature = functionSignature{required: []argumentKind{argumentField, argumentPositiveInteger}}
+	fieldWithTagsSignature  = functionSignature{required: []argumentKind{argumentField, argumentPositiveInteger}, tags: true}
+	movingAverageSignature  = functionSignature{
+		required: []argumentKind{argumentField, argumentPositiveInteger},
+		check:    checkMovingAverage,
+	}
+	holtWintersSignature = functionSignature{
+		// the season S is 0 for the data without seasonality
+		required: []argumentKind{argumentAggregate, argumentPositiveInteger, argumentNonNegativeInteger},
+	}
+)
+
+// functionCatalogue the signatures of openGemini functions, functions not in catalogue are not validated
+var functionCatalogue = map[FunctionEnum]functionSignature{
+	FunctionCount:    singleFieldSignature,
+	FunctionDistinct: singleFieldSignature,
+	FunctionIntegral: fieldWithDurationSignature,
+	FunctionMean:     singleFieldSignature,
+	FunctionMedian:   singleFieldSignature,
+	FunctionMode:     singleFieldSignature,
+	FunctionSpread:   singleFieldSignature,
+	FunctionStddev:   singleFieldSignature,
+	FunctionSum:      singleFieldSignature,
+
+	FunctionBottom: fieldWithTagsSignature,
+	FunctionFirst:  singleFieldSignature,
+	FunctionLast:   singleFieldSignature,
+	FunctionMax:    singleFieldSignature,
+	FunctionMin:    singleFieldSignature,
+	FunctionPercentile: {
+		required: []argumentKind{argumentField, argumentNumber},
+		check:    checkPercentile,
+	},
+	FunctionSample: fieldWithCountSignature,
+	FunctionTop:    fieldWithTagsSignature,
+
+	FunctionAbs:                   singleFieldSignature,
+	FunctionAcos:                  singleFieldSignature,
+	FunctionAsin:                  singleFieldSignature,
+	FunctionAtan:                  singleFieldSignature,
+	FunctionAtan2:                 {required: []argumentKind{argumentField, argumentField}},
+	FunctionCeil:                  singleFieldSignature,
+	FunctionCos:                   singleFieldSignature,
+	FunctionCumulativeSum:         singleFieldSignature,
+	FunctionDerivative:            fieldWithDurationSignature,
+	FunctionDifference:            singleFieldSignature,
+	FunctionElapsed:               fieldWithDurationSignature,
+	FunctionExp:                   singleFieldSignature,
+	FunctionFloor:                 singleFieldSignature,
+	FunctionLn:                    singleFieldSignature,
+	FunctionLog:                   {required: []argumentKind{argumentField, argumentNumber}},
+	FunctionLog2:                  singleFieldSignature,
+	FunctionLog10:                 singleFieldSignature,
+	FunctionMovingAverage:         movingAverageSignature,
+	FunctionNonNegativeDerivative: fieldWithDurationSignature,
+	FunctionNonNegativeDifference: singleFieldSignature,
+	FunctionPow:                   {required: []argumentKind{argumentField, argumentNumber}},
+	FunctionRound:                 singleFieldSignature,
+	FunctionSin:                   singleFieldSignature,
+	FunctionSqrt:                  singleFieldSignature,
+	FunctionTan:                   singleFieldSignature,
+
+	FunctionHoltWinters:        holtWintersSignature,
+	FunctionHoltWintersWithFit: holtWintersSignature,
+}
+
+func (s functionSignature) validate(function FunctionEnum, args []Expression) error {
+	if len(args) < len(s.required) || (!s.tags && len(args) > len(s.required)+len(s.optional)) {
+		return fmt.Errorf("%s: unexpected number of arguments %d", function, len(args))
+	}
+	for i, arg := range args {
+		kind := s.kindOf(i, len(args))
+		if err := checkArgument(kind, arg); err != nil {
+			return fmt.Errorf("%s: argument %d: %w", function, i+1, err)
+		}
+	}
+	if s.check != nil {
+		if err := s.check(args); err != nil {
+			return fmt.Errorf("%s: %w", function, err)
+		}
+	}
+	return nil
+}
+
+// kindOf return the kind of the argument at position idx
+func (s functionSignature) kindOf(idx, total int) argumentKind {
+	if s.tags {
+		switch idx {
+		case 0:
+			return s.required[0]
+		case total - 1:
+			return s.required[len(s.required)-1]
+		default:
+			return argumentTag
+		}
+	}
+	if idx < len(s.required) {
+		return s.required[idx]
+	}
+	return s.optional[idx-len(s.required)]
+}
+
+func checkArgument(kind argumentKind, arg Expression) error {
+	var ok bool
+	switch kind {
+	case argumentField:
+		switch arg.(type) {
+		case *FieldExpression, *StarExpression, *FunctionExpression, *ArithmeticExpression:
+			ok = true
+		}
+	case argumentAggregate:
+		_, ok = arg.(*FunctionExpression)
+	case argumentTag:
+		_, ok = arg.(*FieldExpression)
+	case argumentPositiveInteger:
+		var n int64
+		n, ok = integerArgument(arg)
+		if ok && n <= 0 {
+			return fmt.Errorf("expect %s, got %d", kind, n)
+		}
+	case argumentNonNegativeInteger:
+		var n int64
+		n, ok = integerArgument(arg)
+		if ok && n < 0 {
+			return fmt.Errorf("expect %s, got %d", kind, n)
+		}
+	case argumentNumber:
+		_, ok = numberArgument(arg)
+	case argumentDuration:
+		var d *DurationExpression
+		d, ok = arg.(*DurationExpression)
+		if ok && d.Duration <= 0 {
+			return fmt.Errorf("expect %s greater than 0", kind)
+		}
+	}
+	if !ok {
+		return fmt.Errorf("expect %s, got %T", kind, arg)
+	}
+	if function, isFunction := arg.(*FunctionExpression); isFunction {
+		return function.Validate()
+	}
+	return nil
+}
+
+func integerArgument(arg Expression) (int64, bool) {
+	switch n := arg.(type) {
+	case *ConstantExpression[int]:
+		return int64(n.Value), true
+	case *ConstantExpression[int64]:
+		return n.Value, true
+	default:
+		return 0, false
+	}
+}
+
+func numberArgument(arg Expression) (float64, bool) {
+	if n, ok := integerArgument(arg); ok {
+		return float64(n), true
+	}
+	if n, ok := arg.(*ConstantExpression[float64]); ok {
+		return n.Value, true
+	}
+	return 0, false
+}
+
+func checkPercentile(args []Expression) error {
+	n, _ := numberArgument(args[1])
+	if n < 0 || n > 100 {
+		return errors.New("percentile must be between 0 and 100")
+	}
+	return nil
+}
+
+func checkMovingAverage(args []Expression) error {
+	n, _ := integerArgument(args[1])
+	if n < 2 {
+		return errors.New("moving average window must be greater than 1")
+	}
+	return nil
+}
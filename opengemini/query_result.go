@@ -16,12 +16,22 @@ package opengemini
 
 import "errors"
 
-const RpColumnLen = 8
+const (
+	RpColumnLen    = 8
+	UserColumnLen  = 2
+	GrantColumnLen = 2
+
+	ContinuousQueryColumnLen  = 2
+	DownsamplePolicyColumnLen = 5
+	StreamTaskColumnLen       = 2
+)
 
 // SeriesResult contains the results of a series query
 type SeriesResult struct {
 	Series []*Series `json:"series,omitempty" msgpack:"series,omitempty"`
 	Error  string    `json:"error,omitempty" msgpack:"error,omitempty"`
+	// Partial reports whether the rest series of statement are returned in the next chunk of chunked query
+	Partial bool `json:"partial,omitempty" msgpack:"partial,omitempty"`
 }
 
 // QueryResult is the top-level struct
@@ -78,3 +88,178 @@ func (result *QueryResult) convertMeasurements() []string {
 	}
 	return measurements
 }
+
+func (result *QueryResult) convertUserList() []UserInfo {
+	if len(result.Results) == 0 || len(result.Results[0].Series) == 0 {
+		return []UserInfo{}
+	}
+	var (
+		seriesValues = result.Results[0].Series[0].Values
+		users        = make([]UserInfo, 0, len(seriesValues))
+	)
+
+	for _, v := range seriesValues {
+		if len(v) < UserColumnLen {
+			break
+		}
+		if user := NewUserInfo(v); user != nil {
+			users = append(users, *user)
+		}
+	}
+	return users
+}
+
+func (result *QueryResult) convertGrantList() []GrantInfo {
+	if len(result.Results) == 0 || len(result.Results[0].Series) == 0 {
+		return []GrantInfo{}
+	}
+	var (
+		seriesValues = result.Results[0].Series[0].Values
+		grants       = make([]GrantInfo, 0, len(seriesValues))
+	)
+
+	for _, v := range seriesValues {
+		if len(v) < GrantColumnLen {
+			break
+		}
+		if grant := NewGrantInfo(v); grant != nil {
+			grants = append(grants, *grant)
+		}
+	}
+	return grants
+}
+
+func (result *QueryResult) convertContinuousQueryList() []ContinuousQuery {
+	if len(result.Results) == 0 {
+		return []ContinuousQuery{}
+	}
+	var continuousQueries = make([]ContinuousQuery, 0)
+	// each series represents a database, series name is the database name
+	for _, series := range result.Results[0].Series {
+		for _, v := range series.Values {
+			if len(v) < ContinuousQueryColumnLen {
+				break
+			}
+			if cq := NewContinuousQuery(series.Name, v); cq != nil {
+				continuousQueries = append(continuousQueries, *cq)
+			}
+		}
+	}
+	return continuousQueries
+}
+
+func (result *QueryResult) convertDownsamplePolicyList() []DownsamplePolicy {
+	if len(result.Results) == 0 || len(result.Results[0].Series) == 0 {
+		return []DownsamplePolicy{}
+	}
+	var (
+		seriesValues = result.Results[0].Series[0].Values
+		policies     = make([]DownsamplePolicy, 0, len(seriesValues))
+	)
+
+	for _, v := range seriesValues {
+		if len(v) < DownsamplePolicyColumnLen {
+			break
+		}
+		if policy := NewDownsamplePolicy(v); policy != nil {
+			policies = append(policies, *policy)
+		}
+	}
+	return policies
+}
+
+func (result *QueryResult) convertStreamTaskList() []StreamTask {
+	if len(result.Results) == 0 || len(result.Results[0].Series) == 0 {
+		return []StreamTask{}
+	}
+	var (
+		seriesValues = result.Results[0].Series[0].Values
+		streams      = make([]StreamTask, 0, len(seriesValues))
+	)
+
+	for _, v := range seriesValues {
+		if len(v) < StreamTaskColumnLen {
+			break
+		}
+		if stream := NewStreamTask(v); stream != nil {
+			streams = append(streams, *stream)
+		}
+	}
+	return streams
+}
+
+func (result *QueryResult) convertShards() []Shard {
+	if len(result.Results) == 0 {
+		return []Shard{}
+	}
+	var shards = make([]Shard, 0)
+	for _, series := range result.Results[0].Series {
+		columns := newColumnIndex(series.Columns)
+		for _, v := range series.Values {
+			row := seriesRow{columns: columns, value: v}
+			shard := Shard{
+				ID:              row.uint64("id"),
+				Database:        row.string("database"),
+				RetentionPolicy: row.string("retention_policy"),
+				ShardGroup:      row.uint64("shard_group"),
+				StartTime:       row.time("start_time"),
+				EndTime:         row.time("end_time"),
+				ExpiryTime:      row.time("expiry_time"),
+				Owners:          row.owners("owners"),
+				Tier:            row.string("tier"),
+			}
+			if shard.Database == "" {
+				shard.Database = series.Name
+			}
+			shard.Duration = shard.EndTime.Sub(shard.StartTime)
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
+
+func (result *QueryResult) convertShardGroups() []ShardGroup {
+	if len(result.Results) == 0 {
+		return []ShardGroup{}
+	}
+	var groups = make([]ShardGroup, 0)
+	for _, series := range result.Results[0].Series {
+		columns := newColumnIndex(series.Columns)
+		for _, v := range series.Values {
+			row := seriesRow{columns: columns, value: v}
+			group := ShardGroup{
+				ID:              row.uint64("id"),
+				Database:        row.string("database"),
+				RetentionPolicy: row.string("retention_policy"),
+				StartTime:       row.time("start_time"),
+				EndTime:         row.time("end_time"),
+				ExpiryTime:      row.time("expiry_time"),
+			}
+			group.Duration = group.EndTime.Sub(group.StartTime)
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func (result *QueryResult) convertNodes() []Node {
+	if len(result.Results) == 0 {
+		return []Node{}
+	}
+	var nodes = make([]Node, 0)
+	for _, series := range result.Results[0].Series {
+		columns := newColumnIndex(series.Columns)
+		for _, v := range series.Values {
+			row := seriesRow{columns: columns, value: v}
+			nodes = append(nodes, Node{
+				Time:         row.time("time"),
+				Status:       row.string("status"),
+				Hostname:     row.string("hostname"),
+				NodeID:       row.uint64("nodeID"),
+				NodeType:     row.string("nodeType"),
+				Availability: row.string("availability"),
+			})
+		}
+	}
+	return nodes
+}
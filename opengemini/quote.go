@@ -0,0 +1,140 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	identifierReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	stringReplacer     = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`)
+	durationLiteral    = regexp.MustCompile(`^(\d+(ns|u|µ|ms|s|m|h|d|w))+$`)
+)
+
+// QuoteIdentifier quote the name of database, retention policy, measurement, tag, field or user with double quotes,
+// the double quote, backslash and newline in name are escaped, such as `cpu"load` is quoted as `"cpu\"load"`
+func QuoteIdentifier(name string) string {
+	return `"` + identifierReplacer.Replace(name) + `"`
+}
+
+// QuoteString quote string literal with single quotes, the single quote, backslash and newline in value are
+// escaped, such as `it's` is quoted as `'it\'s'`
+func QuoteString(value string) string {
+	return "'" + stringReplacer.Replace(value) + "'"
+}
+
+// QuoteRegex quote regular expression with slashes, the slash in pattern is escaped unless it is escaped already,
+// such as `cpu/.*` is quoted as `/cpu\/.*/`
+func QuoteRegex(pattern string) string {
+	var buf strings.Builder
+	buf.Grow(len(pattern) + 2)
+	buf.WriteByte('/')
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '\\':
+			if i+1 == len(pattern) {
+				// a trailing backslash would escape the closing slash
+				buf.WriteString(`\\`)
+				continue
+			}
+			buf.WriteByte(ch)
+			buf.WriteByte(pattern[i+1])
+			i++
+		case '/':
+			buf.WriteString(`\/`)
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	buf.WriteByte('/')
+	return buf.String()
+}
+
+// quoteRegexValue quote the regex value of user input, the value may be enclosed in slashes already, such as `/cpu.*/`
+func quoteRegexValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		value = value[1 : len(value)-1]
+	}
+	return QuoteRegex(value)
+}
+
+// quoteIdentifiers quote each name and join them with sep
+func quoteIdentifiers(names []string, sep string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = QuoteIdentifier(name)
+	}
+	return strings.Join(quoted, sep)
+}
+
+// formatLiteral format the value of condition as literal, numbers and booleans are rendered as is, time is rendered
+// as RFC3339 string, and the others are rendered as string literal
+func formatLiteral(value any) string {
+	switch v := value.(type) {
+	case string:
+		return QuoteString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return QuoteString(v.UTC().Format(time.RFC3339Nano))
+	default:
+		return QuoteString(fmt.Sprintf("%v", v))
+	}
+}
+
+// checkDuration check the duration literal of user input, such as `3d` or `1h30m`, `INF` means infinite
+func checkDuration(duration string) error {
+	if strings.EqualFold(duration, "INF") || durationLiteral.MatchString(duration) {
+		return nil
+	}
+	return fmt.Errorf("invalid duration literal %q", duration)
+}
+
+// formatDuration format time.Duration as duration literal using the largest unit that divides it exactly,
+// such as 90 * time.Minute is formatted as `90m` and 48 * time.Hour is formatted as `2d`
+func formatDuration(d time.Duration) string {
+	units := []struct {
+		unit     time.Duration
+		shortcut string
+	}{
+		{7 * 24 * time.Hour, "w"},
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "u"},
+	}
+	if d == 0 {
+		return "0s"
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return strconv.FormatInt(int64(d/u.unit), 10) + u.shortcut
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns"
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// scanQuoted scan the quoted string like the lexer of server, it returns the unquoted value and the rest of input
+func scanQuoted(s string, quote byte) (string, string, error) {
+	if len(s) == 0 || s[0] != quote {
+		return "", "", errors.New("missing opening quote")
+	}
+	var buf strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch ch := s[i]; ch {
+		case quote:
+			return buf.String(), s[i+1:], nil
+		case '\n':
+			return "", "", errors.New("bad string")
+		case '\\':
+			if i+1 == len(s) {
+				return "", "", errors.New("bad escape")
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				buf.WriteByte('\n')
+			case '\\', '"', '\'':
+				buf.WriteByte(s[i])
+			default:
+				return "", "", errors.New("bad escape")
+			}
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	return "", "", errors.New("missing closing quote")
+}
+
+// scanRegex scan the regex literal like the lexer of server, it returns the pattern and the rest of input
+func scanRegex(s string) (string, string, error) {
+	if len(s) == 0 || s[0] != '/' {
+		return "", "", errors.New("missing opening slash")
+	}
+	var buf strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch ch := s[i]; ch {
+		case '/':
+			return buf.String(), s[i+1:], nil
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '/' {
+				buf.WriteByte('/')
+			} else if i+1 < len(s) {
+				buf.WriteByte(ch)
+				buf.WriteByte(s[i+1])
+			}
+			i++
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	return "", "", errors.New("missing closing slash")
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	require.Equal(t, `"cpu"`, QuoteIdentifier("cpu"))
+	require.Equal(t, `"cpu\"; DROP DATABASE \"db"`, QuoteIdentifier(`cpu"; DROP DATABASE "db`))
+	require.Equal(t, `"a\\b\nc"`, QuoteIdentifier("a\\b\nc"))
+}
+
+func TestQuoteString(t *testing.T) {
+	require.Equal(t, `'server01'`, QuoteString("server01"))
+	require.Equal(t, `'it\'s'`, QuoteString("it's"))
+	require.Equal(t, `'\' OR 1=1 --'`, QuoteString("' OR 1=1 --"))
+	require.Equal(t, `'a\\\nb'`, QuoteString("a\\\nb"))
+}
+
+func TestQuoteRegex(t *testing.T) {
+	require.Equal(t, `/cpu.*/`, QuoteRegex("cpu.*"))
+	require.Equal(t, `/cpu\/.*/`, QuoteRegex("cpu/.*"))
+	require.Equal(t, `/cpu\/.*/`, QuoteRegex(`cpu\/.*`))
+	require.Equal(t, `/\d+\\/`, QuoteRegex(`\d+\`))
+	require.Equal(t, `/cpu.*/`, quoteRegexValue("/cpu.*/"))
+}
+
+func TestFormatLiteral(t *testing.T) {
+	require.Equal(t, `'v'`, formatLiteral("v"))
+	require.Equal(t, `true`, formatLiteral(true))
+	require.Equal(t, `-3`, formatLiteral(int8(-3)))
+	require.Equal(t, `42`, formatLiteral(uint64(42)))
+	require.Equal(t, `0.1`, formatLiteral(0.1))
+	require.Equal(t, `1000000000000000000000`, formatLiteral(1e21))
+	require.Equal(t, `'2025-01-01T00:00:00Z'`, formatLiteral(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, `'1m0s'`, formatLiteral(time.Minute))
+}
+
+func TestCheckDuration(t *testing.T) {
+	for _, duration := range []string{"1h", "3d", "1h30m", "100ms", "INF", "inf"} {
+		require.Nil(t, checkDuration(duration), duration)
+	}
+	for _, duration := range []string{"", "1", "0", "1x", "1h REPLICATION 3", "-1h"} {
+		require.NotNil(t, checkDuration(duration), duration)
+	}
+}
+
+func FuzzQuoteIdentifier(f *testing.F) {
+	for _, seed := range []string{"cpu", `a"b`, `a\`, "a\nb", `"; DROP DATABASE "db`, ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		value, rest, err := scanQuoted(QuoteIdentifier(name), '"')
+		require.Nil(t, err)
+		require.Equal(t, name, value)
+		require.Empty(t, rest)
+	})
+}
+
+func FuzzQuoteString(f *testing.F) {
+	for _, seed := range []string{"server01", "it's", `\'`, "a\nb", "' OR 1=1 --", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, value string) {
+		unquoted, rest, err := scanQuoted(QuoteString(value), '\'')
+		require.Nil(t, err)
+		require.Equal(t, value, unquoted)
+		require.Empty(t, rest)
+	})
+}
+
+func FuzzQuoteRegex(f *testing.F) {
+	for _, seed := range []string{"cpu.*", "a/b", `a\/b`, `a\`, `\\/`, "/ OR 1=1", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, pattern string) {
+		unquoted, rest, err := scanRegex(QuoteRegex(pattern))
+		require.Nil(t, err)
+		// the regex literal must not be terminated early
+		require.Empty(t, rest)
+		if !strings.Contains(pattern, `\`) {
+			require.Equal(t, pattern, unquoted)
+		}
+	})
+}
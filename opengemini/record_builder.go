@@ -162,6 +162,40 @@ func (r *writeRequestBuilderImpl) Build() (*proto.WriteRequest, error) {
 	return req, nil
 }
 
+// PointsToWriteRequest convert points to the records of a write request, the points of a measurement are gathered
+// into one record, RecordToPoints converts them back without loss of the values. The signed integers are written as
+// integer columns and come back as int64, the unsigned integers as unsigned columns and come back as uint64. float32
+// is rejected with ErrInvalidFieldType, since the float64 it widens to doesn't have the same decimal form, convert it
+// explicitly. The timestamps are converted to nanoseconds by Point.Precision, a zero timestamp is replaced by the
+// current time as RecordBuilder does
+func PointsToWriteRequest(database, rp string, points []*Point) (*proto.WriteRequest, error) {
+	builder, err := NewWriteRequestBuilder(database, rp)
+	if err != nil {
+		return nil, err
+	}
+	for i, point := range points {
+		if point == nil {
+			continue
+		}
+		if len(point.Fields) == 0 {
+			return nil, fmt.Errorf("point %d of %s: %w", i, point.Measurement, ErrEmptyTagOrField)
+		}
+		for key, value := range point.Fields {
+			if _, ok := value.(float32); ok {
+				return nil, fmt.Errorf("point %d of %s: field %s of float32: %w", i, point.Measurement, key,
+					ErrInvalidFieldType)
+			}
+		}
+		line, err := NewRecordBuilder(point.Measurement)
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		builder.AddRecord(line.AddTags(point.Tags).AddFields(point.Fields).
+			Build(toNanoseconds(point.Timestamp, point.Precision)))
+	}
+	return builder.Build()
+}
+
 type recordLineBuilderImpl struct {
 	measurement    string
 	tags           []*fieldTuple
@@ -230,8 +264,10 @@ func (r *recordLineBuilderImpl) AddField(key string, value interface{}) RecordBu
 		typ = record.FieldTypeFloat
 	case bool:
 		typ = record.FieldTypeBoolean
-	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, int:
+	case int8, int16, int32, int64, int:
 		typ = record.FieldTypeInt
+	case uint8, uint16, uint32, uint64, uint:
+		typ = record.FieldTypeUInt
 	}
 	r.fields = append(r.fields, &fieldTuple{
 		Field: record.Field{
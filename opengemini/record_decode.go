@@ -0,0 +1,125 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"fmt"
+
+	"github.com/openGemini/opengemini-client-go/lib/record"
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+// RecordToPoints decode the block of a gRPC record back into points, such as to proxy the writes by gRPC or to verify
+// the encoding in tests. The block is decompressed according to Record.CompressMethod, the null values are omitted
+// from the tags and fields of the points, and the timestamps are in nanoseconds. The integer columns are decoded as
+// int64 and the unsigned columns as uint64, see PointsToWriteRequest for the reverse conversion
+func RecordToPoints(rec *proto.Record) ([]*Point, error) {
+	if rec == nil {
+		return nil, ErrEmptyRecord
+	}
+	block, err := decompressBlock(rec.CompressMethod, rec.Block)
+	if err != nil {
+		return nil, fmt.Errorf("record of %s: %w", rec.Measurement, err)
+	}
+	var decoded record.Record
+	if err = decoded.Unmarshal(block); err != nil {
+		return nil, fmt.Errorf("record of %s: %w", rec.Measurement, err)
+	}
+	return recordRowsToPoints(rec.Measurement, &decoded)
+}
+
+// WriteRequestToPoints decode all the records of req, the points are grouped by record
+func WriteRequestToPoints(req *proto.WriteRequest) ([]*Point, error) {
+	var points []*Point
+	for _, rec := range req.GetRecords() {
+		recordPoints, err := RecordToPoints(rec)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, recordPoints...)
+	}
+	return points, nil
+}
+
+func decompressBlock(method proto.CompressMethod, block []byte) ([]byte, error) {
+	switch method {
+	case proto.CompressMethod_UNCOMPRESSED:
+		return block, nil
+	case proto.CompressMethod_ZSTD_FAST:
+		return decodeZstdBody(block)
+	case proto.CompressMethod_SNAPPY:
+		return decodeSnappyBody(block)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompressMethod, method)
+	}
+}
+
+func recordRowsToPoints(measurement string, rec *record.Record) ([]*Point, error) {
+	timeIndex := len(rec.Schema) - 1
+	if timeIndex < 0 || rec.Schema[timeIndex].Name != record.TimeField {
+		return nil, fmt.Errorf("record of %s: %w", measurement, ErrInvalidTimeColumn)
+	}
+	rows := rec.RowNums()
+	points := make([]*Point, rows)
+	for row := range points {
+		points[row] = &Point{
+			Measurement: measurement,
+			Precision:   PrecisionNanosecond,
+		}
+	}
+
+	for i, field := range rec.Schema {
+		cv := &rec.ColVals[i]
+		// index of the value in Val, the null rows have no value except in the offsets of string columns
+		valueIndex := 0
+		for row := 0; row < rows; row++ {
+			if cv.IsNil(row) {
+				continue
+			}
+			point := points[row]
+			switch field.Type {
+			case record.FieldTypeTag:
+				point.AddTag(field.Name, stringValue(cv, row))
+			case record.FieldTypeString:
+				point.AddField(field.Name, stringValue(cv, row))
+			case record.FieldTypeInt:
+				if i == timeIndex {
+					point.Timestamp = cv.IntegerValues()[valueIndex]
+				} else {
+					point.AddField(field.Name, cv.IntegerValues()[valueIndex])
+				}
+			case record.FieldTypeUInt:
+				point.AddField(field.Name, uint64(cv.IntegerValues()[valueIndex]))
+			case record.FieldTypeFloat:
+				point.AddField(field.Name, cv.FloatValues()[valueIndex])
+			case record.FieldTypeBoolean:
+				point.AddField(field.Name, cv.BooleanValues()[valueIndex])
+			default:
+				return nil, fmt.Errorf("column %s: %w", field.Name, ErrUnknownFieldType)
+			}
+			valueIndex++
+		}
+	}
+	return points, nil
+}
+
+// stringValue copy the value of row out of the column, the column may be reused
+func stringValue(cv *record.ColVal, row int) string {
+	start := cv.Offset[row]
+	if row == len(cv.Offset)-1 {
+		return string(cv.Val[start:])
+	}
+	return string(cv.Val[start:cv.Offset[row+1]])
+}
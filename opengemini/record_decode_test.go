@@ -0,0 +1,96 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/opengemini-client-go/lib/record"
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+func TestRecordToPoints(t *testing.T) {
+	builder, err := NewWriteRequestBuilder("db0", "")
+	require.Nil(t, err)
+	first, err := NewRecordBuilder("cpu")
+	require.Nil(t, err)
+	second, err := NewRecordBuilder("cpu")
+	require.Nil(t, err)
+	request, err := builder.AddRecord(
+		first.AddTag("host", "a").AddField("usage", 1.5).AddField("count", 3).AddField("ok", true).Build(100),
+		second.AddTag("host", "b").AddField("usage", 2.5).AddField("note", "busy").Build(200),
+	).Build()
+	require.Nil(t, err)
+
+	points, err := WriteRequestToPoints(request)
+	require.Nil(t, err)
+	require.Equal(t, []*Point{
+		{
+			Measurement: "cpu",
+			Precision:   PrecisionNanosecond,
+			Timestamp:   100,
+			Tags:        map[string]string{"host": "a"},
+			Fields:      map[string]interface{}{"usage": 1.5, "count": int64(3), "ok": true},
+		},
+		{
+			Measurement: "cpu",
+			Precision:   PrecisionNanosecond,
+			Timestamp:   200,
+			Tags:        map[string]string{"host": "b"},
+			Fields:      map[string]interface{}{"usage": 2.5, "note": "busy"},
+		},
+	}, points)
+
+	block := request.Records[0].Block
+	encoder, err := zstd.NewWriter(nil)
+	require.Nil(t, err)
+	defer encoder.Close()
+	for method, compressed := range map[proto.CompressMethod][]byte{
+		proto.CompressMethod_SNAPPY:    snappy.Encode(nil, block),
+		proto.CompressMethod_ZSTD_FAST: encoder.EncodeAll(block, nil),
+	} {
+		decoded, err := RecordToPoints(&proto.Record{Measurement: "cpu", CompressMethod: method, Block: compressed})
+		require.Nil(t, err, method.String())
+		require.Equal(t, points, decoded, method.String())
+	}
+
+	_, err = RecordToPoints(&proto.Record{Measurement: "cpu", CompressMethod: proto.CompressMethod_LZ4_FAST, Block: block})
+	require.ErrorIs(t, err, ErrUnsupportedCompressMethod)
+	_, err = RecordToPoints(&proto.Record{Measurement: "cpu", Block: block[:len(block)-1]})
+	require.NotNil(t, err)
+}
+
+func TestRecordToPointsMalformed(t *testing.T) {
+	// the bitmap marks both rows as not null while the nil count is 1, only one value is present
+	rec := record.NewRecordBuilder([]record.Field{
+		{Name: "v", Type: record.FieldTypeInt},
+		{Name: record.TimeField, Type: record.FieldTypeInt},
+	})
+	rec.ColVals[0].AppendInteger(1)
+	rec.ColVals[0].AppendIntegerNull()
+	rec.ColVals[0].Bitmap = []byte{0x03}
+	rec.AppendTime(100, 200)
+	block, err := rec.Marshal(nil)
+	require.Nil(t, err)
+
+	require.NotPanics(t, func() {
+		_, err = RecordToPoints(&proto.Record{Measurement: "cpu", Block: block})
+	})
+	require.ErrorContains(t, err, "nil count")
+}
@@ -21,6 +21,9 @@ import (
 	"sort"
 	"time"
 
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+
 	"github.com/openGemini/opengemini-client-go/lib/record"
 	"github.com/openGemini/opengemini-client-go/proto"
 )
@@ -37,20 +40,69 @@ func (c *client) WriteByGrpc(ctx context.Context, req *proto.WriteRequest) error
 		return ErrEmptyRecord
 	}
 
-	// 使用带超时的上下文
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+	c.metrics.grpcWriteCounter.WithLabelValues(req.Database).Inc()
+	c.metrics.grpcWriteRecords.WithLabelValues(req.Database).Add(float64(len(req.Records)))
+
+	retry := c.rpcClient.cfg.RetryConfig
+	switch {
+	case retry == nil:
+		return c.writeByGrpcAttempt(ctx, req, c.rpcClient.getEndpoint())
+	case retry.HedgingDelay > 0:
+		return c.writeByGrpcHedging(ctx, req, retry)
+	default:
+		return c.writeByGrpcRetry(ctx, req, retry)
+	}
+}
+
+// writeByGrpcAttempt send req to ep once, the result is reported to the load balance for the ejection. An attempt
+// abandoned by the caller, such as a hedged attempt cancelled because another one succeeded or a write which exceeds
+// the deadline of the caller, says nothing about ep, it's neither reported nor counted as error
+func (c *client) writeByGrpcAttempt(ctx context.Context, req *proto.WriteRequest, ep *grpcEndpoint) error {
+	parent := ctx
+	// the deadline of caller takes precedence over the configured timeout
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.rpcClient.cfg.Timeout)
+		defer cancel()
+	}
 
-	response, err := c.rpcClient.getClient().Write(ctx, req)
+	c.metrics.countSent(operationGrpcWrite, ep.address, int64(protobuf.Size(req)))
+	var closures []GrpcInterceptorClosure
+	for _, interceptor := range c.interceptors {
+		if grpcInterceptor, ok := interceptor.(GrpcWriteInterceptor); ok {
+			closures = append(closures, grpcInterceptor.GrpcWrite(ctx, &InterceptorGrpcWrite{Request: req, ServerAddress: ep.address}))
+		}
+	}
+	startAt := time.Now()
+
+	response, err := ep.client.Write(ctx, req)
+	abandoned := err != nil && parent.Err() != nil
+	if !abandoned {
+		c.rpcClient.lb.report(ep, err)
+	}
+	var closureErr error
+	for _, fn := range closures {
+		// the closures see the context of the caller, so that they can tell the abandoned attempts apart
+		if e := fn(parent, response, err); e != nil && closureErr == nil {
+			closureErr = e
+		}
+	}
+	if abandoned {
+		return fmt.Errorf("failed to write rows: %w", err)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to write rows: %v", err)
+		c.metrics.countError(operationGrpcWrite, ep.address, grpcErrorType(err), status.Code(err).String())
+		return fmt.Errorf("failed to write rows: %w", err)
 	}
 
+	c.metrics.observeRequest(operationGrpcWrite, req.Database, ep.address, time.Since(startAt))
+	c.metrics.receivedBytes.WithLabelValues(operationGrpcWrite, ep.address).Add(float64(protobuf.Size(response)))
 	if response.Code != proto.ResponseCode_Success {
+		c.metrics.countError(operationGrpcWrite, ep.address, errorTypeResponse, response.Code.String())
 		return fmt.Errorf("failed to write rows: %s", response.String())
 	}
 
-	return nil
+	return closureErr
 }
 
 type writerClient struct {
@@ -62,6 +114,7 @@ func newWriterClient(cfg *GrpcConfig) (*writerClient, error) {
 	if len(cfg.Addresses) == 0 {
 		return nil, fmt.Errorf("no grpc addresses provided: %w", ErrNoAddress)
 	}
+	cfg = grpcConfigWithDefaults(cfg)
 
 	balance, err := newRPCLoadBalance(cfg)
 	if err != nil {
@@ -72,8 +125,8 @@ func newWriterClient(cfg *GrpcConfig) (*writerClient, error) {
 	return rw, nil
 }
 
-func (r *writerClient) getClient() proto.WriteServiceClient {
-	return r.lb.getClient()
+func (r *writerClient) getEndpoint() *grpcEndpoint {
+	return r.lb.getEndpoint()
 }
 
 func (r *writerClient) Close() error {
@@ -197,20 +250,43 @@ func (c *column) appendFieldValue(column *Column, value interface{}) error {
 	case int32:
 		column.cv.AppendInteger(int64(v))
 		return nil
-	case uint:
+	case int16:
 		column.cv.AppendInteger(int64(v))
 		return nil
-	case uint32:
+	case int8:
 		column.cv.AppendInteger(int64(v))
 		return nil
+	case uint:
+		return c.appendUnsignedValue(column, uint64(v))
+	case uint32:
+		return c.appendUnsignedValue(column, uint64(v))
+	case uint16:
+		return c.appendUnsignedValue(column, uint64(v))
+	case uint8:
+		return c.appendUnsignedValue(column, uint64(v))
 	case uint64:
-		column.cv.AppendInteger(int64(v))
-		return nil
+		return c.appendUnsignedValue(column, v)
 	}
 	// For unknown types, try to throw error
 	return ErrUnknownFieldType
 }
 
+// appendUnsignedValue appends the unsigned value to the unsigned column, which stores the bits of value as int64
+func (c *column) appendUnsignedValue(column *Column, value uint64) error {
+	column.cv.AppendInteger(int64(value))
+	return nil
+}
+
+// checkColumnType rejects the values of another type than the existing column, such as a tag and a field of the same
+// name or a field written as float and string
+func checkColumnType(column *Column, fieldType int) error {
+	if column.schema.Type == fieldType {
+		return nil
+	}
+	return fmt.Errorf("column %s of type %s can't accept %s: %w", column.schema.Name,
+		record.FieldTypeName[column.schema.Type], record.FieldTypeName[fieldType], ErrInvalidFieldType)
+}
+
 func (c *column) processTagColumns(tags []*fieldTuple) (err error) {
 	for _, tag := range tags {
 		tagColumn, ok := c.Columns[tag.Name]
@@ -219,6 +295,8 @@ func (c *column) processTagColumns(tags []*fieldTuple) (err error) {
 			if err != nil {
 				return err
 			}
+		} else if err = checkColumnType(tagColumn, record.FieldTypeTag); err != nil {
+			return err
 		}
 		// write the tag value to column, value must be string
 		tagColumn.cv.AppendString(tag.value.(string))
@@ -236,6 +314,8 @@ func (c *column) processFieldColumns(fields []*fieldTuple) (err error) {
 			if err != nil {
 				return err
 			}
+		} else if err = checkColumnType(fieldColumn, field.Type); err != nil {
+			return err
 		}
 
 		if err := c.appendFieldValue(fieldColumn, field.value); err != nil {
@@ -15,26 +15,122 @@
 package opengemini
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	"github.com/openGemini/opengemini-client-go/proto"
 )
 
+const (
+	defaultGrpcTimeout             = 30 * time.Second
+	defaultGrpcKeepaliveTime       = 10 * time.Second
+	defaultGrpcKeepaliveTimeout    = 3 * time.Second
+	defaultGrpcBackoffBaseDelay    = time.Second
+	defaultGrpcBackoffMaxDelay     = 30 * time.Second
+	defaultGrpcMinConnectTimeout   = 20 * time.Second
+	defaultGrpcWindowSize          = 1 << 24          // 16MB
+	defaultGrpcMaxMsgSize          = 64 * 1024 * 1024 // 64MB
+	defaultGrpcHealthCheckInterval = healthCheckPeriod
+	defaultGrpcHealthCheckTimeout  = 3 * time.Second
+	defaultGrpcEjectionThreshold   = 3
+	defaultGrpcEjectionDuration    = 30 * time.Second
+	defaultGrpcMaxAttempts         = 3
+	defaultGrpcInitialBackoff      = 100 * time.Millisecond
+	defaultGrpcMaxBackoff          = 2 * time.Second
+)
+
+// defaultGrpcRetryableCodes the codes of transient failures, which are retried and count towards the ejection
+var defaultGrpcRetryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded}
+
+// grpcConfigWithDefaults return a copy of config whose zero values are filled with the defaults, the config of
+// caller is left as is
+func grpcConfigWithDefaults(config *GrpcConfig) *GrpcConfig {
+	cfg := *config
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultGrpcTimeout
+	}
+	if cfg.KeepaliveTime <= 0 {
+		cfg.KeepaliveTime = defaultGrpcKeepaliveTime
+	}
+	if cfg.KeepaliveTimeout <= 0 {
+		cfg.KeepaliveTimeout = defaultGrpcKeepaliveTimeout
+	}
+	if cfg.BackoffBaseDelay <= 0 {
+		cfg.BackoffBaseDelay = defaultGrpcBackoffBaseDelay
+	}
+	if cfg.BackoffMaxDelay <= 0 {
+		cfg.BackoffMaxDelay = defaultGrpcBackoffMaxDelay
+	}
+	if cfg.MinConnectTimeout <= 0 {
+		cfg.MinConnectTimeout = defaultGrpcMinConnectTimeout
+	}
+	if cfg.InitialWindowSize <= 0 {
+		cfg.InitialWindowSize = defaultGrpcWindowSize
+	}
+	if cfg.InitialConnWindowSize <= 0 {
+		cfg.InitialConnWindowSize = defaultGrpcWindowSize
+	}
+	if cfg.MaxCallRecvMsgSize <= 0 {
+		cfg.MaxCallRecvMsgSize = defaultGrpcMaxMsgSize
+	}
+	if cfg.MaxCallSendMsgSize <= 0 {
+		cfg.MaxCallSendMsgSize = defaultGrpcMaxMsgSize
+	}
+	if cfg.HealthCheckInterval == 0 {
+		cfg.HealthCheckInterval = defaultGrpcHealthCheckInterval
+	}
+	if cfg.HealthCheckTimeout <= 0 {
+		cfg.HealthCheckTimeout = defaultGrpcHealthCheckTimeout
+	}
+	if cfg.EjectionThreshold == 0 {
+		cfg.EjectionThreshold = defaultGrpcEjectionThreshold
+	}
+	if cfg.EjectionDuration <= 0 {
+		cfg.EjectionDuration = defaultGrpcEjectionDuration
+	}
+	if cfg.RetryConfig != nil {
+		retry := *cfg.RetryConfig
+		cfg.RetryConfig = &retry
+		if retry.MaxAttempts <= 0 {
+			retry.MaxAttempts = defaultGrpcMaxAttempts
+		}
+		if retry.InitialBackoff <= 0 {
+			retry.InitialBackoff = defaultGrpcInitialBackoff
+		}
+		if retry.MaxBackoff <= 0 {
+			retry.MaxBackoff = defaultGrpcMaxBackoff
+		}
+		if len(retry.RetryableCodes) == 0 {
+			retry.RetryableCodes = defaultGrpcRetryableCodes
+		}
+	}
+	return &cfg
+}
+
 type grpcEndpoint struct {
 	address string
 	conn    *grpc.ClientConn
 	client  proto.WriteServiceClient
 	mu      sync.RWMutex
+	// isDown is updated by the Ping RPC of health check
+	isDown atomic.Bool
+	// failures count the consecutive transient failures of the writes
+	failures atomic.Int32
+	// ejectedUntil unix nanoseconds until which the endpoint is skipped
+	ejectedUntil atomic.Int64
 }
 
 func (e *grpcEndpoint) isHealthy() bool {
@@ -43,33 +139,46 @@ func (e *grpcEndpoint) isHealthy() bool {
 	return e.conn.GetState() == connectivity.Ready
 }
 
+// isBroken report whether the connection failed or is closed, the result of last Ping RPC is stale then
+func (e *grpcEndpoint) isBroken() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	state := e.conn.GetState()
+	return state == connectivity.TransientFailure || state == connectivity.Shutdown
+}
+
 type grpcLoadBalance struct {
-	endpoints []*grpcEndpoint
-	current   atomic.Int32
-	stopChan  chan struct{}
+	endpoints         []*grpcEndpoint
+	current           atomic.Int32
+	stopChan          chan struct{}
+	healthCheck       bool
+	ejectionThreshold int
+	ejectionDuration  time.Duration
+	// retryableCodes the codes of the failures which count towards the ejection
+	retryableCodes []codes.Code
 }
 
 func newRPCLoadBalance(cfg *GrpcConfig) (*grpcLoadBalance, error) {
 	var dialOptions = []grpc.DialOption{
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                10 * time.Second,
-			Timeout:             3 * time.Second,
+			Time:                cfg.KeepaliveTime,
+			Timeout:             cfg.KeepaliveTimeout,
 			PermitWithoutStream: true,
 		}),
 		// https://github.com/grpc/grpc/blob/master/doc/connection-backoff.md
 		grpc.WithConnectParams(grpc.ConnectParams{
 			Backoff: backoff.Config{
-				BaseDelay:  time.Second,
+				BaseDelay:  cfg.BackoffBaseDelay,
 				Multiplier: 1.6,
 				Jitter:     0.2,
-				MaxDelay:   time.Second * 30,
+				MaxDelay:   cfg.BackoffMaxDelay,
 			},
-			MinConnectTimeout: time.Second * 20,
+			MinConnectTimeout: cfg.MinConnectTimeout,
 		}),
-		grpc.WithInitialWindowSize(1 << 24),                                    // 16MB
-		grpc.WithInitialConnWindowSize(1 << 24),                                // 16MB
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(64 * 1024 * 1024)), // 64MB
-		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(64 * 1024 * 1024)), // 64MB
+		grpc.WithInitialWindowSize(cfg.InitialWindowSize),
+		grpc.WithInitialConnWindowSize(cfg.InitialConnWindowSize),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.MaxCallRecvMsgSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(cfg.MaxCallSendMsgSize)),
 	}
 
 	if cfg.TlsConfig == nil {
@@ -78,9 +187,23 @@ func newRPCLoadBalance(cfg *GrpcConfig) (*grpcLoadBalance, error) {
 		cred := credentials.NewTLS(cfg.TlsConfig)
 		dialOptions = append(dialOptions, grpc.WithTransportCredentials(cred))
 	}
+	if len(cfg.UnaryInterceptors) != 0 {
+		dialOptions = append(dialOptions, grpc.WithUnaryInterceptor(skipHealthCheck(cfg.UnaryInterceptors)))
+	}
+	if len(cfg.StreamInterceptors) != 0 {
+		dialOptions = append(dialOptions, grpc.WithChainStreamInterceptor(cfg.StreamInterceptors...))
+	}
+	dialOptions = append(dialOptions, cfg.DialOptions...)
 
 	lb := &grpcLoadBalance{
-		stopChan: make(chan struct{}),
+		stopChan:          make(chan struct{}),
+		healthCheck:       cfg.HealthCheckInterval > 0,
+		ejectionThreshold: cfg.EjectionThreshold,
+		ejectionDuration:  cfg.EjectionDuration,
+		retryableCodes:    defaultGrpcRetryableCodes,
+	}
+	if cfg.RetryConfig != nil && len(cfg.RetryConfig.RetryableCodes) != 0 {
+		lb.retryableCodes = cfg.RetryConfig.RetryableCodes
 	}
 
 	for _, address := range cfg.Addresses {
@@ -98,24 +221,133 @@ func newRPCLoadBalance(cfg *GrpcConfig) (*grpcLoadBalance, error) {
 		lb.endpoints = append(lb.endpoints, ep)
 	}
 
+	if lb.healthCheck {
+		go lb.endpointsCheck(cfg.HealthCheckInterval, cfg.HealthCheckTimeout)
+	}
 	return lb, nil
 }
 
-// getClient use polling to return the next available client
-func (r *grpcLoadBalance) getClient() proto.WriteServiceClient {
+// endpointsCheck ping all endpoints at once and then every interval until the load balance is closed
+func (r *grpcLoadBalance) endpointsCheck(interval, timeout time.Duration) {
+	var t = time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		r.checkUpOrDown(timeout)
+		select {
+		case <-r.stopChan:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (r *grpcLoadBalance) checkUpOrDown(timeout time.Duration) {
+	wg := &sync.WaitGroup{}
+	for _, ep := range r.endpoints {
+		wg.Add(1)
+		go func(ep *grpcEndpoint) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			ep.isDown.Store(ep.ping(ctx) != nil)
+		}(ep)
+	}
+	wg.Wait()
+}
+
+// healthCheckKey mark the context of Ping RPC sent by the health check
+type healthCheckKey struct{}
+
+// skipHealthCheck chain the interceptors in order as grpc.WithChainUnaryInterceptor, except that the Ping RPC of
+// health check is invoked directly, so the interceptors of GrpcConfig observe the calls of the caller only
+func skipHealthCheck(interceptors []grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		if ctx.Value(healthCheckKey{}) != nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return chainUnaryInterceptors(interceptors, invoker)(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func chainUnaryInterceptors(interceptors []grpc.UnaryClientInterceptor, invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	if len(interceptors) == 0 {
+		return invoker
+	}
+	next := chainUnaryInterceptors(interceptors[1:], invoker)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return interceptors[0](ctx, method, req, reply, cc, next, opts...)
+	}
+}
+
+// ping send the Ping RPC, a server which doesn't implement it is considered up since it answered
+func (e *grpcEndpoint) ping(ctx context.Context) error {
+	ctx = context.WithValue(ctx, healthCheckKey{}, true)
+	response, err := e.client.Ping(ctx, &proto.PingRequest{ClientId: TraceName})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if response.Status != proto.ServerStatus_Up {
+		return fmt.Errorf("server status %s", response.Status)
+	}
+	return nil
+}
+
+// getEndpoint use polling to return the next available endpoint
+func (r *grpcLoadBalance) getEndpoint() *grpcEndpoint {
 	attempts := len(r.endpoints)
 	for i := 0; i < attempts; i++ {
 		current := r.current.Add(1)
 		idx := int(current) % len(r.endpoints)
 		ep := r.endpoints[idx]
 
-		if ep.isHealthy() {
-			return ep.client
+		if r.isAvailable(ep) {
+			return ep
 		}
 	}
 
 	// no healthy endpoint, return random endpoint
-	return r.endpoints[random.Intn(attempts)].client
+	return r.endpoints[random.Intn(attempts)]
+}
+
+// nextEndpoint return the next available endpoint which is not in tried, or any endpoint if all of them are tried
+func (r *grpcLoadBalance) nextEndpoint(tried []*grpcEndpoint) *grpcEndpoint {
+	ep := r.getEndpoint()
+	for i := 1; i < len(r.endpoints) && slices.Contains(tried, ep); i++ {
+		ep = r.getEndpoint()
+	}
+	return ep
+}
+
+// isAvailable use the result of Ping RPC if the health check is enabled, the connectivity state otherwise, an
+// ejected endpoint or a failed connection is never available
+func (r *grpcLoadBalance) isAvailable(ep *grpcEndpoint) bool {
+	if time.Now().UnixNano() < ep.ejectedUntil.Load() {
+		return false
+	}
+	if r.healthCheck {
+		return !ep.isDown.Load() && !ep.isBroken()
+	}
+	return ep.isHealthy()
+}
+
+// report the result of a write to ep, the endpoint is ejected after ejectionThreshold consecutive failures with one
+// of the retryable codes
+func (r *grpcLoadBalance) report(ep *grpcEndpoint, err error) {
+	if r.ejectionThreshold < 0 {
+		return
+	}
+	if err == nil || !isRetryable(err, r.retryableCodes) {
+		ep.failures.Store(0)
+		return
+	}
+	if ep.failures.Add(1) >= int32(r.ejectionThreshold) {
+		ep.failures.Store(0)
+		ep.ejectedUntil.Store(time.Now().Add(r.ejectionDuration).UnixNano())
+	}
 }
 
 // Close all endpoint
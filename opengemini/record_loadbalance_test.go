@@ -0,0 +1,133 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+type testPingWriteServer struct {
+	proto.UnimplementedWriteServiceServer
+	status func() proto.ServerStatus
+	delay  time.Duration
+}
+
+func (s *testPingWriteServer) Write(ctx context.Context, _ *proto.WriteRequest) (*proto.WriteResponse, error) {
+	select {
+	case <-time.After(s.delay):
+		return &proto.WriteResponse{Code: proto.ResponseCode_Success}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *testPingWriteServer) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	return &proto.PingResponse{Status: s.status()}, nil
+}
+
+func testStartGrpcServer(t *testing.T, server proto.WriteServiceServer) Address {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	grpcServer := grpc.NewServer()
+	proto.RegisterWriteServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+	return Address{Host: "127.0.0.1", Port: listener.Addr().(*net.TCPAddr).Port}
+}
+
+func TestGrpcConfigWithDefaults(t *testing.T) {
+	config := &GrpcConfig{MaxCallSendMsgSize: 1024, HealthCheckInterval: -1, RetryConfig: &GrpcRetryConfig{}}
+	cfg := grpcConfigWithDefaults(config)
+	require.Equal(t, &GrpcConfig{MaxCallSendMsgSize: 1024, HealthCheckInterval: -1, RetryConfig: &GrpcRetryConfig{}},
+		config, "the config of caller must not be changed")
+	require.Equal(t, 3, cfg.RetryConfig.MaxAttempts)
+	require.Equal(t, 30*time.Second, cfg.Timeout)
+	require.Equal(t, 10*time.Second, cfg.KeepaliveTime)
+	require.Equal(t, int32(1<<24), cfg.InitialWindowSize)
+	require.Equal(t, 64*1024*1024, cfg.MaxCallRecvMsgSize)
+	require.Equal(t, 1024, cfg.MaxCallSendMsgSize)
+	require.Equal(t, time.Duration(-1), cfg.HealthCheckInterval)
+}
+
+func TestGrpcHealthCheck(t *testing.T) {
+	up := testStartGrpcServer(t, &testPingWriteServer{status: func() proto.ServerStatus { return proto.ServerStatus_Up }})
+	down := testStartGrpcServer(t, &testPingWriteServer{status: func() proto.ServerStatus { return proto.ServerStatus_Down }})
+	unimplemented := testStartGrpcServer(t, &testMetricsWriteServer{})
+
+	var intercepted atomic.Int32
+	interceptor := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+		intercepted.Add(1)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	cfg := grpcConfigWithDefaults(&GrpcConfig{
+		Addresses:           []Address{up, down, unimplemented},
+		HealthCheckInterval: 20 * time.Millisecond,
+		UnaryInterceptors:   []grpc.UnaryClientInterceptor{interceptor},
+	})
+	lb, err := newRPCLoadBalance(cfg)
+	require.Nil(t, err)
+	defer lb.Close()
+
+	require.Eventually(t, func() bool { return lb.endpoints[1].isDown.Load() }, time.Second, 10*time.Millisecond)
+	for i := 0; i < 10; i++ {
+		require.NotEqual(t, down.String(), lb.getEndpoint().address)
+	}
+	require.False(t, lb.endpoints[0].isDown.Load())
+	require.False(t, lb.endpoints[2].isDown.Load())
+	require.Zero(t, intercepted.Load(), "the Ping RPC of health check must skip the interceptors")
+
+	// the connection state overrides the stale result of Ping RPC
+	require.True(t, lb.isAvailable(lb.endpoints[0]))
+	require.Nil(t, lb.endpoints[0].conn.Close())
+	require.False(t, lb.isAvailable(lb.endpoints[0]))
+}
+
+func TestWriteByGrpcTimeout(t *testing.T) {
+	address := testStartGrpcServer(t, &testPingWriteServer{
+		status: func() proto.ServerStatus { return proto.ServerStatus_Up },
+		delay:  200 * time.Millisecond,
+	})
+	c := testNewClient(t, &Config{
+		Addresses:  []Address{{Host: "127.0.0.1", Port: 8086}},
+		GrpcConfig: &GrpcConfig{Addresses: []Address{address}, Timeout: 50 * time.Millisecond},
+	})
+	defer c.Close()
+
+	recordBuilder, err := NewRecordBuilder("cpu")
+	require.Nil(t, err)
+	builder, err := NewWriteRequestBuilder("db0", "")
+	require.Nil(t, err)
+	request, err := builder.AddRecord(recordBuilder.AddField("v", 1.5).Build(time.Now().UnixNano())).Build()
+	require.Nil(t, err)
+
+	err = c.WriteByGrpc(context.Background(), request)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.Nil(t, c.WriteByGrpc(ctx, request))
+}
@@ -126,8 +126,13 @@ func (c *client) CreateRetentionPolicy(database string, rpConfig RpConfig, isDef
 		return err
 	}
 
+	if err = rpConfig.checkDurations(); err != nil {
+		return err
+	}
+
 	var buf strings.Builder
-	buf.WriteString(fmt.Sprintf("CREATE RETENTION POLICY %s ON \"%s\" DURATION %s REPLICATION 1", rpConfig.Name, database, rpConfig.Duration))
+	buf.WriteString(fmt.Sprintf("CREATE RETENTION POLICY %s ON %s DURATION %s REPLICATION 1",
+		QuoteIdentifier(rpConfig.Name), QuoteIdentifier(database), rpConfig.Duration))
 	if len(rpConfig.ShardGroupDuration) > 0 {
 		buf.WriteString(fmt.Sprintf(" SHARD DURATION %s", rpConfig.ShardGroupDuration))
 	}
@@ -157,8 +162,12 @@ func (c *client) UpdateRetentionPolicy(database string, rpConfig RpConfig, isDef
 		return err
 	}
 
+	if err = rpConfig.checkDurations(); err != nil {
+		return err
+	}
+
 	var buf strings.Builder
-	buf.WriteString(fmt.Sprintf("ALTER RETENTION POLICY %s ON \"%s\" ", rpConfig.Name, database))
+	buf.WriteString(fmt.Sprintf("ALTER RETENTION POLICY %s ON %s ", QuoteIdentifier(rpConfig.Name), QuoteIdentifier(database)))
 	if len(rpConfig.Duration) > 0 {
 		buf.WriteString(fmt.Sprintf(" DURATION %s", rpConfig.Duration))
 	}
@@ -218,7 +227,7 @@ func (c *client) DropRetentionPolicy(database, retentionPolicy string) error {
 		return err
 	}
 
-	cmd := fmt.Sprintf("DROP RETENTION POLICY %s ON \"%s\"", retentionPolicy, database)
+	cmd := "DROP RETENTION POLICY " + QuoteIdentifier(retentionPolicy) + " ON " + QuoteIdentifier(database)
 	queryResult, err := c.queryPost(Query{Command: cmd})
 	if err != nil {
 		return err
@@ -229,3 +238,16 @@ func (c *client) DropRetentionPolicy(database, retentionPolicy string) error {
 	}
 	return nil
 }
+
+// checkDurations check the duration literals of config before rendering them into command, empty duration is skipped
+func (rpConfig RpConfig) checkDurations() error {
+	for _, duration := range []string{rpConfig.Duration, rpConfig.ShardGroupDuration, rpConfig.IndexDuration} {
+		if len(duration) == 0 {
+			continue
+		}
+		if err := checkDuration(duration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -24,4 +24,6 @@ type Series struct {
 	Tags    map[string]string `json:"tags,omitempty" msgpack:"tags,omitempty"`
 	Columns []string          `json:"columns,omitempty" msgpack:"columns,omitempty"`
 	Values  SeriesValues      `json:"values,omitempty" msgpack:"values,omitempty"`
+	// Partial reports whether the rest rows of series are returned in the next chunk of chunked query
+	Partial bool `json:"partial,omitempty" msgpack:"partial,omitempty"`
 }
@@ -77,6 +77,7 @@ func TestServerCheck(t *testing.T) {
 			},
 		},
 		prevIdx: atomic.Int32{},
+		metrics: newMetricsProvider(nil, nil),
 		logger:  slog.Default(),
 	}
 	cli.prevIdx.Store(-1)
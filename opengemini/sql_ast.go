@@ -0,0 +1,158 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import "time"
+
+// sqlStatement the statement parsed by client-side parser
+type sqlStatement interface {
+	statementType() StatementType
+}
+
+// sqlSelectStatement the AST of `SELECT` statement
+type sqlSelectStatement struct {
+	fields     []*sqlField
+	into       *sqlTarget
+	sources    []sqlSource
+	condition  sqlExpr
+	dimensions []sqlExpr
+	fill       FillOption
+	sortFields []*sqlSortField
+	limit      int64
+	offset     int64
+	sLimit     int64
+	sOffset    int64
+	location   string
+}
+
+// statementType `SELECT ... INTO` writes data, it is routed as command
+func (s *sqlSelectStatement) statementType() StatementType {
+	if s.into != nil {
+		return StatementTypeCommand
+	}
+	return StatementTypeQuery
+}
+
+// sqlExplainStatement the AST of `EXPLAIN [ANALYZE] SELECT`
+type sqlExplainStatement struct {
+	analyze   bool
+	statement *sqlSelectStatement
+}
+
+func (s *sqlExplainStatement) statementType() StatementType {
+	return StatementTypeQuery
+}
+
+// sqlRawStatement the statement which is only validated lexically, such as `SHOW` and `CREATE`, keywords are the
+// leading keywords of statement in upper case
+type sqlRawStatement struct {
+	kind     StatementType
+	keywords []string
+	text     string
+}
+
+func (s *sqlRawStatement) statementType() StatementType {
+	return s.kind
+}
+
+type sqlField struct {
+	expr  sqlExpr
+	alias string
+}
+
+// sqlTarget the target of INTO clause, measurement is IntoMeasurementBackReference for `:MEASUREMENT`
+type sqlTarget struct {
+	database        string
+	retentionPolicy string
+	measurement     string
+}
+
+type sqlSource interface {
+	sourceNode()
+}
+
+// sqlMeasurement the measurement source, regex is set for `/pattern/`
+type sqlMeasurement struct {
+	database        string
+	retentionPolicy string
+	name            string
+	regex           *string
+}
+
+func (*sqlMeasurement) sourceNode() {}
+
+type sqlSubQuery struct {
+	statement *sqlSelectStatement
+}
+
+func (*sqlSubQuery) sourceNode() {}
+
+type sqlSortField struct {
+	name      string
+	ascending bool
+}
+
+type sqlExpr interface {
+	exprNode()
+}
+
+// sqlVarRef the reference of field or tag, such as `"value"::float`
+type sqlVarRef struct {
+	name     string
+	dataType string
+}
+
+// sqlCall the function call, such as `mean("value")`, name is kept as written
+type sqlCall struct {
+	name string
+	args []sqlExpr
+}
+
+// sqlBinaryExpr the binary expression, such as `"value" > 1` or `a AND b`, op is in upper case
+type sqlBinaryExpr struct {
+	op  string
+	lhs sqlExpr
+	rhs sqlExpr
+}
+
+type sqlParenExpr struct {
+	expr sqlExpr
+}
+
+type sqlWildcard struct {
+	// dataType `field` or `tag` for `*::field`
+	dataType string
+}
+
+type sqlStringLiteral struct{ value string }
+type sqlIntegerLiteral struct{ value int64 }
+type sqlNumberLiteral struct{ value float64 }
+type sqlDurationLiteral struct{ value time.Duration }
+type sqlBooleanLiteral struct{ value bool }
+type sqlRegexLiteral struct{ pattern string }
+type sqlBoundParameter struct{ name string }
+
+func (*sqlVarRef) exprNode()          {}
+func (*sqlCall) exprNode()            {}
+func (*sqlBinaryExpr) exprNode()      {}
+func (*sqlParenExpr) exprNode()       {}
+func (*sqlWildcard) exprNode()        {}
+func (*sqlStringLiteral) exprNode()   {}
+func (*sqlIntegerLiteral) exprNode()  {}
+func (*sqlNumberLiteral) exprNode()   {}
+func (*sqlDurationLiteral) exprNode() {}
+func (*sqlBooleanLiteral) exprNode()  {}
+func (*sqlRegexLiteral) exprNode()    {}
+func (*sqlBoundParameter) exprNode()  {}
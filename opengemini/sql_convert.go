@@ -0,0 +1,432 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ValidateCommand parse the command on client side and report the syntax error with position, the command may
+// contain multiple statements separated by `;`. `SELECT` statements are checked completely including the arguments
+// of functions in catalogue, the other statements are checked lexically
+func ValidateCommand(command string) error {
+	statements, err := parseSQL(command)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		switch s := statement.(type) {
+		case *sqlSelectStatement:
+			err = validateSelect(s)
+		case *sqlExplainStatement:
+			err = validateSelect(s.statement)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseQuery parse a single `SELECT` statement into QueryBuilder, so that the query can be modified and rendered
+// again, such as adding a condition to the query written by user. The constructs which QueryBuilder can't render
+// are rejected, such as type casts, bound parameters and sources with database or retention policy
+func ParseQuery(command string) (*QueryBuilder, error) {
+	statements, err := parseSQL(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(statements) != 1 {
+		return nil, fmt.Errorf("expect a single SELECT statement, got %d statements", len(statements))
+	}
+	statement, ok := statements[0].(*sqlSelectStatement)
+	if !ok {
+		return nil, errors.New("expect a SELECT statement")
+	}
+	if err = validateSelect(statement); err != nil {
+		return nil, err
+	}
+	return convertSelect(statement)
+}
+
+// classifyStatements return the statement type used to route the statements, a batch is routed as command if any
+// statement changes data or schema, so that it is sent by POST. INSERT can't be mixed with other statements because
+// it is written by line protocol instead of query
+func classifyStatements(statements []sqlStatement) (StatementType, error) {
+	if len(statements) == 1 {
+		return statements[0].statementType(), nil
+	}
+	stmtType := StatementTypeQuery
+	for _, statement := range statements {
+		switch statement.statementType() {
+		case StatementTypeInsert:
+			return StatementTypeUnknown, errors.New("INSERT statement can't be mixed with other statements")
+		case StatementTypeCommand:
+			stmtType = StatementTypeCommand
+		}
+	}
+	return stmtType, nil
+}
+
+// validateSelect check the regexes and the functions which can be converted to FunctionExpression
+func validateSelect(statement *sqlSelectStatement) error {
+	for _, field := range statement.fields {
+		expr, err := convertExpr(field.expr)
+		if err != nil {
+			// the expressions which QueryBuilder doesn't support are left to server
+			continue
+		}
+		if err = validateExpression(expr); err != nil {
+			return err
+		}
+	}
+	for _, source := range statement.sources {
+		switch s := source.(type) {
+		case *sqlMeasurement:
+			if s.regex == nil {
+				continue
+			}
+			if _, err := regexp.Compile(*s.regex); err != nil {
+				return fmt.Errorf("from: invalid regex /%s/: %w", *s.regex, err)
+			}
+		case *sqlSubQuery:
+			if err := validateSelect(s.statement); err != nil {
+				return fmt.Errorf("subquery: %w", err)
+			}
+		}
+	}
+	if statement.fill != "" && len(statement.dimensions) == 0 {
+		return errors.New("fill requires group by")
+	}
+	return validateRegexes(statement.condition)
+}
+
+func validateRegexes(expr sqlExpr) error {
+	switch e := expr.(type) {
+	case *sqlBinaryExpr:
+		if err := validateRegexes(e.lhs); err != nil {
+			return err
+		}
+		return validateRegexes(e.rhs)
+	case *sqlParenExpr:
+		return validateRegexes(e.expr)
+	case *sqlRegexLiteral:
+		if _, err := regexp.Compile(e.pattern); err != nil {
+			return fmt.Errorf("where: invalid regex /%s/: %w", e.pattern, err)
+		}
+	}
+	return nil
+}
+
+func convertSelect(statement *sqlSelectStatement) (*QueryBuilder, error) {
+	builder := CreateQueryBuilder()
+
+	var fields []Expression
+	for _, field := range statement.fields {
+		expr, err := convertExpr(field.expr)
+		if err != nil {
+			return nil, err
+		}
+		if field.alias != "" {
+			expr = NewAsExpression(field.alias, expr)
+		}
+		fields = append(fields, expr)
+	}
+	// SELECT * is rendered by default
+	if len(fields) != 1 || !isStarExpression(fields[0]) {
+		builder.Select(fields...)
+	}
+
+	if statement.into != nil {
+		builder.Into(statement.into.database, statement.into.retentionPolicy, statement.into.measurement)
+	}
+
+	if err := convertSources(builder, statement.sources); err != nil {
+		return nil, err
+	}
+
+	if statement.condition != nil {
+		condition, err := convertCondition(statement.condition)
+		if err != nil {
+			return nil, err
+		}
+		builder.Where(condition)
+	}
+
+	if err := convertDimensions(builder, statement.dimensions); err != nil {
+		return nil, err
+	}
+
+	if statement.fill != "" {
+		builder.Fill(statement.fill)
+	}
+
+	switch {
+	case len(statement.sortFields) > 1:
+		return nil, errors.New("QueryBuilder only supports ORDER BY time")
+	case len(statement.sortFields) == 1:
+		field := statement.sortFields[0]
+		if !strings.EqualFold(field.name, "time") {
+			return nil, errors.New("QueryBuilder only supports ORDER BY time")
+		}
+		if field.ascending {
+			builder.OrderBy(Asc)
+		} else {
+			builder.OrderBy(Desc)
+		}
+	}
+
+	builder.Limit(statement.limit).Offset(statement.offset).SLimit(statement.sLimit).SOffset(statement.sOffset)
+
+	if statement.location != "" {
+		location, err := time.LoadLocation(statement.location)
+		if err != nil {
+			return nil, err
+		}
+		builder.Timezone(location)
+	}
+	return builder, nil
+}
+
+func isStarExpression(expr Expression) bool {
+	_, ok := expr.(*StarExpression)
+	return ok
+}
+
+func convertSources(builder *QueryBuilder, sources []sqlSource) error {
+	var measurements, patterns []string
+	var subQueries []*sqlSubQuery
+	for _, source := range sources {
+		switch s := source.(type) {
+		case *sqlMeasurement:
+			if s.database != "" || s.retentionPolicy != "" {
+				return errors.New("QueryBuilder doesn't support source with database or retention policy")
+			}
+			if s.regex != nil {
+				patterns = append(patterns, *s.regex)
+			} else {
+				measurements = append(measurements, s.name)
+			}
+		case *sqlSubQuery:
+			subQueries = append(subQueries, s)
+		}
+	}
+
+	switch {
+	case len(measurements) == len(sources):
+		builder.From(measurements...)
+	case len(patterns) == len(sources):
+		builder.FromRegex(patterns...)
+	case len(subQueries) == 1 && len(sources) == 1:
+		subQuery, err := convertSelect(subQueries[0].statement)
+		if err != nil {
+			return fmt.Errorf("subquery: %w", err)
+		}
+		builder.FromSubQuery(subQuery)
+	default:
+		return errors.New("QueryBuilder doesn't support mixed sources or multiple subqueries")
+	}
+	return nil
+}
+
+func convertDimensions(builder *QueryBuilder, dimensions []sqlExpr) error {
+	var groupBy []Expression
+	for _, dimension := range dimensions {
+		if call, ok := dimension.(*sqlCall); ok && strings.EqualFold(call.name, "time") {
+			interval, offset, err := convertGroupByTime(call)
+			if err != nil {
+				return err
+			}
+			builder.GroupByTime(interval, offset)
+			continue
+		}
+		expr, err := convertExpr(dimension)
+		if err != nil {
+			return err
+		}
+		groupBy = append(groupBy, expr)
+	}
+	if len(groupBy) > 0 {
+		builder.GroupBy(groupBy...)
+	}
+	return nil
+}
+
+func convertGroupByTime(call *sqlCall) (time.Duration, time.Duration, error) {
+	if len(call.args) == 0 || len(call.args) > 2 {
+		return 0, 0, errors.New("group by time requires an interval and an optional offset")
+	}
+	var durations [2]time.Duration
+	for i, arg := range call.args {
+		duration, ok := arg.(*sqlDurationLiteral)
+		if !ok {
+			return 0, 0, errors.New("group by time requires duration arguments")
+		}
+		durations[i] = duration.value
+	}
+	return durations[0], durations[1], nil
+}
+
+func convertExpr(expr sqlExpr) (Expression, error) {
+	switch e := expr.(type) {
+	case *sqlVarRef:
+		if e.dataType != "" {
+			return nil, fmt.Errorf("QueryBuilder doesn't support type cast %s::%s", e.name, e.dataType)
+		}
+		return NewFieldExpression(e.name), nil
+	case *sqlWildcard:
+		if e.dataType != "" {
+			return nil, fmt.Errorf("QueryBuilder doesn't support type cast *::%s", e.dataType)
+		}
+		return NewStarExpression(), nil
+	case *sqlCall:
+		var args []Expression
+		for _, arg := range e.args {
+			converted, err := convertExpr(arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, converted)
+		}
+		return NewFunctionExpression(FunctionEnum(strings.ToUpper(e.name)), args...), nil
+	case *sqlBinaryExpr:
+		lhs, err := convertExpr(e.lhs)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := convertExpr(e.rhs)
+		if err != nil {
+			return nil, err
+		}
+		return NewArithmeticExpression(ArithmeticOperator(e.op), lhs, rhs), nil
+	case *sqlParenExpr:
+		return convertExpr(e.expr)
+	case *sqlIntegerLiteral:
+		return NewConstantExpression(e.value), nil
+	case *sqlNumberLiteral:
+		return NewConstantExpression(e.value), nil
+	case *sqlBooleanLiteral:
+		return NewConstantExpression(e.value), nil
+	case *sqlDurationLiteral:
+		return NewDurationExpression(e.value), nil
+	default:
+		return nil, fmt.Errorf("QueryBuilder doesn't support expression %T in fields", expr)
+	}
+}
+
+func convertCondition(expr sqlExpr) (Condition, error) {
+	switch e := expr.(type) {
+	case *sqlParenExpr:
+		return convertCondition(e.expr)
+	case *sqlBinaryExpr:
+		if e.op == "AND" || e.op == "OR" {
+			var conditions []Condition
+			for _, operand := range flattenLogical(e.op, e) {
+				condition, err := convertCondition(operand)
+				if err != nil {
+					return nil, err
+				}
+				conditions = append(conditions, condition)
+			}
+			return NewCompositeCondition(LogicalOperator(e.op), conditions...), nil
+		}
+		return convertComparison(e)
+	default:
+		return nil, fmt.Errorf("QueryBuilder doesn't support condition %T", expr)
+	}
+}
+
+// flattenLogical flatten the chain of the same logical operator, such as `a AND b AND c`
+func flattenLogical(op string, expr sqlExpr) []sqlExpr {
+	binary, ok := expr.(*sqlBinaryExpr)
+	if !ok || binary.op != op {
+		return []sqlExpr{expr}
+	}
+	return append(flattenLogical(op, binary.lhs), flattenLogical(op, binary.rhs)...)
+}
+
+func convertComparison(expr *sqlBinaryExpr) (Condition, error) {
+	var operator ComparisonOperator
+	switch expr.op {
+	case "=", "<", "<=", ">", ">=", "=~", "!~":
+		operator = ComparisonOperator(expr.op)
+	case "<>", "!=":
+		operator = NotEquals
+	default:
+		return nil, fmt.Errorf("QueryBuilder doesn't support operator %s in condition", expr.op)
+	}
+
+	ref, ok := expr.lhs.(*sqlVarRef)
+	if !ok || ref.dataType != "" {
+		return nil, errors.New("QueryBuilder requires a column on the left side of condition")
+	}
+	if strings.EqualFold(ref.name, "time") {
+		if condition, ok := convertTimeComparison(operator, expr.rhs); ok {
+			return condition, nil
+		}
+	}
+
+	switch value := expr.rhs.(type) {
+	case *sqlStringLiteral:
+		return NewComparisonCondition(ref.name, operator, value.value), nil
+	case *sqlIntegerLiteral:
+		return NewComparisonCondition(ref.name, operator, value.value), nil
+	case *sqlNumberLiteral:
+		return NewComparisonCondition(ref.name, operator, value.value), nil
+	case *sqlBooleanLiteral:
+		return NewComparisonCondition(ref.name, operator, value.value), nil
+	case *sqlRegexLiteral:
+		return NewComparisonCondition(ref.name, operator, value.pattern), nil
+	default:
+		return nil, fmt.Errorf("QueryBuilder doesn't support value %T in condition", expr.rhs)
+	}
+}
+
+// convertTimeComparison convert `time op 'RFC3339'` and `time op now() - duration`
+func convertTimeComparison(operator ComparisonOperator, rhs sqlExpr) (Condition, bool) {
+	switch value := rhs.(type) {
+	case *sqlStringLiteral:
+		t, err := time.Parse(time.RFC3339Nano, value.value)
+		if err != nil {
+			return nil, false
+		}
+		return NewTimeCondition(operator, t), true
+	case *sqlCall:
+		if isNowCall(value) {
+			return NewRelativeTimeCondition(operator, 0), true
+		}
+	case *sqlBinaryExpr:
+		duration, ok := value.rhs.(*sqlDurationLiteral)
+		if !ok || !isNowCall(value.lhs) {
+			return nil, false
+		}
+		switch value.op {
+		case "-":
+			return NewRelativeTimeCondition(operator, duration.value), true
+		case "+":
+			return NewRelativeTimeCondition(operator, -duration.value), true
+		}
+	}
+	return nil, false
+}
+
+func isNowCall(expr sqlExpr) bool {
+	call, ok := expr.(*sqlCall)
+	return ok && strings.EqualFold(call.name, "now") && len(call.args) == 0
+}
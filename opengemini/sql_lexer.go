@@ -0,0 +1,378 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sqlToken the token kind of InfluxQL/openGemini SQL
+type sqlToken int
+
+const (
+	sqlEOF sqlToken = iota
+	sqlIdent
+	sqlString
+	sqlInteger
+	sqlNumber
+	sqlDuration
+	sqlRegex
+	sqlBoundParam
+	sqlOperator
+	sqlLeftParen
+	sqlRightParen
+	sqlComma
+	sqlDot
+	sqlDoubleColon
+	sqlColon
+	sqlSemicolon
+)
+
+func (t sqlToken) String() string {
+	switch t {
+	case sqlEOF:
+		return "EOF"
+	case sqlIdent:
+		return "identifier"
+	case sqlString:
+		return "string"
+	case sqlInteger:
+		return "integer"
+	case sqlNumber:
+		return "number"
+	case sqlDuration:
+		return "duration"
+	case sqlRegex:
+		return "regex"
+	case sqlBoundParam:
+		return "bound parameter"
+	case sqlOperator:
+		return "operator"
+	case sqlLeftParen:
+		return "("
+	case sqlRightParen:
+		return ")"
+	case sqlComma:
+		return ","
+	case sqlDot:
+		return "."
+	case sqlDoubleColon:
+		return "::"
+	case sqlColon:
+		return ":"
+	case sqlSemicolon:
+		return ";"
+	default:
+		return "unknown"
+	}
+}
+
+// sqlLexeme a token with its position and literal, the literal of string and quoted identifier is unquoted
+type sqlLexeme struct {
+	tok sqlToken
+	pos int
+	lit string
+	// quoted whether the identifier is quoted, a quoted identifier is never a keyword
+	quoted bool
+}
+
+// isKeyword check whether the lexeme is the unquoted keyword, keywords are case-insensitive
+func (l sqlLexeme) isKeyword(keyword string) bool {
+	return l.tok == sqlIdent && !l.quoted && strings.EqualFold(l.lit, keyword)
+}
+
+func (l sqlLexeme) String() string {
+	switch l.tok {
+	case sqlEOF:
+		return "EOF"
+	case sqlIdent:
+		if l.quoted {
+			return QuoteIdentifier(l.lit)
+		}
+		return l.lit
+	case sqlString:
+		return QuoteString(l.lit)
+	case sqlRegex:
+		return QuoteRegex(l.lit)
+	case sqlBoundParam:
+		return "$" + l.lit
+	default:
+		return l.lit
+	}
+}
+
+// sqlSyntaxError the syntax error found by client-side parser
+type sqlSyntaxError struct {
+	pos     int
+	message string
+}
+
+func (e *sqlSyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.pos+1, e.message)
+}
+
+// sqlLexer split the command into tokens, regex literal is ambiguous with division operator, so it is only scanned
+// when parser asks for it by scanRegex
+type sqlLexer struct {
+	input string
+	pos   int
+}
+
+func newSQLLexer(input string) *sqlLexer {
+	return &sqlLexer{input: input}
+}
+
+func (l *sqlLexer) peekByte(offset int) byte {
+	if l.pos+offset >= len(l.input) {
+		return 0
+	}
+	return l.input[l.pos+offset]
+}
+
+// skipSpaceAndComments skip white spaces, `-- comment` and `/* comment */`
+func (l *sqlLexer) skipSpaceAndComments() error {
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		switch {
+		case unicode.IsSpace(r):
+			l.pos += size
+		case r == '-' && l.peekByte(1) == '-':
+			end := strings.IndexByte(l.input[l.pos:], '\n')
+			if end < 0 {
+				l.pos = len(l.input)
+			} else {
+				l.pos += end + 1
+			}
+		case r == '/' && l.peekByte(1) == '*':
+			end := strings.Index(l.input[l.pos+2:], "*/")
+			if end < 0 {
+				return &sqlSyntaxError{pos: l.pos, message: "unterminated comment"}
+			}
+			l.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *sqlLexer) scan() (sqlLexeme, error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return sqlLexeme{}, err
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return sqlLexeme{tok: sqlEOF, pos: start}, nil
+	}
+
+	r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+	switch {
+	case isIdentStart(r):
+		return l.scanIdent(), nil
+	case r == '"':
+		lit, err := l.scanQuoted('"')
+		return sqlLexeme{tok: sqlIdent, pos: start, lit: lit, quoted: true}, err
+	case r == '\'':
+		lit, err := l.scanQuoted('\'')
+		return sqlLexeme{tok: sqlString, pos: start, lit: lit}, err
+	case isDigit(r) || (r == '.' && isDigit(rune(l.peekByte(1)))):
+		return l.scanNumber()
+	case r == '$':
+		l.pos++
+		ident := l.scanIdent()
+		if ident.lit == "" {
+			return sqlLexeme{}, &sqlSyntaxError{pos: start, message: "empty bound parameter"}
+		}
+		return sqlLexeme{tok: sqlBoundParam, pos: start, lit: ident.lit}, nil
+	}
+
+	l.pos += size
+	two := string(r) + string(l.peekByte(0))
+	switch two {
+	case "<>", "!=", "<=", ">=", "=~", "!~":
+		l.pos++
+		return sqlLexeme{tok: sqlOperator, pos: start, lit: two}, nil
+	case "::":
+		l.pos++
+		return sqlLexeme{tok: sqlDoubleColon, pos: start, lit: two}, nil
+	}
+	switch r {
+	case '+', '-', '*', '/', '%', '=', '<', '>', '&', '|', '^':
+		return sqlLexeme{tok: sqlOperator, pos: start, lit: string(r)}, nil
+	case '(':
+		return sqlLexeme{tok: sqlLeftParen, pos: start, lit: "("}, nil
+	case ')':
+		return sqlLexeme{tok: sqlRightParen, pos: start, lit: ")"}, nil
+	case ',':
+		return sqlLexeme{tok: sqlComma, pos: start, lit: ","}, nil
+	case '.':
+		return sqlLexeme{tok: sqlDot, pos: start, lit: "."}, nil
+	case ':':
+		return sqlLexeme{tok: sqlColon, pos: start, lit: ":"}, nil
+	case ';':
+		return sqlLexeme{tok: sqlSemicolon, pos: start, lit: ";"}, nil
+	}
+	return sqlLexeme{}, &sqlSyntaxError{pos: start, message: fmt.Sprintf("unexpected character %q", r)}
+}
+
+func (l *sqlLexer) scanIdent() sqlLexeme {
+	start := l.pos
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !isIdentStart(r) && !isDigit(r) {
+			break
+		}
+		l.pos += size
+	}
+	return sqlLexeme{tok: sqlIdent, pos: start, lit: l.input[start:l.pos]}
+}
+
+// scanQuoted scan the string or quoted identifier, the escape sequences are the same as server
+func (l *sqlLexer) scanQuoted(quote byte) (string, error) {
+	start := l.pos
+	l.pos++
+	var buf strings.Builder
+	for l.pos < len(l.input) {
+		ch := l.input[l.pos]
+		switch ch {
+		case quote:
+			l.pos++
+			return buf.String(), nil
+		case '\n':
+			return "", &sqlSyntaxError{pos: l.pos, message: "newline in quoted string"}
+		case '\\':
+			switch l.peekByte(1) {
+			case 'n':
+				buf.WriteByte('\n')
+			case '\\', '"', '\'':
+				buf.WriteByte(l.peekByte(1))
+			default:
+				return "", &sqlSyntaxError{pos: l.pos, message: "bad escape sequence"}
+			}
+			l.pos += 2
+		default:
+			buf.WriteByte(ch)
+			l.pos++
+		}
+	}
+	return "", &sqlSyntaxError{pos: start, message: "unterminated quoted string"}
+}
+
+// durationUnits the units of duration literal, the longer unit must be checked first
+var durationUnits = []string{"ns", "ms", "µ", "u", "s", "m", "h", "d", "w"}
+
+func (l *sqlLexer) scanNumber() (sqlLexeme, error) {
+	start := l.pos
+	l.skipDigits()
+	isFloat := false
+	if l.peekByte(0) == '.' && isDigit(rune(l.peekByte(1))) {
+		isFloat = true
+		l.pos++
+		l.skipDigits()
+	}
+	if isFloat {
+		return sqlLexeme{tok: sqlNumber, pos: start, lit: l.input[start:l.pos]}, nil
+	}
+
+	// duration literal such as 1h30m, each segment is an integer followed by a unit
+	isDuration := false
+	for {
+		unit := l.durationUnit()
+		if unit == "" {
+			break
+		}
+		isDuration = true
+		l.pos += len(unit)
+		if !isDigit(rune(l.peekByte(0))) {
+			break
+		}
+		l.skipDigits()
+		if l.durationUnit() == "" {
+			return sqlLexeme{}, &sqlSyntaxError{pos: start, message: "invalid duration " + l.input[start:l.pos]}
+		}
+	}
+	if r, _ := utf8.DecodeRuneInString(l.input[l.pos:]); l.pos < len(l.input) && isIdentStart(r) {
+		return sqlLexeme{}, &sqlSyntaxError{pos: start, message: "invalid number " + l.input[start:l.pos+1]}
+	}
+	if isDuration {
+		return sqlLexeme{tok: sqlDuration, pos: start, lit: l.input[start:l.pos]}, nil
+	}
+	return sqlLexeme{tok: sqlInteger, pos: start, lit: l.input[start:l.pos]}, nil
+}
+
+// durationUnit return the duration unit at current position, the unit must not be followed by identifier character
+func (l *sqlLexer) durationUnit() string {
+	rest := l.input[l.pos:]
+	for _, unit := range durationUnits {
+		if !strings.HasPrefix(rest, unit) {
+			continue
+		}
+		next, _ := utf8.DecodeRuneInString(rest[len(unit):])
+		if len(rest) > len(unit) && isIdentStart(next) {
+			continue
+		}
+		return unit
+	}
+	return ""
+}
+
+func (l *sqlLexer) skipDigits() {
+	for l.pos < len(l.input) && isDigit(rune(l.input[l.pos])) {
+		l.pos++
+	}
+}
+
+// scanRegex scan the regex literal at current position, `\/` is unescaped as `/`, the other escape sequences are
+// kept as they are
+func (l *sqlLexer) scanRegex() (sqlLexeme, error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return sqlLexeme{}, err
+	}
+	start := l.pos
+	if l.peekByte(0) != '/' {
+		return sqlLexeme{}, &sqlSyntaxError{pos: start, message: "expect regex"}
+	}
+	l.pos++
+	var buf strings.Builder
+	for l.pos < len(l.input) {
+		ch := l.input[l.pos]
+		switch {
+		case ch == '/':
+			l.pos++
+			return sqlLexeme{tok: sqlRegex, pos: start, lit: buf.String()}, nil
+		case ch == '\\' && l.peekByte(1) == '/':
+			buf.WriteByte('/')
+			l.pos += 2
+		case ch == '\\' && l.pos+1 < len(l.input):
+			buf.WriteString(l.input[l.pos : l.pos+2])
+			l.pos += 2
+		default:
+			buf.WriteByte(ch)
+			l.pos++
+		}
+	}
+	return sqlLexeme{}, &sqlSyntaxError{pos: start, message: "unterminated regex"}
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,873 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlParser parse InfluxQL/openGemini SQL into AST, `SELECT` and `EXPLAIN` are parsed completely, the other
+// statements are validated lexically because their syntax differs between server versions
+type sqlParser struct {
+	lexer *sqlLexer
+	// buf the lexemes pushed back by unscan, the last one is scanned first
+	buf []sqlLexeme
+}
+
+func newSQLParser(command string) *sqlParser {
+	return &sqlParser{lexer: newSQLLexer(command)}
+}
+
+// parseSQL parse the command which may contain multiple statements separated by `;`
+func parseSQL(command string) ([]sqlStatement, error) {
+	p := newSQLParser(command)
+	var statements []sqlStatement
+	for {
+		tok, err := p.scan()
+		if err != nil {
+			return nil, err
+		}
+		if tok.tok == sqlEOF {
+			break
+		}
+		if tok.tok == sqlSemicolon {
+			continue
+		}
+		p.unscan(tok)
+
+		statement, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+
+		tok, err = p.scan()
+		if err != nil {
+			return nil, err
+		}
+		if tok.tok != sqlSemicolon && tok.tok != sqlEOF {
+			return nil, p.unexpected(tok, "; or EOF")
+		}
+		if tok.tok == sqlEOF {
+			break
+		}
+	}
+	if len(statements) == 0 {
+		return nil, ErrEmptyCommand
+	}
+	return statements, nil
+}
+
+func (p *sqlParser) scan() (sqlLexeme, error) {
+	if n := len(p.buf); n > 0 {
+		tok := p.buf[n-1]
+		p.buf = p.buf[:n-1]
+		return tok, nil
+	}
+	return p.lexer.scan()
+}
+
+func (p *sqlParser) unscan(tok sqlLexeme) {
+	p.buf = append(p.buf, tok)
+}
+
+// scanRegexIfPresent scan regex literal if the next lexeme starts with `/`
+func (p *sqlParser) scanRegexIfPresent() (sqlLexeme, bool, error) {
+	if n := len(p.buf); n > 0 {
+		next := p.buf[n-1]
+		if next.tok != sqlOperator || next.lit != "/" {
+			return sqlLexeme{}, false, nil
+		}
+		// the lexemes after next are scanned again from the position of next
+		p.lexer.pos = next.pos
+		p.buf = nil
+	} else {
+		if err := p.lexer.skipSpaceAndComments(); err != nil {
+			return sqlLexeme{}, false, err
+		}
+		if p.lexer.peekByte(0) != '/' {
+			return sqlLexeme{}, false, nil
+		}
+	}
+	tok, err := p.lexer.scanRegex()
+	return tok, err == nil, err
+}
+
+func (p *sqlParser) unexpected(tok sqlLexeme, expected string) error {
+	return &sqlSyntaxError{pos: tok.pos, message: fmt.Sprintf("unexpected %s, expect %s", tok, expected)}
+}
+
+// scanKeyword scan the next lexeme and return whether it is the keyword, the lexeme is pushed back if not
+func (p *sqlParser) scanKeyword(keyword string) (bool, error) {
+	tok, err := p.scan()
+	if err != nil {
+		return false, err
+	}
+	if tok.isKeyword(keyword) {
+		return true, nil
+	}
+	p.unscan(tok)
+	return false, nil
+}
+
+func (p *sqlParser) expectKeyword(keyword string) error {
+	tok, err := p.scan()
+	if err != nil {
+		return err
+	}
+	if !tok.isKeyword(keyword) {
+		return p.unexpected(tok, keyword)
+	}
+	return nil
+}
+
+func (p *sqlParser) expect(token sqlToken) (sqlLexeme, error) {
+	tok, err := p.scan()
+	if err != nil {
+		return tok, err
+	}
+	if tok.tok != token {
+		return tok, p.unexpected(tok, token.String())
+	}
+	return tok, nil
+}
+
+func (p *sqlParser) parseStatement() (sqlStatement, error) {
+	tok, err := p.scan()
+	if err != nil {
+		return nil, err
+	}
+	if tok.tok != sqlIdent || tok.quoted {
+		return nil, p.unexpected(tok, "statement")
+	}
+	keyword := strings.ToUpper(tok.lit)
+	switch {
+	case keyword == "SELECT":
+		return p.parseSelect()
+	case keyword == "EXPLAIN":
+		return p.parseExplain()
+	case isInsertKeyword(keyword):
+		// the line protocol after INSERT is not InfluxQL, it is parsed by parseInsertStatement
+		text := p.lexer.input[tok.pos:]
+		p.lexer.pos = len(p.lexer.input)
+		return &sqlRawStatement{kind: StatementTypeInsert, keywords: []string{keyword}, text: text}, nil
+	case isQueryKeyword(keyword):
+		return p.parseRaw(tok, StatementTypeQuery)
+	case isCommandKeyword(keyword):
+		return p.parseRaw(tok, StatementTypeCommand)
+	default:
+		return nil, &sqlSyntaxError{pos: tok.pos, message: "unsupported statement " + tok.lit}
+	}
+}
+
+// parseRaw consume the lexemes of statement until `;` or EOF, the parentheses must be balanced
+func (p *sqlParser) parseRaw(first sqlLexeme, kind StatementType) (*sqlRawStatement, error) {
+	statement := &sqlRawStatement{kind: kind, keywords: []string{strings.ToUpper(first.lit)}}
+	var depth int
+	var keywordsDone bool
+	var end = len(p.lexer.input)
+	prev := first
+	for {
+		if prev.tok == sqlOperator && (prev.lit == "=~" || prev.lit == "!~") || prev.isKeyword("FROM") ||
+			(prev.tok == sqlComma && depth == 0) {
+			regex, ok, err := p.scanRegexIfPresent()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				prev = regex
+				keywordsDone = true
+				continue
+			}
+		}
+		tok, err := p.scan()
+		if err != nil {
+			return nil, err
+		}
+		switch tok.tok {
+		case sqlEOF:
+			if depth != 0 {
+				return nil, &sqlSyntaxError{pos: tok.pos, message: "unbalanced parentheses"}
+			}
+			statement.text = strings.TrimSpace(p.lexer.input[first.pos:end])
+			p.unscan(tok)
+			return statement, nil
+		case sqlSemicolon:
+			if depth != 0 {
+				return nil, &sqlSyntaxError{pos: tok.pos, message: "unbalanced parentheses"}
+			}
+			statement.text = strings.TrimSpace(p.lexer.input[first.pos:tok.pos])
+			p.unscan(tok)
+			return statement, nil
+		case sqlLeftParen:
+			depth++
+		case sqlRightParen:
+			depth--
+			if depth < 0 {
+				return nil, &sqlSyntaxError{pos: tok.pos, message: "unbalanced parentheses"}
+			}
+		}
+		if !keywordsDone && tok.tok == sqlIdent && !tok.quoted && len(statement.keywords) < 3 {
+			statement.keywords = append(statement.keywords, strings.ToUpper(tok.lit))
+		} else {
+			keywordsDone = true
+		}
+		prev = tok
+	}
+}
+
+func (p *sqlParser) parseExplain() (*sqlExplainStatement, error) {
+	statement := &sqlExplainStatement{}
+	analyze, err := p.scanKeyword("ANALYZE")
+	if err != nil {
+		return nil, err
+	}
+	statement.analyze = analyze
+	if err = p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+	statement.statement, err = p.parseSelect()
+	if err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// parseSelect parse the `SELECT` statement, the keyword SELECT has been consumed
+func (p *sqlParser) parseSelect() (*sqlSelectStatement, error) {
+	var err error
+	statement := &sqlSelectStatement{}
+	if statement.fields, err = p.parseFields(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.scanKeyword("INTO"); err != nil {
+		return nil, err
+	} else if ok {
+		if statement.into, err = p.parseTarget(); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	if statement.sources, err = p.parseSources(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.scanKeyword("WHERE"); err != nil {
+		return nil, err
+	} else if ok {
+		if statement.condition, err = p.parseExpr(0); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.scanKeyword("GROUP"); err != nil {
+		return nil, err
+	} else if ok {
+		if err = p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		if statement.dimensions, err = p.parseDimensions(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.scanKeyword("FILL"); err != nil {
+		return nil, err
+	} else if ok {
+		if statement.fill, err = p.parseFill(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.scanKeyword("ORDER"); err != nil {
+		return nil, err
+	} else if ok {
+		if err = p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		if statement.sortFields, err = p.parseSortFields(); err != nil {
+			return nil, err
+		}
+	}
+	for _, clause := range []struct {
+		keyword string
+		value   *int64
+	}{
+		{"LIMIT", &statement.limit},
+		{"OFFSET", &statement.offset},
+		{"SLIMIT", &statement.sLimit},
+		{"SOFFSET", &statement.sOffset},
+	} {
+		if ok, err := p.scanKeyword(clause.keyword); err != nil {
+			return nil, err
+		} else if ok {
+			if *clause.value, err = p.parseInteger(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if ok, err := p.scanKeyword("TZ"); err != nil {
+		return nil, err
+	} else if ok {
+		if statement.location, err = p.parseTimezone(); err != nil {
+			return nil, err
+		}
+	}
+	return statement, nil
+}
+
+func (p *sqlParser) parseFields() ([]*sqlField, error) {
+	var fields []*sqlField
+	for {
+		expr, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		field := &sqlField{expr: expr}
+		if ok, err := p.scanKeyword("AS"); err != nil {
+			return nil, err
+		} else if ok {
+			alias, err := p.expect(sqlIdent)
+			if err != nil {
+				return nil, err
+			}
+			field.alias = alias.lit
+		}
+		fields = append(fields, field)
+
+		if ok, err := p.scanComma(); err != nil || !ok {
+			return fields, err
+		}
+	}
+}
+
+func (p *sqlParser) scanComma() (bool, error) {
+	tok, err := p.scan()
+	if err != nil {
+		return false, err
+	}
+	if tok.tok == sqlComma {
+		return true, nil
+	}
+	p.unscan(tok)
+	return false, nil
+}
+
+// parseSegments parse the dot separated names such as `"db"."rp"."mst"` or `"db".."mst"`, the last segment can be
+// a regex if allowRegex, or `:MEASUREMENT` if allowBackReference
+func (p *sqlParser) parseSegments(allowRegex, allowBackReference bool) ([]string, *string, error) {
+	var segments []string
+	for {
+		if allowRegex {
+			regex, ok, err := p.scanRegexIfPresent()
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				return segments, &regex.lit, nil
+			}
+		}
+		tok, err := p.scan()
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case tok.tok == sqlIdent:
+			segments = append(segments, tok.lit)
+		case tok.tok == sqlColon && allowBackReference:
+			if err = p.expectKeyword("MEASUREMENT"); err != nil {
+				return nil, nil, err
+			}
+			return append(segments, IntoMeasurementBackReference), nil, nil
+		case tok.tok == sqlDot && len(segments) > 0:
+			// empty retention policy such as "db".."mst"
+			segments = append(segments, "")
+			p.unscan(tok)
+		default:
+			return nil, nil, p.unexpected(tok, "identifier")
+		}
+
+		tok, err = p.scan()
+		if err != nil {
+			return nil, nil, err
+		}
+		if tok.tok != sqlDot {
+			p.unscan(tok)
+			return segments, nil, nil
+		}
+		if len(segments) == 3 {
+			return nil, nil, p.unexpected(tok, "at most 3 segments")
+		}
+	}
+}
+
+func (p *sqlParser) parseTarget() (*sqlTarget, error) {
+	segments, _, err := p.parseSegments(false, true)
+	if err != nil {
+		return nil, err
+	}
+	target := &sqlTarget{measurement: segments[len(segments)-1]}
+	switch len(segments) {
+	case 2:
+		target.retentionPolicy = segments[0]
+	case 3:
+		target.database, target.retentionPolicy = segments[0], segments[1]
+	}
+	if target.measurement == "" {
+		return nil, &sqlSyntaxError{pos: p.lexer.pos, message: "empty INTO measurement"}
+	}
+	return target, nil
+}
+
+func (p *sqlParser) parseSources() ([]sqlSource, error) {
+	var sources []sqlSource
+	for {
+		tok, err := p.scan()
+		if err != nil {
+			return nil, err
+		}
+		if tok.tok == sqlLeftParen {
+			if err = p.expectKeyword("SELECT"); err != nil {
+				return nil, err
+			}
+			statement, err := p.parseSelect()
+			if err != nil {
+				return nil, err
+			}
+			if _, err = p.expect(sqlRightParen); err != nil {
+				return nil, err
+			}
+			sources = append(sources, &sqlSubQuery{statement: statement})
+		} else {
+			p.unscan(tok)
+			segments, regex, err := p.parseSegments(true, false)
+			if err != nil {
+				return nil, err
+			}
+			measurement := &sqlMeasurement{regex: regex}
+			if regex == nil {
+				measurement.name = segments[len(segments)-1]
+				segments = segments[:len(segments)-1]
+			}
+			if len(segments) > 2 {
+				return nil, &sqlSyntaxError{pos: tok.pos, message: "too many segments of measurement"}
+			}
+			switch len(segments) {
+			case 1:
+				measurement.retentionPolicy = segments[0]
+			case 2:
+				measurement.database, measurement.retentionPolicy = segments[0], segments[1]
+			}
+			sources = append(sources, measurement)
+		}
+
+		if ok, err := p.scanComma(); err != nil || !ok {
+			return sources, err
+		}
+	}
+}
+
+func (p *sqlParser) parseDimensions() ([]sqlExpr, error) {
+	var dimensions []sqlExpr
+	for {
+		regex, ok, err := p.scanRegexIfPresent()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			dimensions = append(dimensions, &sqlRegexLiteral{pattern: regex.lit})
+		} else {
+			expr, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			dimensions = append(dimensions, expr)
+		}
+
+		if ok, err := p.scanComma(); err != nil || !ok {
+			return dimensions, err
+		}
+	}
+}
+
+func (p *sqlParser) parseFill() (FillOption, error) {
+	if _, err := p.expect(sqlLeftParen); err != nil {
+		return "", err
+	}
+	tok, err := p.scan()
+	if err != nil {
+		return "", err
+	}
+	var option FillOption
+	negative := false
+	if tok.tok == sqlOperator && tok.lit == "-" {
+		negative = true
+		if tok, err = p.scan(); err != nil {
+			return "", err
+		}
+	}
+	switch {
+	case tok.tok == sqlInteger || tok.tok == sqlNumber:
+		option = FillOption(tok.lit)
+		if negative {
+			option = "-" + option
+		}
+	case !negative && tok.tok == sqlIdent && !tok.quoted:
+		option = FillOption(strings.ToLower(tok.lit))
+		switch option {
+		case FillNone, FillNull, FillPrevious, FillLinear:
+		default:
+			return "", p.unexpected(tok, "none, null, previous, linear or number")
+		}
+	default:
+		return "", p.unexpected(tok, "none, null, previous, linear or number")
+	}
+	if _, err = p.expect(sqlRightParen); err != nil {
+		return "", err
+	}
+	return option, nil
+}
+
+func (p *sqlParser) parseSortFields() ([]*sqlSortField, error) {
+	var fields []*sqlSortField
+	for {
+		tok, err := p.expect(sqlIdent)
+		if err != nil {
+			return nil, err
+		}
+		field := &sqlSortField{name: tok.lit, ascending: true}
+		if tok.isKeyword("ASC") || tok.isKeyword("DESC") {
+			// ORDER BY DESC is short for ORDER BY time DESC
+			field = &sqlSortField{name: "time", ascending: tok.isKeyword("ASC")}
+		} else if ok, err := p.scanKeyword("DESC"); err != nil {
+			return nil, err
+		} else if ok {
+			field.ascending = false
+		} else if _, err = p.scanKeyword("ASC"); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+
+		if ok, err := p.scanComma(); err != nil || !ok {
+			return fields, err
+		}
+	}
+}
+
+func (p *sqlParser) parseInteger() (int64, error) {
+	tok, err := p.expect(sqlInteger)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(tok.lit, 10, 64)
+	if err != nil {
+		return 0, &sqlSyntaxError{pos: tok.pos, message: "invalid integer " + tok.lit}
+	}
+	return n, nil
+}
+
+func (p *sqlParser) parseTimezone() (string, error) {
+	if _, err := p.expect(sqlLeftParen); err != nil {
+		return "", err
+	}
+	tok, err := p.expect(sqlString)
+	if err != nil {
+		return "", err
+	}
+	if _, err = p.expect(sqlRightParen); err != nil {
+		return "", err
+	}
+	if _, err = time.LoadLocation(tok.lit); err != nil {
+		return "", &sqlSyntaxError{pos: tok.pos, message: "unknown timezone " + tok.lit}
+	}
+	return tok.lit, nil
+}
+
+// binaryPrecedence return the operator and precedence of binary operator, 0 means not a binary operator
+func binaryPrecedence(tok sqlLexeme) (string, int) {
+	switch {
+	case tok.isKeyword("OR"):
+		return "OR", 1
+	case tok.isKeyword("AND"):
+		return "AND", 2
+	case tok.tok != sqlOperator:
+		return "", 0
+	}
+	switch tok.lit {
+	case "=", "!=", "<>", "<", "<=", ">", ">=", "=~", "!~":
+		return tok.lit, 4
+	case "+", "-", "|", "^":
+		return tok.lit, 5
+	case "*", "/", "%", "&":
+		return tok.lit, 6
+	default:
+		return "", 0
+	}
+}
+
+// parseExpr parse the binary expression whose operators bind tighter than minPrecedence
+func (p *sqlParser) parseExpr(minPrecedence int) (sqlExpr, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, err := p.scan()
+		if err != nil {
+			return nil, err
+		}
+		op, precedence := binaryPrecedence(tok)
+		if precedence == 0 || precedence <= minPrecedence {
+			p.unscan(tok)
+			return lhs, nil
+		}
+
+		var rhs sqlExpr
+		if op == "=~" || op == "!~" {
+			rhs, err = p.parseRegexOperand()
+		} else {
+			rhs, err = p.parseExpr(precedence)
+		}
+		if err != nil {
+			return nil, err
+		}
+		lhs = &sqlBinaryExpr{op: op, lhs: lhs, rhs: rhs}
+	}
+}
+
+func (p *sqlParser) parseRegexOperand() (sqlExpr, error) {
+	regex, ok, err := p.scanRegexIfPresent()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return &sqlRegexLiteral{pattern: regex.lit}, nil
+	}
+	tok, err := p.scan()
+	if err != nil {
+		return nil, err
+	}
+	if tok.tok == sqlBoundParam {
+		return &sqlBoundParameter{name: tok.lit}, nil
+	}
+	return nil, p.unexpected(tok, "regex")
+}
+
+func (p *sqlParser) parseUnary() (sqlExpr, error) {
+	tok, err := p.scan()
+	if err != nil {
+		return nil, err
+	}
+	if tok.tok != sqlOperator || (tok.lit != "-" && tok.lit != "+") {
+		p.unscan(tok)
+		return p.parsePrimary()
+	}
+
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if tok.lit == "+" {
+		return expr, nil
+	}
+	switch literal := expr.(type) {
+	case *sqlIntegerLiteral:
+		literal.value = -literal.value
+	case *sqlNumberLiteral:
+		literal.value = -literal.value
+	case *sqlDurationLiteral:
+		literal.value = -literal.value
+	default:
+		return &sqlBinaryExpr{op: "*", lhs: &sqlIntegerLiteral{value: -1}, rhs: expr}, nil
+	}
+	return expr, nil
+}
+
+func (p *sqlParser) parsePrimary() (sqlExpr, error) {
+	tok, err := p.scan()
+	if err != nil {
+		return nil, err
+	}
+	switch tok.tok {
+	case sqlLeftParen:
+		expr, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = p.expect(sqlRightParen); err != nil {
+			return nil, err
+		}
+		return &sqlParenExpr{expr: expr}, nil
+	case sqlIdent:
+		return p.parseIdentExpr(tok)
+	case sqlString:
+		return &sqlStringLiteral{value: tok.lit}, nil
+	case sqlInteger:
+		n, err := strconv.ParseInt(tok.lit, 10, 64)
+		if err != nil {
+			return nil, &sqlSyntaxError{pos: tok.pos, message: "invalid integer " + tok.lit}
+		}
+		return &sqlIntegerLiteral{value: n}, nil
+	case sqlNumber:
+		n, err := strconv.ParseFloat(tok.lit, 64)
+		if err != nil {
+			return nil, &sqlSyntaxError{pos: tok.pos, message: "invalid number " + tok.lit}
+		}
+		return &sqlNumberLiteral{value: n}, nil
+	case sqlDuration:
+		d, err := parseDurationLiteral(tok.lit)
+		if err != nil {
+			return nil, &sqlSyntaxError{pos: tok.pos, message: err.Error()}
+		}
+		return &sqlDurationLiteral{value: d}, nil
+	case sqlBoundParam:
+		return &sqlBoundParameter{name: tok.lit}, nil
+	case sqlOperator:
+		if tok.lit == "*" {
+			wildcard := &sqlWildcard{}
+			dataType, err := p.parseDataType()
+			if err != nil {
+				return nil, err
+			}
+			wildcard.dataType = dataType
+			return wildcard, nil
+		}
+	}
+	return nil, p.unexpected(tok, "expression")
+}
+
+func (p *sqlParser) parseIdentExpr(ident sqlLexeme) (sqlExpr, error) {
+	if !ident.quoted {
+		next, err := p.scan()
+		if err != nil {
+			return nil, err
+		}
+		if next.tok == sqlLeftParen {
+			return p.parseCall(ident)
+		}
+		p.unscan(next)
+		if ident.isKeyword("TRUE") || ident.isKeyword("FALSE") {
+			return &sqlBooleanLiteral{value: ident.isKeyword("TRUE")}, nil
+		}
+	}
+	dataType, err := p.parseDataType()
+	if err != nil {
+		return nil, err
+	}
+	return &sqlVarRef{name: ident.lit, dataType: dataType}, nil
+}
+
+// parseDataType parse the type cast such as `::float`
+func (p *sqlParser) parseDataType() (string, error) {
+	tok, err := p.scan()
+	if err != nil {
+		return "", err
+	}
+	if tok.tok != sqlDoubleColon {
+		p.unscan(tok)
+		return "", nil
+	}
+	tok, err = p.expect(sqlIdent)
+	if err != nil {
+		return "", err
+	}
+	dataType := strings.ToLower(tok.lit)
+	switch dataType {
+	case "float", "integer", "unsigned", "string", "boolean", "field", "tag":
+		return dataType, nil
+	default:
+		return "", &sqlSyntaxError{pos: tok.pos, message: "unknown data type " + tok.lit}
+	}
+}
+
+// parseCall parse the arguments of function call, the left parenthesis has been consumed
+func (p *sqlParser) parseCall(name sqlLexeme) (sqlExpr, error) {
+	call := &sqlCall{name: name.lit}
+	tok, err := p.scan()
+	if err != nil {
+		return nil, err
+	}
+	if tok.tok == sqlRightParen {
+		return call, nil
+	}
+	p.unscan(tok)
+	for {
+		regex, ok, err := p.scanRegexIfPresent()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			call.args = append(call.args, &sqlRegexLiteral{pattern: regex.lit})
+		} else {
+			arg, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+		}
+
+		tok, err := p.scan()
+		if err != nil {
+			return nil, err
+		}
+		switch tok.tok {
+		case sqlRightParen:
+			return call, nil
+		case sqlComma:
+		default:
+			return nil, p.unexpected(tok, ", or )")
+		}
+	}
+}
+
+// parseDurationLiteral parse the duration literal such as 1h30m, the lexer has checked the format
+func parseDurationLiteral(literal string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"u":  time.Microsecond,
+		"µ":  time.Microsecond,
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+	}
+	var total time.Duration
+	rest := literal
+	for len(rest) > 0 {
+		i := 0
+		for i < len(rest) && isDigit(rune(rest[i])) {
+			i++
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, errors.New("invalid duration " + literal)
+		}
+		j := i
+		for j < len(rest) && !isDigit(rune(rest[j])) {
+			j++
+		}
+		unit, ok := units[rest[i:j]]
+		if !ok {
+			return 0, errors.New("invalid duration " + literal)
+		}
+		total += time.Duration(n) * unit
+		rest = rest[j:]
+	}
+	return total, nil
+}
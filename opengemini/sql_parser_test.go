@@ -0,0 +1,231 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryRoundTrip(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.Nil(t, err)
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	builders := []*QueryBuilder{
+		CreateQueryBuilder().From("h2o_feet"),
+		CreateQueryBuilder().Select(NewFieldExpression("water level"), NewFieldExpression("location")).
+			From("h2o_feet", "h2o_pH").Limit(10).Offset(5),
+		CreateQueryBuilder().Select(NewAsExpression("avg", NewFunctionExpression(FunctionMean, NewFieldExpression("v")))).
+			From("cpu").
+			Where(NewCompositeCondition(And,
+				NewComparisonCondition("host", Equals, "server'01"),
+				NewComparisonCondition("region", Match, "us-.*"),
+				NewTimeCondition(GreaterThanOrEquals, start),
+				NewRelativeTimeCondition(LessThan, time.Hour),
+			)).
+			GroupByTime(10*time.Minute, time.Minute).GroupBy(NewFieldExpression("host")).
+			Fill(FillPrevious).OrderBy(Desc).SLimit(2).SOffset(1).Timezone(shanghai),
+		CreateQueryBuilder().Select(NewArithmeticExpression(Add,
+			NewArithmeticExpression(Multiply, NewFieldExpression("v"), NewConstantExpression(4)),
+			NewConstantExpression(2.5))).From("cpu").
+			Where(NewCompositeCondition(Or,
+				NewComparisonCondition("v", GreaterThan, int64(-3)),
+				NewComparisonCondition("ok", NotEquals, true),
+			)),
+		CreateQueryBuilder().Select(NewFunctionExpression(FunctionPercentile, NewFieldExpression("v"), NewConstantExpression(95))).
+			Into("db", "", "mst").FromRegex("cpu.*", "mem/.*").GroupByTime(time.Hour, 0).Fill(FillValue(-1)),
+		CreateQueryBuilder().Select(NewFunctionExpression(FunctionMax, NewFieldExpression("mean"))).
+			FromSubQuery(CreateQueryBuilder().Select(NewFunctionExpression(FunctionMean, NewFieldExpression("v"))).
+				From("cpu").GroupBy(NewFieldExpression("host"))),
+	}
+	for _, builder := range builders {
+		command := builder.Build().Command
+		parsed, err := ParseQuery(command)
+		require.Nil(t, err, command)
+		require.Equal(t, command, parsed.Build().Command)
+	}
+}
+
+func TestParseQueryModify(t *testing.T) {
+	builder, err := ParseQuery(`select mean(v) from "cpu" where host = 'a' group by time(1m)`)
+	require.Nil(t, err)
+	builder.Where(NewCompositeCondition(And,
+		NewComparisonCondition("host", Equals, "a"),
+		NewRelativeTimeCondition(GreaterThan, time.Hour),
+	)).Limit(10)
+	require.Equal(t, `SELECT MEAN("v") FROM "cpu" WHERE ("host" = 'a' AND time > now() - 1h) GROUP BY time(1m) LIMIT 10`,
+		builder.Build().Command)
+}
+
+func TestParseQueryUnsupported(t *testing.T) {
+	commands := []string{
+		`SHOW DATABASES`,
+		`SELECT * FROM a; SELECT * FROM b`,
+		`SELECT "v"::float FROM "cpu"`,
+		`SELECT * FROM "db"."rp"."cpu"`,
+		`SELECT * FROM "cpu", /mem/`,
+		`SELECT 'a' FROM "cpu"`,
+		`SELECT * FROM "cpu" WHERE "v" = $v`,
+		`SELECT * FROM "cpu" ORDER BY "host"`,
+	}
+	for _, command := range commands {
+		_, err := ParseQuery(command)
+		require.NotNil(t, err, command)
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	valid := []string{
+		`SELECT * FROM "cpu"`,
+		`select "v" / 2, -"v", top("v", "host", 3) from cpu where time > now() - 1h30m and "host" =~ /^a\/b$/`,
+		`SELECT * FROM "db".."cpu" WHERE "a" = 'x' -- comment`,
+		`SELECT mean(*) INTO :MEASUREMENT FROM /.*/ GROUP BY time(1h, -5m), * fill(none) ORDER BY DESC`,
+		`EXPLAIN ANALYZE SELECT count("v") FROM "cpu" TZ('UTC')`,
+		`SHOW TAG VALUES FROM /cpu.*/ WITH KEY =~ /ho(st|ok)/ WHERE "a" = 'b'`,
+		`CREATE CONTINUOUS QUERY "cq" ON "db" BEGIN SELECT mean("v") / 2 INTO "m" FROM "cpu" GROUP BY time(1h) END`,
+		`CREATE DATABASE "db"; DROP MEASUREMENT "cpu";`,
+		`GRANT ALL PRIVILEGES TO "user"`,
+		"INSERT cpu,host=a v=1;2",
+		`SELECT holt_winters(first("v"), 10, 0) FROM "cpu" WHERE time > now() - 1d GROUP BY time(1h)`,
+	}
+	for _, command := range valid {
+		require.Nil(t, ValidateCommand(command), command)
+	}
+
+	invalid := map[string]string{
+		``:                                         "empty command",
+		`SELEC * FROM "cpu"`:                       "unsupported statement SELEC",
+		`SELECT * FROM`:                            "position 14",
+		`SELECT * "cpu"`:                           "expect FROM",
+		`SELECT * FROM "cpu" WHERE "a" = 'b`:       "position 33: unterminated",
+		`SELECT * FROM "cpu" fill(linear)`:         "fill requires group by",
+		`SELECT * FROM "cpu" GROUP BY time(1h`:     "expect , or )",
+		`SELECT * FROM "cpu" TZ('Mars/Olympus')`:   "unknown timezone",
+		`SELECT * FROM "cpu" WHERE "a" =~ 'b'`:     "expect regex",
+		`SELECT * FROM /cpu(/`:                     "invalid regex",
+		`SELECT PERCENTILE("v") FROM "cpu"`:        "PERCENTILE",
+		`SHOW SERIES FROM ("cpu"`:                  "unbalanced parentheses",
+		`SELECT * FROM "cpu" LIMIT 1 SHOW`:         "expect ; or EOF",
+		`SELECT moving_average("v", 1) FROM "cpu"`: "window must be greater than 1",
+	}
+	for command, message := range invalid {
+		err := ValidateCommand(command)
+		require.NotNil(t, err, command)
+		require.Contains(t, err.Error(), message, command)
+	}
+}
+
+func TestClassifyStatements(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected StatementType
+		hasError bool
+	}{
+		{`SELECT * FROM "cpu"`, StatementTypeQuery, false},
+		{`SELECT * FROM "cpu"; SHOW DATABASES`, StatementTypeQuery, false},
+		{`SELECT * INTO "mst" FROM "cpu"`, StatementTypeCommand, false},
+		{`SHOW DATABASES; DROP DATABASE "db"`, StatementTypeCommand, false},
+		{`EXPLAIN SELECT * FROM "cpu"`, StatementTypeQuery, false},
+		{`SET PASSWORD FOR "user" = 'pass'`, StatementTypeCommand, false},
+		{`INSERT cpu v=1`, StatementTypeInsert, false},
+		{`CREATE DATABASE "db"; INSERT cpu v=1`, StatementTypeUnknown, true},
+	}
+	for _, test := range tests {
+		statements, err := parseSQL(test.command)
+		require.Nil(t, err, test.command)
+		stmtType, err := classifyStatements(statements)
+		require.Equal(t, test.hasError, err != nil, test.command)
+		require.Equal(t, test.expected, stmtType, test.command)
+	}
+}
+
+func TestResolveStatementType(t *testing.T) {
+	stmtType, err := resolveStatementType(Statement{Command: `SELECT * FROM "cpu"; DROP MEASUREMENT "cpu"`})
+	require.Nil(t, err)
+	require.Equal(t, StatementTypeCommand, stmtType)
+
+	// syntax unknown to client parser falls back to the first keyword
+	stmtType, err = resolveStatementType(Statement{Command: `SELECT * FROM "cpu" WHERE "a" == 1`})
+	require.Nil(t, err)
+	require.Equal(t, StatementTypeQuery, stmtType)
+
+	_, err = resolveStatementType(Statement{Command: `SELECT * FROM "cpu" WHERE "a" == 1`, Validate: true})
+	require.NotNil(t, err)
+
+	// line protocol is not split by `;`
+	stmtType, err = resolveStatementType(Statement{Command: `INSERT cpu,host=a v="x;DROP DATABASE db"`})
+	require.Nil(t, err)
+	require.Equal(t, StatementTypeInsert, stmtType)
+}
+
+func TestExecuteRoutesCommandBatchByPost(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		methods = append(methods, request.Method)
+		writer.Header().Set("Content-Type", HttpContentTypeJSON)
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":0},{"statement_id":1}]}`))
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}})
+
+	result, err := c.Execute(Statement{Database: "db", Command: `SHOW MEASUREMENTS; SELECT * FROM "cpu"`})
+	require.Nil(t, err)
+	require.Equal(t, StatementTypeQuery, result.StatementType)
+
+	result, err = c.Execute(Statement{Database: "db", Command: `SHOW MEASUREMENTS; DROP MEASUREMENT "cpu"`})
+	require.Nil(t, err)
+	require.Equal(t, StatementTypeCommand, result.StatementType)
+	require.Equal(t, []string{http.MethodGet, http.MethodPost}, methods)
+
+	result, err = c.Execute(Statement{Database: "db", Command: `SELECT * FROM`, Validate: true})
+	require.NotNil(t, err)
+	require.Equal(t, StatementTypeUnknown, result.StatementType)
+	require.Len(t, methods, 2)
+}
+
+func TestExecuteWithPrecision(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		requests = append(requests, request)
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+		if request.URL.Path == UrlWrite {
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writer.Header().Set("Content-Type", HttpContentTypeJSON)
+		_, _ = writer.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{Addresses: []Address{testServerAddress(t, server.URL)}})
+
+	_, err := c.Execute(Statement{Database: "db", Command: `SELECT * FROM "cpu"`, Precision: PrecisionSecond})
+	require.Nil(t, err)
+	require.Equal(t, "s", requests[0].URL.Query().Get("epoch"))
+
+	_, err = c.Execute(Statement{Database: "db", Command: `INSERT cpu v=1 2`, Precision: PrecisionMillisecond})
+	require.Nil(t, err)
+	require.Equal(t, "cpu v=1 2000000", bodies[1])
+}
@@ -74,6 +74,10 @@ var (
 		"ALTER":  true,
 		"UPDATE": true,
 		"DELETE": true,
+		"GRANT":  true,
+		"REVOKE": true,
+		"SET":    true,
+		"KILL":   true,
 	}
 )
 
@@ -80,7 +80,7 @@ func TestIsQueryKeyword(t *testing.T) {
 }
 
 func TestIsCommandKeyword(t *testing.T) {
-	commandKeywords := []string{"CREATE", "DROP", "ALTER", "UPDATE", "DELETE"}
+	commandKeywords := []string{"CREATE", "DROP", "ALTER", "UPDATE", "DELETE", "GRANT", "REVOKE", "SET", "KILL"}
 	nonCommandKeywords := []string{"SELECT", "SHOW", "INSERT", "EXPLAIN", "UNKNOWN"}
 
 	for _, keyword := range commandKeywords {
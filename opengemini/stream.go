@@ -0,0 +1,253 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StreamTask defines the structure for stream computation task info
+type StreamTask struct {
+	Name string
+	// Query the `SELECT` statement of stream task
+	Query string
+	// Delay the max delay time of data which stream task waits for
+	Delay string
+}
+
+func (s *StreamTask) SetName(value SeriesValue) error {
+	name, ok := value[0].(string)
+	if !ok {
+		return fmt.Errorf("set StreamTask name: name must be a string")
+	}
+	s.Name = name
+	return nil
+}
+
+func (s *StreamTask) SetQuery(value SeriesValue) error {
+	query, ok := value[1].(string)
+	if !ok {
+		return fmt.Errorf("set StreamTask query: query must be a string")
+	}
+	s.Query = query
+	return nil
+}
+
+func (s *StreamTask) SetDelay(value SeriesValue) error {
+	// delay column is optional
+	if len(value) < 3 {
+		return nil
+	}
+	delay, ok := value[2].(string)
+	if !ok {
+		return fmt.Errorf("set StreamTask delay: delay must be a string")
+	}
+	s.Delay = delay
+	return nil
+}
+
+func NewStreamTask(value SeriesValue) *StreamTask {
+	s := &StreamTask{}
+	if !errors.Is(s.SetName(value), nil) ||
+		!errors.Is(s.SetQuery(value), nil) ||
+		!errors.Is(s.SetDelay(value), nil) {
+		return nil
+	}
+	return s
+}
+
+// StreamBuilder build command `CREATE STREAM`, such as
+//
+//	CREATE STREAM "stream0" INTO "rp"."mst" ON SELECT SUM("v") FROM "src" GROUP BY time(1m), "tag" DELAY 10s
+type StreamBuilder interface {
+	// Name specify stream task name
+	Name(name string) StreamBuilder
+	// Database specify the database which stream task runs on
+	Database(database string) StreamBuilder
+	// Into specify the destination measurement, if retention policy is empty, use default retention policy
+	Into(retentionPolicy, measurement string) StreamBuilder
+	// Select specify the select expressions, the same as QueryBuilder.Select
+	Select(selectExpressions ...Expression) StreamBuilder
+	// From specify the source measurement
+	From(measurement string) StreamBuilder
+	// Where filter source data, the same as QueryBuilder.Where
+	Where(condition Condition) StreamBuilder
+	// GroupByTime specify the interval of GROUP BY time(), it is required
+	GroupByTime(interval time.Duration) StreamBuilder
+	// GroupBy specify the other group by expressions, such as tag fields
+	GroupBy(groupByExpressions ...Expression) StreamBuilder
+	// Delay specify the max delay time of data which stream task waits for
+	Delay(delay time.Duration) StreamBuilder
+	build() (string, error)
+	getDatabase() string
+}
+
+type streamBuilder struct {
+	name     string
+	database string
+	delay    time.Duration
+	query    *QueryBuilder
+}
+
+func NewStreamBuilder() StreamBuilder {
+	return &streamBuilder{query: CreateQueryBuilder()}
+}
+
+func (s *streamBuilder) Name(name string) StreamBuilder {
+	s.name = name
+	return s
+}
+
+func (s *streamBuilder) Database(database string) StreamBuilder {
+	s.database = database
+	return s
+}
+
+func (s *streamBuilder) Into(retentionPolicy, measurement string) StreamBuilder {
+	s.query.Into("", retentionPolicy, measurement)
+	return s
+}
+
+func (s *streamBuilder) Select(selectExpressions ...Expression) StreamBuilder {
+	s.query.Select(selectExpressions...)
+	return s
+}
+
+func (s *streamBuilder) From(measurement string) StreamBuilder {
+	s.query.From(measurement)
+	return s
+}
+
+func (s *streamBuilder) Where(condition Condition) StreamBuilder {
+	s.query.Where(condition)
+	return s
+}
+
+func (s *streamBuilder) GroupByTime(interval time.Duration) StreamBuilder {
+	s.query.GroupByTime(interval, 0)
+	return s
+}
+
+func (s *streamBuilder) GroupBy(groupByExpressions ...Expression) StreamBuilder {
+	s.query.GroupBy(groupByExpressions...)
+	return s
+}
+
+func (s *streamBuilder) Delay(delay time.Duration) StreamBuilder {
+	s.delay = delay
+	return s
+}
+
+func (s *streamBuilder) build() (string, error) {
+	if len(s.name) == 0 {
+		return "", ErrEmptyStreamName
+	}
+	if err := checkDatabaseName(s.database); err != nil {
+		return "", err
+	}
+	if err := checkMeasurementName(s.query.intoMeasurement); err != nil {
+		return "", fmt.Errorf("into: %w", err)
+	}
+	if len(s.query.from) == 0 {
+		return "", fmt.Errorf("from: %w", ErrEmptyMeasurement)
+	}
+	if s.query.groupByTime <= 0 {
+		return "", errors.New("group by time interval must be greater than 0")
+	}
+	if s.delay < 0 {
+		return "", errors.New("stream delay must not be negative")
+	}
+
+	var buf strings.Builder
+	buf.WriteString("CREATE STREAM " + QuoteIdentifier(s.name))
+	buf.WriteString(s.query.buildInto())
+	buf.WriteString(" ON ")
+	buf.WriteString(s.query.buildSelect())
+	buf.WriteString(s.query.buildFrom(nil))
+	buf.WriteString(s.query.buildWhere(nil))
+	buf.WriteString(s.query.buildGroupBy())
+	if s.delay > 0 {
+		buf.WriteString(" DELAY " + formatDuration(s.delay))
+	}
+	return buf.String(), nil
+}
+
+func (s *streamBuilder) getDatabase() string {
+	return s.database
+}
+
+// CreateStream use command `CREATE STREAM` to create stream computation task
+func (c *client) CreateStream(builder StreamBuilder) error {
+	command, err := builder.build()
+	if err != nil {
+		return err
+	}
+
+	queryResult, err := c.queryPost(Query{Database: builder.getDatabase(), Command: command})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("create stream %w", err)
+	}
+	return nil
+}
+
+// ShowStreams use command `SHOW STREAMS` to view stream tasks of database
+func (c *client) ShowStreams(database string) ([]StreamTask, error) {
+	err := checkDatabaseName(database)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult, err := c.Query(Query{Database: database, Command: "SHOW STREAMS ON " + QuoteIdentifier(database)})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show streams err: %s", err)
+	}
+
+	return queryResult.convertStreamTaskList(), nil
+}
+
+// DropStream use command `DROP STREAM` to delete stream task
+func (c *client) DropStream(database, name string) error {
+	err := checkDatabaseName(database)
+	if err != nil {
+		return err
+	}
+	if len(name) == 0 {
+		return ErrEmptyStreamName
+	}
+
+	queryResult, err := c.queryPost(Query{Database: database, Command: "DROP STREAM " + QuoteIdentifier(name)})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("drop stream %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamBuilder(t *testing.T) {
+	command, err := NewStreamBuilder().Name("stream0").Database("db0").Into("rp0", "cpu_sum").
+		Select(NewFunctionExpression(FunctionSum, NewFieldExpression("usage"))).From("cpu").
+		GroupByTime(time.Minute).GroupBy(NewFieldExpression("host")).Delay(10 * time.Second).build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE STREAM "stream0" INTO "rp0"."cpu_sum" ON SELECT SUM("usage") FROM "cpu" GROUP BY time(1m), "host" DELAY 10s`, command)
+
+	command, err = NewStreamBuilder().Name("stream0").Database("db0").Into("", "cpu_sum").
+		Select(NewFunctionExpression(FunctionSum, NewFieldExpression("usage"))).From("cpu").
+		GroupByTime(time.Minute).build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE STREAM "stream0" INTO "cpu_sum" ON SELECT SUM("usage") FROM "cpu" GROUP BY time(1m)`, command)
+}
+
+func TestStreamBuilderError(t *testing.T) {
+	_, err := NewStreamBuilder().Database("db0").Into("", "m1").From("m0").GroupByTime(time.Minute).build()
+	require.ErrorIs(t, err, ErrEmptyStreamName)
+
+	_, err = NewStreamBuilder().Name("stream0").Into("", "m1").From("m0").GroupByTime(time.Minute).build()
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+
+	_, err = NewStreamBuilder().Name("stream0").Database("db0").From("m0").GroupByTime(time.Minute).build()
+	require.ErrorIs(t, err, ErrEmptyMeasurement)
+
+	_, err = NewStreamBuilder().Name("stream0").Database("db0").Into("", "m1").From("m0").build()
+	require.NotNil(t, err)
+}
+
+func TestConvertStreamTaskList(t *testing.T) {
+	result := &QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Columns: []string{"name", "query", "delay"},
+		Values:  SeriesValues{{"stream0", "CREATE STREAM stream0", "10s"}, {"stream1", "CREATE STREAM stream1"}},
+	}}}}}
+	require.Equal(t, []StreamTask{
+		{Name: "stream0", Query: "CREATE STREAM stream0", Delay: "10s"},
+		{Name: "stream1", Query: "CREATE STREAM stream1"},
+	}, result.convertStreamTaskList())
+}
+
+func TestClientStream(t *testing.T) {
+	c := testDefaultClient(t)
+	databaseName := randomDatabaseName()
+	streamName := "stream_" + randomMeasurement()
+	err := c.CreateDatabase(databaseName)
+	require.Nil(t, err)
+
+	err = c.CreateStream(NewStreamBuilder().Name(streamName).Database(databaseName).Into("autogen", "cpu_sum").
+		Select(NewFunctionExpression(FunctionSum, NewFieldExpression("usage"))).From("cpu").
+		GroupByTime(time.Minute).Delay(10 * time.Second))
+	require.Nil(t, err)
+
+	streams, err := c.ShowStreams(databaseName)
+	require.Nil(t, err)
+	var found bool
+	for _, stream := range streams {
+		if stream.Name == streamName {
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	err = c.DropStream(databaseName, streamName)
+	require.Nil(t, err)
+	err = c.DropDatabase(databaseName)
+	require.Nil(t, err)
+}
+
+func TestClientDropStreamEmptyName(t *testing.T) {
+	c := testDefaultClient(t)
+	err := c.DropStream("db0", "")
+	require.ErrorIs(t, err, ErrEmptyStreamName)
+	_, err = c.ShowStreams("")
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+}
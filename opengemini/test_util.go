@@ -15,6 +15,8 @@
 package opengemini
 
 import (
+	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/libgox/unicodex/letter"
@@ -37,6 +39,15 @@ func testNewClient(t *testing.T, config *Config) Client {
 	return client
 }
 
+// testServerAddress return the address of a test server listening on serverURL, such as httptest.Server.URL
+func testServerAddress(t *testing.T, serverURL string) Address {
+	u, err := url.Parse(serverURL)
+	require.Nil(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.Nil(t, err)
+	return Address{Host: u.Hostname(), Port: port}
+}
+
 func randomDatabaseName() string {
 	return letter.RandEnglish(8)
 }
@@ -0,0 +1,319 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Privilege string
+
+const (
+	PrivilegeRead  Privilege = "READ"
+	PrivilegeWrite Privilege = "WRITE"
+	PrivilegeAll   Privilege = "ALL PRIVILEGES"
+	PrivilegeNone  Privilege = "NO PRIVILEGES"
+)
+
+// UserInfo defines the structure for user info
+type UserInfo struct {
+	Name  string
+	Admin bool
+}
+
+func (u *UserInfo) SetName(value SeriesValue) error {
+	name, ok := value[0].(string)
+	if !ok {
+		return fmt.Errorf("set UserInfo name: name must be a string")
+	}
+	u.Name = name
+	return nil
+}
+
+func (u *UserInfo) SetAdmin(value SeriesValue) error {
+	admin, ok := value[1].(bool)
+	if !ok {
+		return fmt.Errorf("set UserInfo admin: admin must be a bool")
+	}
+	u.Admin = admin
+	return nil
+}
+
+func NewUserInfo(value SeriesValue) *UserInfo {
+	u := &UserInfo{}
+	if !errors.Is(u.SetName(value), nil) ||
+		!errors.Is(u.SetAdmin(value), nil) {
+		return nil
+	}
+	return u
+}
+
+// GrantInfo defines the structure for the privilege of user on database
+type GrantInfo struct {
+	Database  string
+	Privilege Privilege
+}
+
+func (g *GrantInfo) SetDatabase(value SeriesValue) error {
+	database, ok := value[0].(string)
+	if !ok {
+		return fmt.Errorf("set GrantInfo database: database must be a string")
+	}
+	g.Database = database
+	return nil
+}
+
+func (g *GrantInfo) SetPrivilege(value SeriesValue) error {
+	privilege, ok := value[1].(string)
+	if !ok {
+		return fmt.Errorf("set GrantInfo privilege: privilege must be a string")
+	}
+	g.Privilege = Privilege(privilege)
+	return nil
+}
+
+func NewGrantInfo(value SeriesValue) *GrantInfo {
+	g := &GrantInfo{}
+	if !errors.Is(g.SetDatabase(value), nil) ||
+		!errors.Is(g.SetPrivilege(value), nil) {
+		return nil
+	}
+	return g
+}
+
+// CreateUserBuilder build command `CREATE USER`
+type CreateUserBuilder interface {
+	// Username specify the name of the new user
+	Username(username string) CreateUserBuilder
+	// Password specify the password of the new user
+	Password(password string) CreateUserBuilder
+	// Admin create the user as an admin user with `WITH ALL PRIVILEGES`
+	Admin() CreateUserBuilder
+	build() (string, error)
+}
+
+type createUserBuilder struct {
+	username string
+	password string
+	admin    bool
+}
+
+func (c *createUserBuilder) Username(username string) CreateUserBuilder {
+	c.username = username
+	return c
+}
+
+func (c *createUserBuilder) Password(password string) CreateUserBuilder {
+	c.password = password
+	return c
+}
+
+func (c *createUserBuilder) Admin() CreateUserBuilder {
+	c.admin = true
+	return c
+}
+
+func (c *createUserBuilder) build() (string, error) {
+	if err := checkUserName(c.username); err != nil {
+		return "", err
+	}
+	if len(c.password) == 0 {
+		return "", ErrEmptyUserPassword
+	}
+	var buf strings.Builder
+	buf.WriteString("CREATE USER " + QuoteIdentifier(c.username) + " WITH PASSWORD " + QuoteString(c.password))
+	if c.admin {
+		buf.WriteString(" WITH ALL PRIVILEGES")
+	}
+	return buf.String(), nil
+}
+
+func NewCreateUserBuilder() CreateUserBuilder {
+	return &createUserBuilder{}
+}
+
+type privilegeCommand string
+
+const (
+	privilegeGrant  privilegeCommand = "GRANT"
+	privilegeRevoke privilegeCommand = "REVOKE"
+)
+
+// PrivilegeBuilder build command `GRANT` and `REVOKE` for the privilege of user on database
+type PrivilegeBuilder interface {
+	// Privilege specify privilege, support PrivilegeRead, PrivilegeWrite and PrivilegeAll
+	Privilege(privilege Privilege) PrivilegeBuilder
+	// Database specify the database which privilege is granted on or revoked from
+	Database(database string) PrivilegeBuilder
+	// Username specify the user which privilege is granted to or revoked from
+	Username(username string) PrivilegeBuilder
+	build(command privilegeCommand) (string, error)
+}
+
+type privilegeBuilder struct {
+	privilege Privilege
+	database  string
+	username  string
+}
+
+func (p *privilegeBuilder) Privilege(privilege Privilege) PrivilegeBuilder {
+	p.privilege = privilege
+	return p
+}
+
+func (p *privilegeBuilder) Database(database string) PrivilegeBuilder {
+	p.database = database
+	return p
+}
+
+func (p *privilegeBuilder) Username(username string) PrivilegeBuilder {
+	p.username = username
+	return p
+}
+
+func (p *privilegeBuilder) build(command privilegeCommand) (string, error) {
+	if err := checkDatabaseName(p.database); err != nil {
+		return "", err
+	}
+	if err := checkUserName(p.username); err != nil {
+		return "", err
+	}
+	switch p.privilege {
+	case PrivilegeRead, PrivilegeWrite, PrivilegeAll:
+	default:
+		return "", fmt.Errorf("invalid privilege: %q", p.privilege)
+	}
+	var direction = "TO"
+	if command == privilegeRevoke {
+		direction = "FROM"
+	}
+	return fmt.Sprintf("%s %s ON %s %s %s", command, p.privilege, QuoteIdentifier(p.database), direction,
+		QuoteIdentifier(p.username)), nil
+}
+
+func NewPrivilegeBuilder() PrivilegeBuilder {
+	return &privilegeBuilder{}
+}
+
+// CreateUser use command `CREATE USER` to create user
+func (c *client) CreateUser(builder CreateUserBuilder) error {
+	command, err := builder.build()
+	if err != nil {
+		return err
+	}
+	return c.executeUserCommand(command, "create user")
+}
+
+// DropUser use command `DROP USER` to delete user
+func (c *client) DropUser(username string) error {
+	if err := checkUserName(username); err != nil {
+		return err
+	}
+	return c.executeUserCommand("DROP USER "+QuoteIdentifier(username), "drop user")
+}
+
+// SetPassword use command `SET PASSWORD` to reset the password of user
+func (c *client) SetPassword(username, password string) error {
+	if err := checkUserName(username); err != nil {
+		return err
+	}
+	if len(password) == 0 {
+		return ErrEmptyUserPassword
+	}
+	return c.executeUserCommand("SET PASSWORD FOR "+QuoteIdentifier(username)+" = "+QuoteString(password), "set password")
+}
+
+// ShowUsers use command `SHOW USERS` to view all users and whether they are admin
+func (c *client) ShowUsers() ([]UserInfo, error) {
+	queryResult, err := c.Query(Query{Command: "SHOW USERS"})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show users err: %s", err)
+	}
+
+	return queryResult.convertUserList(), nil
+}
+
+// GrantPrivilege use command `GRANT` to grant privilege on database to user
+func (c *client) GrantPrivilege(builder PrivilegeBuilder) error {
+	command, err := builder.build(privilegeGrant)
+	if err != nil {
+		return err
+	}
+	return c.executeUserCommand(command, "grant privilege")
+}
+
+// RevokePrivilege use command `REVOKE` to revoke privilege on database from user
+func (c *client) RevokePrivilege(builder PrivilegeBuilder) error {
+	command, err := builder.build(privilegeRevoke)
+	if err != nil {
+		return err
+	}
+	return c.executeUserCommand(command, "revoke privilege")
+}
+
+// ShowGrants use command `SHOW GRANTS FOR` to view the database privileges of user
+func (c *client) ShowGrants(username string) ([]GrantInfo, error) {
+	if err := checkUserName(username); err != nil {
+		return nil, err
+	}
+
+	queryResult, err := c.Query(Query{Command: "SHOW GRANTS FOR " + QuoteIdentifier(username)})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return nil, fmt.Errorf("show grants err: %s", err)
+	}
+
+	return queryResult.convertGrantList(), nil
+}
+
+// GrantAdmin use command `GRANT ALL PRIVILEGES` to grant admin privilege to user
+func (c *client) GrantAdmin(username string) error {
+	if err := checkUserName(username); err != nil {
+		return err
+	}
+	return c.executeUserCommand("GRANT ALL PRIVILEGES TO "+QuoteIdentifier(username), "grant admin")
+}
+
+// RevokeAdmin use command `REVOKE ALL PRIVILEGES` to revoke admin privilege from user
+func (c *client) RevokeAdmin(username string) error {
+	if err := checkUserName(username); err != nil {
+		return err
+	}
+	return c.executeUserCommand("REVOKE ALL PRIVILEGES FROM "+QuoteIdentifier(username), "revoke admin")
+}
+
+func (c *client) executeUserCommand(command, operation string) error {
+	queryResult, err := c.queryPost(Query{Command: command})
+	if err != nil {
+		return err
+	}
+
+	err = queryResult.hasError()
+	if err != nil {
+		return fmt.Errorf("%s %w", operation, err)
+	}
+	return nil
+}
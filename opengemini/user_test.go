@@ -0,0 +1,123 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUserBuilder(t *testing.T) {
+	command, err := NewCreateUserBuilder().Username("user0").Password("Pwd@123456").build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE USER "user0" WITH PASSWORD 'Pwd@123456'`, command)
+
+	command, err = NewCreateUserBuilder().Username("admin0").Password(`it's\pwd`).Admin().build()
+	require.Nil(t, err)
+	require.Equal(t, `CREATE USER "admin0" WITH PASSWORD 'it\'s\\pwd' WITH ALL PRIVILEGES`, command)
+
+	_, err = NewCreateUserBuilder().Password("Pwd@123456").build()
+	require.ErrorIs(t, err, ErrEmptyUserName)
+
+	_, err = NewCreateUserBuilder().Username("user0").build()
+	require.ErrorIs(t, err, ErrEmptyUserPassword)
+}
+
+func TestPrivilegeBuilder(t *testing.T) {
+	builder := NewPrivilegeBuilder().Privilege(PrivilegeRead).Database("db0").Username("user0")
+	command, err := builder.build(privilegeGrant)
+	require.Nil(t, err)
+	require.Equal(t, `GRANT READ ON "db0" TO "user0"`, command)
+
+	command, err = builder.build(privilegeRevoke)
+	require.Nil(t, err)
+	require.Equal(t, `REVOKE READ ON "db0" FROM "user0"`, command)
+
+	command, err = NewPrivilegeBuilder().Privilege(PrivilegeAll).Database("db0").Username("user0").build(privilegeGrant)
+	require.Nil(t, err)
+	require.Equal(t, `GRANT ALL PRIVILEGES ON "db0" TO "user0"`, command)
+
+	_, err = NewPrivilegeBuilder().Privilege(PrivilegeRead).Username("user0").build(privilegeGrant)
+	require.ErrorIs(t, err, ErrEmptyDatabaseName)
+
+	_, err = NewPrivilegeBuilder().Privilege(PrivilegeRead).Database("db0").build(privilegeGrant)
+	require.ErrorIs(t, err, ErrEmptyUserName)
+
+	_, err = NewPrivilegeBuilder().Privilege(PrivilegeNone).Database("db0").Username("user0").build(privilegeGrant)
+	require.NotNil(t, err)
+}
+
+func TestConvertUserAndGrantList(t *testing.T) {
+	result := &QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Columns: []string{"user", "admin"},
+		Values:  SeriesValues{{"admin", true}, {"user0", false}},
+	}}}}}
+	require.Equal(t, []UserInfo{{Name: "admin", Admin: true}, {Name: "user0", Admin: false}}, result.convertUserList())
+
+	result = &QueryResult{Results: []*SeriesResult{{Series: []*Series{{
+		Columns: []string{"database", "privilege"},
+		Values:  SeriesValues{{"db0", "READ"}, {"db1", "ALL PRIVILEGES"}},
+	}}}}}
+	require.Equal(t, []GrantInfo{{Database: "db0", Privilege: PrivilegeRead}, {Database: "db1", Privilege: PrivilegeAll}},
+		result.convertGrantList())
+
+	require.Equal(t, []UserInfo{}, (&QueryResult{}).convertUserList())
+	require.Equal(t, []GrantInfo{}, (&QueryResult{}).convertGrantList())
+}
+
+func TestClientUserAndPrivilege(t *testing.T) {
+	c := testDefaultClient(t)
+	databaseName := randomDatabaseName()
+	username := "user_" + randomMeasurement()
+	err := c.CreateDatabase(databaseName)
+	require.Nil(t, err)
+
+	err = c.CreateUser(NewCreateUserBuilder().Username(username).Password("Pwd@123456"))
+	require.Nil(t, err)
+	users, err := c.ShowUsers()
+	require.Nil(t, err)
+	require.Contains(t, users, UserInfo{Name: username, Admin: false})
+
+	err = c.SetPassword(username, "NewPwd@123456")
+	require.Nil(t, err)
+
+	err = c.GrantPrivilege(NewPrivilegeBuilder().Privilege(PrivilegeRead).Database(databaseName).Username(username))
+	require.Nil(t, err)
+	grants, err := c.ShowGrants(username)
+	require.Nil(t, err)
+	require.Contains(t, grants, GrantInfo{Database: databaseName, Privilege: PrivilegeRead})
+
+	err = c.RevokePrivilege(NewPrivilegeBuilder().Privilege(PrivilegeRead).Database(databaseName).Username(username))
+	require.Nil(t, err)
+	grants, err = c.ShowGrants(username)
+	require.Nil(t, err)
+	require.NotContains(t, grants, GrantInfo{Database: databaseName, Privilege: PrivilegeRead})
+
+	err = c.DropUser(username)
+	require.Nil(t, err)
+	err = c.DropDatabase(databaseName)
+	require.Nil(t, err)
+}
+
+func TestClientUserEmptyName(t *testing.T) {
+	c := testDefaultClient(t)
+	require.ErrorIs(t, c.DropUser(""), ErrEmptyUserName)
+	require.ErrorIs(t, c.SetPassword("", "Pwd@123456"), ErrEmptyUserName)
+	require.ErrorIs(t, c.GrantAdmin(""), ErrEmptyUserName)
+	require.ErrorIs(t, c.RevokeAdmin(""), ErrEmptyUserName)
+	_, err := c.ShowGrants("")
+	require.ErrorIs(t, err, ErrEmptyUserName)
+}
@@ -71,6 +71,8 @@ func (c *client) WritePointWithRp(database string, rp string, point *Point, call
 				}
 				value = actual
 			}
+			// counted before the send, otherwise the consumer may decrement first and the gauge turns negative
+			c.metrics.batchQueueDepth.WithLabelValues(database, rp).Inc()
 			value <- &sendBatchWithCB{
 				point:    point,
 				callback: callback,
@@ -108,6 +110,9 @@ func (c *client) encodePoint(point *Point) (*bytes.Buffer, error) {
 	if err := enc.Encode(point); err != nil {
 		return nil, errors.New("encode failed, error: " + err.Error())
 	}
+	if err := closeWriter(writer); err != nil {
+		return nil, errors.New("encode failed, error: " + err.Error())
+	}
 
 	return &buffer, nil
 }
@@ -120,6 +125,9 @@ func (c *client) encodeBatchPoints(bp []*Point) (*bytes.Buffer, error) {
 	if err := enc.BatchEncode(bp); err != nil {
 		return nil, errors.New("batchEncode failed, error: " + err.Error())
 	}
+	if err := closeWriter(writer); err != nil {
+		return nil, errors.New("batchEncode failed, error: " + err.Error())
+	}
 
 	return &buffer, nil
 }
@@ -146,25 +154,31 @@ func (c *client) internalBatchSend(ctx context.Context, database string, rp stri
 	var ticker = time.NewTicker(tickInterval)
 	var points = make([]*Point, 0, c.config.BatchConfig.BatchSize)
 	var cbs []WriteCallback
+	var queueDepth = c.metrics.batchQueueDepth.WithLabelValues(database, rp)
 	needFlush := false
 	for {
 		select {
 		case <-ctx.Done():
 			ticker.Stop()
 			for record := range resource {
+				queueDepth.Dec()
 				record.callback(fmt.Errorf("send batch context cancelled"))
 			}
 			return
 		case <-ticker.C:
 			needFlush = true
 		case record := <-resource:
+			queueDepth.Dec()
 			points = append(points, record.point)
 			cbs = append(cbs, record.callback)
 		}
 		if len(points) >= c.config.BatchConfig.BatchSize || needFlush {
-			err := c.WriteBatchPointsWithRp(ctx, database, rp, points)
-			for _, callback := range cbs {
-				callback(err)
+			// the idle tick flushes nothing, it mustn't be observed as a batch flush
+			if len(points) > 0 {
+				err := c.flushBatch(ctx, database, rp, points)
+				for _, callback := range cbs {
+					callback(err)
+				}
 			}
 			needFlush = false
 			ticker.Reset(tickInterval)
@@ -182,6 +196,14 @@ func (c *client) newWriter(buffer *bytes.Buffer) io.Writer {
 	}
 }
 
+// closeWriter flush the trailer of compressed stream, the server can't decode the body without it
+func closeWriter(writer io.Writer) error {
+	if closer, ok := writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 func (c *client) innerWrite(ctx context.Context, database string, rp string, buffer *bytes.Buffer) (*http.Response, error) {
 	req := requestDetails{
 		queryValues: make(url.Values),
@@ -207,3 +229,22 @@ func (c *client) innerWrite(ctx context.Context, database string, rp string, buf
 
 	return response, err
 }
+
+// flushBatch write the points of batch queue, the interceptors implementing BatchInterceptor observe the flush
+func (c *client) flushBatch(ctx context.Context, database string, rp string, points []*Point) error {
+	var closures []BatchInterceptorClosure
+	flush := &InterceptorBatchFlush{Database: database, RetentionPolicy: rp, Points: len(points)}
+	for _, interceptor := range c.interceptors {
+		if batchInterceptor, ok := interceptor.(BatchInterceptor); ok {
+			var closure BatchInterceptorClosure
+			ctx, closure = batchInterceptor.BatchFlush(ctx, flush)
+			closures = append(closures, closure)
+		}
+	}
+
+	err := c.WriteBatchPointsWithRp(ctx, database, rp, points)
+	for _, fn := range closures {
+		fn(ctx, err)
+	}
+	return err
+}
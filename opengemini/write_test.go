@@ -15,7 +15,11 @@
 package opengemini
 
 import (
+	"compress/gzip"
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -343,3 +347,26 @@ func TestWriteWithBatchSize(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteWithGzip(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "gzip", request.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(request.Body)
+		require.Nil(t, err)
+		body, err := io.ReadAll(reader)
+		require.Nil(t, err)
+		bodies = append(bodies, string(body))
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := testNewClient(t, &Config{
+		Addresses:      []Address{testServerAddress(t, server.URL)},
+		CompressMethod: CompressMethodGzip,
+	})
+
+	point := &Point{Measurement: "cpu", Fields: map[string]any{"v": 1}, Timestamp: 1}
+	require.Nil(t, c.WriteBatchPoints(context.Background(), "db0", []*Point{point, point}))
+	require.Equal(t, []string{"cpu v=1i 1\ncpu v=1i 1\n"}, bodies)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	_ Writer = (*httpWriter)(nil)
+	_ Writer = (*grpcWriter)(nil)
+)
+
+func (c *client) Writer() Writer {
+	if c.config.WriteTransport == WriteTransportGrpc {
+		return &grpcWriter{client: c}
+	}
+	return &httpWriter{client: c}
+}
+
+// httpWriter writes the points as line protocol by WriteBatchPointsWithRp
+type httpWriter struct {
+	client *client
+}
+
+func (w *httpWriter) WritePoints(ctx context.Context, database string, rp string, points []*Point) error {
+	return w.client.WriteBatchPointsWithRp(ctx, database, rp, nanosecondPoints(points))
+}
+
+// nanosecondPoints convert the timestamps to nanoseconds as the records by gRPC, the line protocol is written without
+// precision. The points in other precisions are copied, so that the points of caller are unchanged
+func nanosecondPoints(points []*Point) []*Point {
+	var converted []*Point
+	for i, point := range points {
+		if point == nil || point.Precision == PrecisionNanosecond || point.Precision == PrecisionRFC3339 {
+			continue
+		}
+		if converted == nil {
+			converted = append([]*Point(nil), points...)
+		}
+		copied := *point
+		copied.Timestamp = toNanoseconds(point.Timestamp, point.Precision)
+		copied.Precision = PrecisionNanosecond
+		converted[i] = &copied
+	}
+	if converted == nil {
+		return points
+	}
+	return converted
+}
+
+// grpcWriter writes the points as records by WriteByGrpc, with the credentials of GrpcConfig.AuthConfig
+type grpcWriter struct {
+	client *client
+}
+
+func (w *grpcWriter) WritePoints(ctx context.Context, database string, rp string, points []*Point) error {
+	if w.client.rpcClient == nil {
+		return errors.New("write by gRPC requires GrpcConfig")
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	request, err := PointsToWriteRequest(database, rp, points)
+	if err != nil {
+		return err
+	}
+	if auth := w.client.config.GrpcConfig.AuthConfig; auth != nil && auth.AuthType == AuthTypePassword {
+		request.Username = auth.Username
+		request.Password = auth.Password
+	}
+	return w.client.WriteByGrpc(ctx, request)
+}
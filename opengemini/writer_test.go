@@ -0,0 +1,191 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opengemini
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+type testCaptureWriteServer struct {
+	proto.UnimplementedWriteServiceServer
+	requests chan *proto.WriteRequest
+}
+
+func (s *testCaptureWriteServer) Write(_ context.Context, req *proto.WriteRequest) (*proto.WriteResponse, error) {
+	s.requests <- req
+	return &proto.WriteResponse{Code: proto.ResponseCode_Success}, nil
+}
+
+func TestPointsToWriteRequest(t *testing.T) {
+	points := []*Point{
+		{
+			Measurement: "cpu",
+			Precision:   PrecisionMillisecond,
+			Timestamp:   1000,
+			Tags:        map[string]string{"host": "a"},
+			Fields:      map[string]any{"small": int8(-3), "count": uint32(7), "ratio": 0.5, "ok": true},
+		},
+		nil,
+		{
+			Measurement: "mem",
+			Timestamp:   2000,
+			Fields:      map[string]any{"free": uint64(math.MaxUint64), "note": "low"},
+		},
+	}
+	request, err := PointsToWriteRequest("db0", "rp0", points)
+	require.Nil(t, err)
+	require.Equal(t, "db0", request.Database)
+	require.Equal(t, "rp0", request.RetentionPolicy)
+	require.Len(t, request.Records, 2)
+
+	decoded, err := WriteRequestToPoints(request)
+	require.Nil(t, err)
+	byMeasurement := make(map[string]*Point)
+	for _, point := range decoded {
+		byMeasurement[point.Measurement] = point
+	}
+	require.Equal(t, &Point{
+		Measurement: "cpu",
+		Precision:   PrecisionNanosecond,
+		Timestamp:   int64(time.Second),
+		Tags:        map[string]string{"host": "a"},
+		Fields:      map[string]any{"small": int64(-3), "count": uint64(7), "ratio": 0.5, "ok": true},
+	}, byMeasurement["cpu"])
+	require.Equal(t, &Point{
+		Measurement: "mem",
+		Precision:   PrecisionNanosecond,
+		Timestamp:   2000,
+		Fields:      map[string]any{"free": uint64(math.MaxUint64), "note": "low"},
+	}, byMeasurement["mem"])
+
+	invalid := map[string][]*Point{
+		"no fields":        {{Measurement: "cpu", Tags: map[string]string{"host": "a"}}},
+		"no measurement":   {{Fields: map[string]any{"v": 1}}},
+		"float32":          {{Measurement: "cpu", Fields: map[string]any{"v": float32(0.1)}}},
+		"signed, unsigned": {{Measurement: "cpu", Fields: map[string]any{"v": 1}}, {Measurement: "cpu", Fields: map[string]any{"v": uint(1)}}},
+		"tag as field":     {{Measurement: "cpu", Tags: map[string]string{"v": "a"}, Fields: map[string]any{"v": 1}}},
+		"conflicting type": {{Measurement: "cpu", Fields: map[string]any{"v": 1.5}}, {Measurement: "cpu", Fields: map[string]any{"v": "a"}}},
+	}
+	for name, points := range invalid {
+		_, err = PointsToWriteRequest("db0", "", points)
+		require.NotNil(t, err, name)
+	}
+}
+
+func TestPointsToWriteRequestFieldTypes(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected any
+	}{
+		{value: 1, expected: int64(1)},
+		{value: int8(-8), expected: int64(-8)},
+		{value: int16(-16), expected: int64(-16)},
+		{value: int32(-32), expected: int64(-32)},
+		{value: int64(math.MinInt64), expected: int64(math.MinInt64)},
+		{value: uint(7), expected: uint64(7)},
+		{value: uint8(8), expected: uint64(8)},
+		{value: uint16(16), expected: uint64(16)},
+		{value: uint32(math.MaxUint32), expected: uint64(math.MaxUint32)},
+		{value: uint64(7), expected: uint64(7)},
+		{value: uint64(math.MaxInt64) + 1, expected: uint64(math.MaxInt64) + 1},
+		{value: uint64(math.MaxUint64), expected: uint64(math.MaxUint64)},
+		{value: 1.25, expected: 1.25},
+		{value: math.SmallestNonzeroFloat64, expected: math.SmallestNonzeroFloat64},
+		{value: true, expected: true},
+		{value: "text", expected: "text"},
+	}
+	for _, tt := range tests {
+		point := &Point{Measurement: "cpu", Timestamp: 100, Fields: map[string]any{"v": tt.value}}
+		request, err := PointsToWriteRequest("db0", "", []*Point{point})
+		require.Nil(t, err, "%T", tt.value)
+		decoded, err := WriteRequestToPoints(request)
+		require.Nil(t, err, "%T", tt.value)
+		require.Equal(t, []*Point{{
+			Measurement: "cpu",
+			Precision:   PrecisionNanosecond,
+			Timestamp:   100,
+			Fields:      map[string]any{"v": tt.expected},
+		}}, decoded, "%T", tt.value)
+	}
+
+	// the zero timestamp is the time of conversion
+	before := time.Now().UnixNano()
+	request, err := PointsToWriteRequest("db0", "", []*Point{{Measurement: "cpu", Fields: map[string]any{"v": 1}}})
+	require.Nil(t, err)
+	decoded, err := WriteRequestToPoints(request)
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, decoded[0].Timestamp, before)
+	require.LessOrEqual(t, decoded[0].Timestamp, time.Now().UnixNano())
+}
+
+func TestClientWriter(t *testing.T) {
+	bodies := make(chan string, 1)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		bodies <- string(body)
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpServer.Close()
+	address := testServerAddress(t, httpServer.URL)
+	grpcServer := &testCaptureWriteServer{requests: make(chan *proto.WriteRequest, 1)}
+	grpcConfig := &GrpcConfig{
+		Addresses:  []Address{testStartGrpcServer(t, grpcServer)},
+		AuthConfig: &AuthConfig{AuthType: AuthTypePassword, Username: "user", Password: "pass"},
+	}
+
+	point := &Point{Measurement: "cpu", Precision: PrecisionSecond, Timestamp: 1, Fields: map[string]any{"v": 1.5}}
+	ctx := context.Background()
+
+	httpClient := testNewClient(t, &Config{Addresses: []Address{address}, GrpcConfig: grpcConfig})
+	defer httpClient.Close()
+	require.Nil(t, httpClient.Writer().WritePoints(ctx, "db0", "", []*Point{point}))
+	require.Equal(t, "cpu v=1.5 1000000000\n", <-bodies)
+	// the point of caller isn't converted in place
+	require.Equal(t, int64(1), point.Timestamp)
+
+	grpcClient := testNewClient(t, &Config{
+		Addresses:      []Address{address},
+		GrpcConfig:     grpcConfig,
+		WriteTransport: WriteTransportGrpc,
+	})
+	defer grpcClient.Close()
+	require.Nil(t, grpcClient.Writer().WritePoints(ctx, "db0", "", []*Point{point}))
+	request := <-grpcServer.requests
+	require.Equal(t, "user", request.Username)
+	require.Equal(t, "pass", request.Password)
+	decoded, err := WriteRequestToPoints(request)
+	require.Nil(t, err)
+	require.Equal(t, []*Point{{
+		Measurement: "cpu",
+		Precision:   PrecisionNanosecond,
+		Timestamp:   int64(time.Second),
+		Fields:      map[string]any{"v": 1.5},
+	}}, decoded)
+
+	_, err = newClient(&Config{Addresses: []Address{address}, WriteTransport: WriteTransportGrpc})
+	require.NotNil(t, err)
+	_, err = newClient(&Config{Addresses: []Address{address}, WriteTransport: "UDP"})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,409 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelexporter implement the metric.Exporter of OpenTelemetry SDK by the openGemini client, so that the
+// services push the metrics into openGemini without a collector
+//
+//	exporter, err := otelexporter.New(client, &otelexporter.Config{Database: "otel"})
+//	provider := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exporter)))
+//
+// Each data point is written as a point of the measurement named by NamingScheme, the attributes are tags. The
+// fields depend on the aggregation
+//
+//	Gauge, Sum             value
+//	Histogram              count, sum, min, max and the cumulative bucket counts le_<bound>, le_+Inf
+//	ExponentialHistogram   count, sum, min, max, scale, zero_count and the bucket counts positive_<index>,
+//	                       negative_<index>, the lower bound of bucket index is base^index where base is 2^(2^-scale)
+//	Summary                count, sum and the quantiles quantile_<quantile>
+package otelexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+var ErrShutdown = errors.New("exporter is shut down")
+
+// WriteMode the write path of exporter
+type WriteMode int
+
+const (
+	// WriteModeHTTP write the points of each export by WriteBatchPointsWithRp
+	WriteModeHTTP WriteMode = iota
+	// WriteModeBatch write the points by WritePointWithRp, they are batched with the other writes of client by
+	// Config.BatchConfig, the export waits for the callbacks
+	WriteModeBatch
+	// WriteModeGrpc write the points as records by WriteByGrpc, Config.GrpcConfig of client is required
+	WriteModeGrpc
+)
+
+// NamingScheme name the measurements, fields and tags
+type NamingScheme interface {
+	// Measurement name the measurement of metric
+	Measurement(scope instrumentation.Scope, metric metricdata.Metrics) string
+	// Field name the field, field is one of the names listed in package document such as value, count and le_0.5
+	Field(metric metricdata.Metrics, field string) string
+	// Tag name the tag of attribute
+	Tag(key attribute.Key) string
+}
+
+// DefaultNaming keep the metric name, fields and attribute keys as is, such as http.server.request.duration
+var DefaultNaming NamingScheme = defaultNaming{}
+
+// UnderscoreNaming replace the characters which are not letter, digit or underscore with underscore in measurement
+// and tag, such as http_server_request_duration, it is easier to query without quoting
+var UnderscoreNaming NamingScheme = underscoreNaming{}
+
+type defaultNaming struct{}
+
+func (defaultNaming) Measurement(_ instrumentation.Scope, metric metricdata.Metrics) string {
+	return metric.Name
+}
+
+func (defaultNaming) Field(_ metricdata.Metrics, field string) string {
+	return field
+}
+
+func (defaultNaming) Tag(key attribute.Key) string {
+	return string(key)
+}
+
+type underscoreNaming struct{}
+
+func (underscoreNaming) Measurement(_ instrumentation.Scope, metric metricdata.Metrics) string {
+	return underscore(metric.Name)
+}
+
+func (underscoreNaming) Field(_ metricdata.Metrics, field string) string {
+	return field
+}
+
+func (underscoreNaming) Tag(key attribute.Key) string {
+	return underscore(string(key))
+}
+
+func underscore(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+type Config struct {
+	// Database the database to write, it is required
+	Database string
+	// RetentionPolicy the retention policy to write, use default retention policy if empty
+	RetentionPolicy string
+	// WriteMode the write path, default is WriteModeHTTP
+	WriteMode WriteMode
+	// Naming name the measurements, fields and tags, default is DefaultNaming
+	Naming NamingScheme
+	// ResourceAttributes the keys of resource attributes which are added as tags, such as service.name
+	ResourceAttributes []attribute.Key
+	// TemporalitySelector default is metric.DefaultTemporalitySelector, which is cumulative
+	TemporalitySelector metric.TemporalitySelector
+	// AggregationSelector default is metric.DefaultAggregationSelector
+	AggregationSelector metric.AggregationSelector
+}
+
+type exporter struct {
+	client opengemini.Client
+	config Config
+
+	mu       sync.RWMutex
+	shutdown bool
+}
+
+// New create the metric.Exporter writing to openGemini, the client is not closed by Shutdown
+func New(client opengemini.Client, config *Config) (metric.Exporter, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	if config == nil || config.Database == "" {
+		return nil, opengemini.ErrEmptyDatabaseName
+	}
+	e := &exporter{client: client, config: *config}
+	if e.config.Naming == nil {
+		e.config.Naming = DefaultNaming
+	}
+	if e.config.TemporalitySelector == nil {
+		e.config.TemporalitySelector = metric.DefaultTemporalitySelector
+	}
+	if e.config.AggregationSelector == nil {
+		e.config.AggregationSelector = metric.DefaultAggregationSelector
+	}
+	return e, nil
+}
+
+func (e *exporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return e.config.TemporalitySelector(kind)
+}
+
+func (e *exporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return e.config.AggregationSelector(kind)
+}
+
+func (e *exporter) Export(ctx context.Context, metrics *metricdata.ResourceMetrics) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.shutdown {
+		return ErrShutdown
+	}
+
+	points, err := ToPoints(metrics, e.config.Naming, e.config.ResourceAttributes)
+	if err != nil || len(points) == 0 {
+		return err
+	}
+	switch e.config.WriteMode {
+	case WriteModeBatch:
+		return e.writeBatch(ctx, points)
+	case WriteModeGrpc:
+		return e.writeGrpc(ctx, points)
+	default:
+		return e.client.WriteBatchPointsWithRp(ctx, e.config.Database, e.config.RetentionPolicy, points)
+	}
+}
+
+func (e *exporter) writeBatch(ctx context.Context, points []*opengemini.Point) error {
+	results := make(chan error, len(points))
+	for i, point := range points {
+		err := e.client.WritePointWithRp(e.config.Database, e.config.RetentionPolicy, point, func(err error) {
+			results <- err
+		})
+		if err != nil {
+			// the points before are queued already, don't wait for them
+			return fmt.Errorf("write point %d: %w", i, err)
+		}
+	}
+
+	var errs []error
+	for range points {
+		select {
+		case err := <-results:
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *exporter) writeGrpc(ctx context.Context, points []*opengemini.Point) error {
+	request, err := opengemini.PointsToWriteRequest(e.config.Database, e.config.RetentionPolicy, points)
+	if err != nil {
+		return err
+	}
+	return e.client.WriteByGrpc(ctx, request)
+}
+
+// ForceFlush has nothing to flush, the points are written in Export
+func (e *exporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (e *exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return ctx.Err()
+}
+
+// ToPoints convert the metrics into points, see the package document for the fields of each aggregation
+func ToPoints(metrics *metricdata.ResourceMetrics, naming NamingScheme, resourceAttributes []attribute.Key) (
+	[]*opengemini.Point, error) {
+	if naming == nil {
+		naming = DefaultNaming
+	}
+	resourceTags := make(map[string]string, len(resourceAttributes))
+	for _, key := range resourceAttributes {
+		if value, ok := lookupResource(metrics.Resource, key); ok {
+			resourceTags[naming.Tag(key)] = value
+		}
+	}
+
+	var points []*opengemini.Point
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			c := &converter{
+				naming:       naming,
+				metric:       m,
+				measurement:  naming.Measurement(scope.Scope, m),
+				resourceTags: resourceTags,
+			}
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				points = append(points, convertDataPoints(c, data.DataPoints)...)
+			case metricdata.Gauge[float64]:
+				points = append(points, convertDataPoints(c, data.DataPoints)...)
+			case metricdata.Sum[int64]:
+				points = append(points, convertDataPoints(c, data.DataPoints)...)
+			case metricdata.Sum[float64]:
+				points = append(points, convertDataPoints(c, data.DataPoints)...)
+			case metricdata.Histogram[int64]:
+				points = append(points, convertHistogram(c, data.DataPoints)...)
+			case metricdata.Histogram[float64]:
+				points = append(points, convertHistogram(c, data.DataPoints)...)
+			case metricdata.ExponentialHistogram[int64]:
+				points = append(points, convertExponentialHistogram(c, data.DataPoints)...)
+			case metricdata.ExponentialHistogram[float64]:
+				points = append(points, convertExponentialHistogram(c, data.DataPoints)...)
+			case metricdata.Summary:
+				points = append(points, convertSummary(c, data.DataPoints)...)
+			default:
+				return nil, fmt.Errorf("metric %s: unsupported aggregation %T", m.Name, m.Data)
+			}
+		}
+	}
+	return points, nil
+}
+
+func lookupResource(res *resource.Resource, key attribute.Key) (string, bool) {
+	if res == nil {
+		return "", false
+	}
+	value, ok := res.Set().Value(key)
+	if !ok {
+		return "", false
+	}
+	return value.Emit(), true
+}
+
+type converter struct {
+	naming       NamingScheme
+	metric       metricdata.Metrics
+	measurement  string
+	resourceTags map[string]string
+}
+
+func (c *converter) newPoint(attributes attribute.Set, timestamp int64) *opengemini.Point {
+	tags := make(map[string]string, attributes.Len()+len(c.resourceTags))
+	for key, value := range c.resourceTags {
+		tags[key] = value
+	}
+	iterator := attributes.Iter()
+	for iterator.Next() {
+		kv := iterator.Attribute()
+		if value := kv.Value.Emit(); value != "" {
+			tags[c.naming.Tag(kv.Key)] = value
+		}
+	}
+	return &opengemini.Point{
+		Measurement: c.measurement,
+		Tags:        tags,
+		Fields:      make(map[string]any),
+		Timestamp:   timestamp,
+	}
+}
+
+func (c *converter) setField(point *opengemini.Point, field string, value any) {
+	// openGemini has no unsigned integer, the counts don't overflow int64 in practice
+	if v, ok := value.(uint64); ok {
+		value = int64(v)
+	}
+	point.Fields[c.naming.Field(c.metric, field)] = value
+}
+
+func convertDataPoints[N int64 | float64](c *converter, dataPoints []metricdata.DataPoint[N]) []*opengemini.Point {
+	points := make([]*opengemini.Point, 0, len(dataPoints))
+	for _, dp := range dataPoints {
+		point := c.newPoint(dp.Attributes, dp.Time.UnixNano())
+		c.setField(point, "value", dp.Value)
+		points = append(points, point)
+	}
+	return points
+}
+
+func setExtrema[N int64 | float64](c *converter, point *opengemini.Point, field string, extrema metricdata.Extrema[N]) {
+	if value, ok := extrema.Value(); ok {
+		c.setField(point, field, value)
+	}
+}
+
+func convertHistogram[N int64 | float64](c *converter, dataPoints []metricdata.HistogramDataPoint[N]) []*opengemini.Point {
+	points := make([]*opengemini.Point, 0, len(dataPoints))
+	for _, dp := range dataPoints {
+		point := c.newPoint(dp.Attributes, dp.Time.UnixNano())
+		c.setField(point, "count", dp.Count)
+		c.setField(point, "sum", dp.Sum)
+		setExtrema(c, point, "min", dp.Min)
+		setExtrema(c, point, "max", dp.Max)
+		var cumulative uint64
+		for i, count := range dp.BucketCounts {
+			cumulative += count
+			bound := "+Inf"
+			if i < len(dp.Bounds) {
+				bound = strconv.FormatFloat(dp.Bounds[i], 'g', -1, 64)
+			}
+			c.setField(point, "le_"+bound, cumulative)
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+func convertExponentialHistogram[N int64 | float64](c *converter,
+	dataPoints []metricdata.ExponentialHistogramDataPoint[N]) []*opengemini.Point {
+	points := make([]*opengemini.Point, 0, len(dataPoints))
+	for _, dp := range dataPoints {
+		point := c.newPoint(dp.Attributes, dp.Time.UnixNano())
+		c.setField(point, "count", dp.Count)
+		c.setField(point, "sum", dp.Sum)
+		setExtrema(c, point, "min", dp.Min)
+		setExtrema(c, point, "max", dp.Max)
+		c.setField(point, "scale", int64(dp.Scale))
+		c.setField(point, "zero_count", dp.ZeroCount)
+		for i, count := range dp.PositiveBucket.Counts {
+			if count > 0 {
+				c.setField(point, "positive_"+strconv.Itoa(int(dp.PositiveBucket.Offset)+i), count)
+			}
+		}
+		for i, count := range dp.NegativeBucket.Counts {
+			if count > 0 {
+				c.setField(point, "negative_"+strconv.Itoa(int(dp.NegativeBucket.Offset)+i), count)
+			}
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+func convertSummary(c *converter, dataPoints []metricdata.SummaryDataPoint) []*opengemini.Point {
+	points := make([]*opengemini.Point, 0, len(dataPoints))
+	for _, dp := range dataPoints {
+		point := c.newPoint(dp.Attributes, dp.Time.UnixNano())
+		c.setField(point, "count", dp.Count)
+		c.setField(point, "sum", dp.Sum)
+		for _, quantile := range dp.QuantileValues {
+			c.setField(point, "quantile_"+strconv.FormatFloat(quantile.Quantile, 'g', -1, 64), quantile.Value)
+		}
+		points = append(points, point)
+	}
+	return points
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+var testTime = time.Unix(1735689600, 0)
+
+func testMetrics() *metricdata.ResourceMetrics {
+	attrs := attribute.NewSet(attribute.String("http.route", "/api"), attribute.Int("code", 200))
+	return &metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(attribute.String("service.name", "checkout"), attribute.String("host", "a")),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "test"},
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "http.requests",
+					Data: metricdata.Sum[int64]{
+						DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Time: testTime, Value: 3}},
+						Temporality: metricdata.CumulativeTemporality,
+						IsMonotonic: true,
+					},
+				},
+				{
+					Name: "cpu.usage",
+					Data: metricdata.Gauge[float64]{
+						DataPoints: []metricdata.DataPoint[float64]{{Time: testTime, Value: 0.5}},
+					},
+				},
+				{
+					Name: "http.duration",
+					Data: metricdata.Histogram[float64]{
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							Attributes:   attrs,
+							Time:         testTime,
+							Count:        4,
+							Bounds:       []float64{0.1, 1},
+							BucketCounts: []uint64{1, 2, 1},
+							Min:          metricdata.NewExtrema(0.05),
+							Max:          metricdata.NewExtrema(2.0),
+							Sum:          3.5,
+						}},
+					},
+				},
+				{
+					Name: "rpc.size",
+					Data: metricdata.ExponentialHistogram[int64]{
+						DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{
+							Time:           testTime,
+							Count:          3,
+							Sum:            30,
+							Scale:          1,
+							ZeroCount:      1,
+							PositiveBucket: metricdata.ExponentialBucket{Offset: 5, Counts: []uint64{1, 0, 1}},
+						}},
+					},
+				},
+			},
+		}},
+	}
+}
+
+func TestToPoints(t *testing.T) {
+	points, err := ToPoints(testMetrics(), UnderscoreNaming, []attribute.Key{"service.name", "missing"})
+	require.Nil(t, err)
+	require.Equal(t, []*opengemini.Point{
+		{
+			Measurement: "http_requests",
+			Tags:        map[string]string{"service_name": "checkout", "http_route": "/api", "code": "200"},
+			Fields:      map[string]any{"value": int64(3)},
+			Timestamp:   testTime.UnixNano(),
+		},
+		{
+			Measurement: "cpu_usage",
+			Tags:        map[string]string{"service_name": "checkout"},
+			Fields:      map[string]any{"value": 0.5},
+			Timestamp:   testTime.UnixNano(),
+		},
+		{
+			Measurement: "http_duration",
+			Tags:        map[string]string{"service_name": "checkout", "http_route": "/api", "code": "200"},
+			Fields: map[string]any{"count": int64(4), "sum": 3.5, "min": 0.05, "max": 2.0,
+				"le_0.1": int64(1), "le_1": int64(3), "le_+Inf": int64(4)},
+			Timestamp: testTime.UnixNano(),
+		},
+		{
+			Measurement: "rpc_size",
+			Tags:        map[string]string{"service_name": "checkout"},
+			Fields: map[string]any{"count": int64(3), "sum": int64(30), "scale": int64(1), "zero_count": int64(1),
+				"positive_5": int64(1), "positive_7": int64(1)},
+			Timestamp: testTime.UnixNano(),
+		},
+	}, points)
+}
+
+// testClient start a fake openGemini server which records the lines of write requests
+func testClient(t *testing.T, config *opengemini.Config) (opengemini.Client, func() []string) {
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		require.Nil(t, err)
+		lines = append(lines, strings.Split(strings.TrimSpace(string(body)), "\n")...)
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.Nil(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.Nil(t, err)
+	config.Addresses = []opengemini.Address{{Host: u.Hostname(), Port: port}}
+	client, err := opengemini.NewClient(config)
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, func() []string {
+		sort.Strings(lines)
+		return lines
+	}
+}
+
+func TestExportWithMeterProvider(t *testing.T) {
+	for _, mode := range []WriteMode{WriteModeHTTP, WriteModeBatch} {
+		config := &opengemini.Config{}
+		if mode == WriteModeBatch {
+			config.BatchConfig = &opengemini.BatchConfig{BatchSize: 10, BatchInterval: 10 * time.Millisecond}
+		}
+		client, lines := testClient(t, config)
+		exporter, err := New(client, &Config{Database: "otel", WriteMode: mode})
+		require.Nil(t, err)
+
+		reader := metric.NewPeriodicReader(exporter, metric.WithInterval(time.Hour))
+		provider := metric.NewMeterProvider(metric.WithReader(reader), metric.WithResource(resource.Empty()))
+		counter, err := provider.Meter("test").Int64Counter("requests")
+		require.Nil(t, err)
+		counter.Add(context.Background(), 2, metricAttributes("path", "/a"))
+		counter.Add(context.Background(), 1, metricAttributes("path", "/b"))
+
+		require.Nil(t, provider.ForceFlush(context.Background()))
+		require.Len(t, lines(), 2)
+		require.True(t, strings.HasPrefix(lines()[0], `requests,path=/a value=2i `), lines()[0])
+		require.True(t, strings.HasPrefix(lines()[1], `requests,path=/b value=1i `), lines()[1])
+
+		require.Nil(t, provider.Shutdown(context.Background()))
+		require.ErrorIs(t, exporter.Export(context.Background(), testMetrics()), ErrShutdown)
+	}
+}
+
+func TestNewExporter(t *testing.T) {
+	client, _ := testClient(t, &opengemini.Config{})
+	_, err := New(client, &Config{})
+	require.ErrorIs(t, err, opengemini.ErrEmptyDatabaseName)
+	_, err = New(nil, &Config{Database: "otel"})
+	require.NotNil(t, err)
+
+	exporter, err := New(client, &Config{Database: "otel"})
+	require.Nil(t, err)
+	require.Equal(t, metricdata.CumulativeTemporality, exporter.Temporality(metric.InstrumentKindCounter))
+	require.Equal(t, metric.DefaultAggregationSelector(metric.InstrumentKindHistogram),
+		exporter.Aggregation(metric.InstrumentKindHistogram))
+}
+
+func metricAttributes(key, value string) otelmetric.MeasurementOption {
+	return otelmetric.WithAttributes(attribute.String(key, value))
+}
@@ -0,0 +1,390 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package promremote serve Prometheus remote_write and remote_read by the openGemini client, so that openGemini is
+// the long-term storage of Prometheus
+//
+//	adapter, err := promremote.NewAdapter(client, &promremote.Config{Database: "prometheus"})
+//	http.HandleFunc("/api/v1/prom/write", adapter.HandleWrite)
+//	http.HandleFunc("/api/v1/prom/read", adapter.HandleRead)
+//
+// A series is stored as the measurement named by `__name__`, the other labels are tags and the sample value is the
+// field named by Config.Field
+package promremote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const (
+	// LabelMetricName the label of metric name, it is mapped to measurement
+	LabelMetricName = "__name__"
+	// DefaultField the default field of sample value
+	DefaultField = "value"
+	// DefaultMaxRequestSize the default limit of compressed request body
+	DefaultMaxRequestSize = 32 << 20
+)
+
+var (
+	ErrEmptyMetricName    = errors.New("metric name is required")
+	ErrUnsupportedMatcher = errors.New("unsupported matcher")
+	ErrMissingNameMatcher = errors.New("remote read requires a matcher of " + LabelMetricName)
+	ErrRequestTooLarge    = errors.New("request body is too large")
+)
+
+type Config struct {
+	// Database the database to store the samples, it is required
+	Database string
+	// RetentionPolicy the retention policy to store the samples, use default retention policy if empty
+	RetentionPolicy string
+	// Field the field of sample value, default is DefaultField
+	Field string
+	// UseGrpc write the samples as records by WriteByGrpc, GrpcConfig of client is required
+	UseGrpc bool
+	// MaxRequestSize the limit of compressed request body, default is DefaultMaxRequestSize
+	MaxRequestSize int64
+}
+
+// Adapter serve the remote storage protocol of Prometheus
+type Adapter interface {
+	// HandleWrite serve remote_write, the snappy compressed WriteRequest is written to openGemini
+	HandleWrite(w http.ResponseWriter, r *http.Request)
+	// HandleRead serve remote_read, each query is translated into a `SELECT` statement and the result is returned as
+	// samples
+	HandleRead(w http.ResponseWriter, r *http.Request)
+}
+
+type adapter struct {
+	client opengemini.Client
+	config Config
+}
+
+func NewAdapter(client opengemini.Client, config *Config) (Adapter, error) {
+	if client == nil {
+		return nil, errors.New("client is required")
+	}
+	if config == nil || config.Database == "" {
+		return nil, opengemini.ErrEmptyDatabaseName
+	}
+	a := &adapter{client: client, config: *config}
+	if a.config.Field == "" {
+		a.config.Field = DefaultField
+	}
+	if a.config.MaxRequestSize <= 0 {
+		a.config.MaxRequestSize = DefaultMaxRequestSize
+	}
+	return a, nil
+}
+
+func (a *adapter) HandleWrite(w http.ResponseWriter, r *http.Request) {
+	var request WriteRequest
+	if err := a.decodeRequest(r, request.Unmarshal); err != nil {
+		// the client error is not retried by Prometheus
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if a.config.UseGrpc {
+		err = a.writeRecords(r, request.Timeseries)
+	} else {
+		var points []*opengemini.Point
+		points, err = TimeSeriesToPoints(request.Timeseries, a.config.Field)
+		if err == nil && len(points) > 0 {
+			err = a.client.WriteBatchPointsWithRp(r.Context(), a.config.Database, a.config.RetentionPolicy, points)
+		}
+	}
+	if errors.Is(err, ErrEmptyMetricName) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *adapter) writeRecords(r *http.Request, series []TimeSeries) error {
+	builder, err := opengemini.NewWriteRequestBuilder(a.config.Database, a.config.RetentionPolicy)
+	if err != nil {
+		return err
+	}
+	lines, err := TimeSeriesToRecords(series, a.config.Field)
+	if err != nil || len(lines) == 0 {
+		return err
+	}
+	request, err := builder.AddRecord(lines...).Build()
+	if err != nil {
+		return err
+	}
+	return a.client.WriteByGrpc(r.Context(), request)
+}
+
+func (a *adapter) HandleRead(w http.ResponseWriter, r *http.Request) {
+	var request ReadRequest
+	if err := a.decodeRequest(r, request.Unmarshal); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := ReadResponse{Results: make([]QueryResult, 0, len(request.Queries))}
+	for _, query := range request.Queries {
+		builder, err := QueryToBuilder(query, a.config.Field)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		q := builder.Build()
+		q.Database = a.config.Database
+		q.RetentionPolicy = a.config.RetentionPolicy
+		q.Precision = opengemini.PrecisionMillisecond
+		result, err := a.client.Query(*q)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		series, err := QueryResultToTimeSeries(result, a.config.Field)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response.Results = append(response.Results, QueryResult{Timeseries: series})
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Encoding", "snappy")
+	_, _ = w.Write(snappy.Encode(nil, response.Marshal()))
+}
+
+// decodeRequest read the snappy block compressed protobuf body
+func (a *adapter) decodeRequest(r *http.Request, unmarshal func([]byte) error) error {
+	compressed, err := io.ReadAll(io.LimitReader(r.Body, a.config.MaxRequestSize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(compressed)) > a.config.MaxRequestSize {
+		return ErrRequestTooLarge
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return fmt.Errorf("decode snappy: %w", err)
+	}
+	return unmarshal(data)
+}
+
+// TimeSeriesToPoints convert the samples into points, the NaN and Inf samples such as the stale markers are dropped
+// because line protocol can't represent them
+func TimeSeriesToPoints(series []TimeSeries, field string) ([]*opengemini.Point, error) {
+	var points []*opengemini.Point
+	for _, ts := range series {
+		measurement, tags, err := splitLabels(ts.Labels)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range ts.Samples {
+			if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+				continue
+			}
+			points = append(points, &opengemini.Point{
+				Measurement: measurement,
+				Tags:        tags,
+				Fields:      map[string]any{field: sample.Value},
+				Timestamp:   sample.Timestamp * int64(time.Millisecond),
+			})
+		}
+	}
+	return points, nil
+}
+
+// TimeSeriesToRecords convert the samples into the record lines of gRPC write, the same as TimeSeriesToPoints
+func TimeSeriesToRecords(series []TimeSeries, field string) ([]opengemini.RecordLine, error) {
+	var lines []opengemini.RecordLine
+	for _, ts := range series {
+		measurement, tags, err := splitLabels(ts.Labels)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range ts.Samples {
+			if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+				continue
+			}
+			builder, err := opengemini.NewRecordBuilder(measurement)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, builder.AddTags(tags).AddField(field, sample.Value).
+				Build(sample.Timestamp*int64(time.Millisecond)))
+		}
+	}
+	return lines, nil
+}
+
+// splitLabels map `__name__` to measurement and the other labels to tags, the empty label is the same as absent in
+// Prometheus so that it is not stored
+func splitLabels(labels []Label) (string, map[string]string, error) {
+	var measurement string
+	tags := make(map[string]string, len(labels))
+	for _, label := range labels {
+		switch {
+		case label.Name == LabelMetricName:
+			measurement = label.Value
+		case label.Value != "":
+			tags[label.Name] = label.Value
+		}
+	}
+	if measurement == "" {
+		return "", nil, ErrEmptyMetricName
+	}
+	return measurement, tags, nil
+}
+
+// QueryToBuilder translate the matchers into `SELECT "value" FROM "metric" WHERE (...) GROUP BY *`, the regex matchers
+// are anchored as Prometheus does, the matcher of `__name__` must be equal or regex
+func QueryToBuilder(query Query, field string) (*opengemini.QueryBuilder, error) {
+	builder := opengemini.CreateQueryBuilder().Select(opengemini.NewFieldExpression(field))
+	conditions := []opengemini.Condition{
+		opengemini.NewTimeCondition(opengemini.GreaterThanOrEquals, time.UnixMilli(query.StartTimestampMs)),
+		opengemini.NewTimeCondition(opengemini.LessThanOrEquals, time.UnixMilli(query.EndTimestampMs)),
+	}
+	var hasName bool
+	for _, matcher := range query.Matchers {
+		if matcher.Name == LabelMetricName {
+			switch matcher.Type {
+			case MatchEqual:
+				builder.From(matcher.Value)
+			case MatchRegexp:
+				builder.FromRegex(anchorRegex(matcher.Value))
+			default:
+				return nil, fmt.Errorf("%w: %s%s%q", ErrUnsupportedMatcher, matcher.Name, matcher.Type, matcher.Value)
+			}
+			hasName = true
+			continue
+		}
+
+		var operator opengemini.ComparisonOperator
+		value := matcher.Value
+		switch matcher.Type {
+		case MatchEqual:
+			operator = opengemini.Equals
+		case MatchNotEqual:
+			operator = opengemini.NotEquals
+		case MatchRegexp:
+			operator, value = opengemini.Match, anchorRegex(value)
+		case MatchNotRegexp:
+			operator, value = opengemini.NotMatch, anchorRegex(value)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMatcher, matcher.Type)
+		}
+		conditions = append(conditions, opengemini.NewComparisonCondition(matcher.Name, operator, value))
+	}
+	if !hasName {
+		return nil, ErrMissingNameMatcher
+	}
+	return builder.Where(opengemini.NewCompositeCondition(opengemini.And, conditions...)).
+		GroupBy(opengemini.NewStarExpression()), nil
+}
+
+func anchorRegex(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+// QueryResultToTimeSeries convert the series of `SELECT` grouped by tags into Prometheus series, the time column must
+// be in milliseconds
+func QueryResultToTimeSeries(result *opengemini.QueryResult, field string) ([]TimeSeries, error) {
+	if result.Error != "" {
+		return nil, errors.New(result.Error)
+	}
+	var series []TimeSeries
+	for _, seriesResult := range result.Results {
+		if seriesResult.Error != "" {
+			return nil, errors.New(seriesResult.Error)
+		}
+		for _, s := range seriesResult.Series {
+			timeIndex, valueIndex := -1, -1
+			for i, column := range s.Columns {
+				switch column {
+				case "time":
+					timeIndex = i
+				case field:
+					valueIndex = i
+				}
+			}
+			if timeIndex < 0 || valueIndex < 0 {
+				return nil, fmt.Errorf("series %s has no column time or %s", s.Name, field)
+			}
+
+			ts := TimeSeries{Labels: make([]Label, 0, len(s.Tags)+1)}
+			ts.Labels = append(ts.Labels, Label{Name: LabelMetricName, Value: s.Name})
+			for name, value := range s.Tags {
+				if value != "" {
+					ts.Labels = append(ts.Labels, Label{Name: name, Value: value})
+				}
+			}
+			sort.Slice(ts.Labels, func(i, j int) bool { return ts.Labels[i].Name < ts.Labels[j].Name })
+			for _, row := range s.Values {
+				if row[valueIndex] == nil {
+					continue
+				}
+				timestamp, err := toFloat(row[timeIndex])
+				if err != nil {
+					return nil, fmt.Errorf("series %s time: %w", s.Name, err)
+				}
+				value, err := toFloat(row[valueIndex])
+				if err != nil {
+					return nil, fmt.Errorf("series %s %s: %w", s.Name, field, err)
+				}
+				ts.Samples = append(ts.Samples, Sample{Value: value, Timestamp: int64(timestamp)})
+			}
+			series = append(series, ts)
+		}
+	}
+	return series, nil
+}
+
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unsupported value %v of type %T", value, value)
+	}
+}
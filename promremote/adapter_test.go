@@ -0,0 +1,164 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promremote
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+// testAdapter start a fake openGemini server which records the requests and answers the queries by response
+func testAdapter(t *testing.T, response string) (Adapter, *[]*http.Request, *[]string) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		require.Nil(t, err)
+		requests = append(requests, request)
+		bodies = append(bodies, string(body))
+		if request.URL.Path == "/write" {
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.Nil(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.Nil(t, err)
+	client, err := opengemini.NewClient(&opengemini.Config{Addresses: []opengemini.Address{{Host: u.Hostname(), Port: port}}})
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	adapter, err := NewAdapter(client, &Config{Database: "prometheus"})
+	require.Nil(t, err)
+	return adapter, &requests, &bodies
+}
+
+func TestProtobufRoundTrip(t *testing.T) {
+	write := WriteRequest{Timeseries: []TimeSeries{{
+		Labels:  []Label{{Name: LabelMetricName, Value: "up"}, {Name: "job", Value: "node"}},
+		Samples: []Sample{{Value: 1, Timestamp: 1735689600000}, {Value: -0.5, Timestamp: -1}},
+	}}}
+	var decodedWrite WriteRequest
+	require.Nil(t, decodedWrite.Unmarshal(write.Marshal()))
+	require.Equal(t, write, decodedWrite)
+
+	read := ReadRequest{Queries: []Query{{
+		StartTimestampMs: 1,
+		EndTimestampMs:   2,
+		Matchers:         []LabelMatcher{{Type: MatchNotRegexp, Name: "job", Value: "n.*"}},
+	}}}
+	var decodedRead ReadRequest
+	require.Nil(t, decodedRead.Unmarshal(read.Marshal()))
+	require.Equal(t, read, decodedRead)
+
+	response := ReadResponse{Results: []QueryResult{{Timeseries: write.Timeseries}, {}}}
+	var decodedResponse ReadResponse
+	require.Nil(t, decodedResponse.Unmarshal(response.Marshal()))
+	require.Equal(t, response, decodedResponse)
+
+	require.NotNil(t, decodedWrite.Unmarshal([]byte{0x0a, 0x05, 0x01}))
+}
+
+func TestHandleWrite(t *testing.T) {
+	adapter, _, bodies := testAdapter(t, "")
+	request := WriteRequest{Timeseries: []TimeSeries{
+		{
+			Labels:  []Label{{Name: LabelMetricName, Value: "up"}, {Name: "job", Value: "node"}, {Name: "env", Value: ""}},
+			Samples: []Sample{{Value: 1, Timestamp: 1000}, {Value: math.NaN(), Timestamp: 2000}},
+		},
+	}}
+
+	recorder := httptest.NewRecorder()
+	adapter.HandleWrite(recorder, httptest.NewRequest(http.MethodPost, "/write",
+		bytes.NewReader(snappy.Encode(nil, request.Marshal()))))
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+	require.Equal(t, []string{"up,job=node value=1 1000000000\n"}, *bodies)
+
+	request.Timeseries[0].Labels = request.Timeseries[0].Labels[1:]
+	recorder = httptest.NewRecorder()
+	adapter.HandleWrite(recorder, httptest.NewRequest(http.MethodPost, "/write",
+		bytes.NewReader(snappy.Encode(nil, request.Marshal()))))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	adapter.HandleWrite(recorder, httptest.NewRequest(http.MethodPost, "/write", strings.NewReader("not snappy")))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestQueryToBuilder(t *testing.T) {
+	builder, err := QueryToBuilder(Query{
+		StartTimestampMs: 1735689600000,
+		EndTimestampMs:   1735689660000,
+		Matchers: []LabelMatcher{
+			{Type: MatchRegexp, Name: LabelMetricName, Value: "node_.*"},
+			{Type: MatchEqual, Name: "job", Value: "node"},
+			{Type: MatchNotRegexp, Name: "instance", Value: "a/b"},
+		},
+	}, DefaultField)
+	require.Nil(t, err)
+	require.Equal(t, `SELECT "value" FROM /^(?:node_.*)$/ WHERE (time >= '2025-01-01T00:00:00Z' AND `+
+		`time <= '2025-01-01T00:01:00Z' AND "job" = 'node' AND "instance" !~ /^(?:a\/b)$/) GROUP BY *`,
+		builder.Build().Command)
+
+	_, err = QueryToBuilder(Query{Matchers: []LabelMatcher{{Type: MatchEqual, Name: "job", Value: "node"}}}, DefaultField)
+	require.ErrorIs(t, err, ErrMissingNameMatcher)
+	_, err = QueryToBuilder(Query{Matchers: []LabelMatcher{{Type: MatchNotEqual, Name: LabelMetricName}}}, DefaultField)
+	require.ErrorIs(t, err, ErrUnsupportedMatcher)
+}
+
+func TestHandleRead(t *testing.T) {
+	adapter, requests, _ := testAdapter(t, `{"results":[{"statement_id":0,"series":[`+
+		`{"name":"up","tags":{"job":"node","env":""},"columns":["time","value"],"values":[[1000,1],[2000,null],[3000,0]]}`+
+		`]}]}`)
+	request := ReadRequest{Queries: []Query{{
+		StartTimestampMs: 0,
+		EndTimestampMs:   5000,
+		Matchers:         []LabelMatcher{{Type: MatchEqual, Name: LabelMetricName, Value: "up"}},
+	}}}
+
+	recorder := httptest.NewRecorder()
+	adapter.HandleRead(recorder, httptest.NewRequest(http.MethodPost, "/read",
+		bytes.NewReader(snappy.Encode(nil, request.Marshal()))))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "snappy", recorder.Header().Get("Content-Encoding"))
+	require.Equal(t, "ms", (*requests)[0].URL.Query().Get("epoch"))
+	require.Equal(t, "prometheus", (*requests)[0].URL.Query().Get("db"))
+
+	data, err := snappy.Decode(nil, recorder.Body.Bytes())
+	require.Nil(t, err)
+	var response ReadResponse
+	require.Nil(t, response.Unmarshal(data))
+	require.Equal(t, ReadResponse{Results: []QueryResult{{Timeseries: []TimeSeries{{
+		Labels:  []Label{{Name: LabelMetricName, Value: "up"}, {Name: "job", Value: "node"}},
+		Samples: []Sample{{Value: 1, Timestamp: 1000}, {Value: 0, Timestamp: 3000}},
+	}}}}}, response)
+}
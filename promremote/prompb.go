@@ -0,0 +1,370 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promremote
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of Prometheus remote storage protocol, see prompb/remote.proto and prompb/types.proto of Prometheus.
+// Only the fields used by the adapter are decoded, the others such as exemplars, histograms and metadata are skipped.
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+type Sample struct {
+	Value float64
+	// Timestamp milliseconds since epoch
+	Timestamp int64
+}
+
+type TimeSeries struct {
+	Labels  []Label
+	Samples []Sample
+}
+
+type WriteRequest struct {
+	Timeseries []TimeSeries
+}
+
+type MatchType int32
+
+const (
+	MatchEqual MatchType = iota
+	MatchNotEqual
+	MatchRegexp
+	MatchNotRegexp
+)
+
+func (m MatchType) String() string {
+	switch m {
+	case MatchEqual:
+		return "="
+	case MatchNotEqual:
+		return "!="
+	case MatchRegexp:
+		return "=~"
+	case MatchNotRegexp:
+		return "!~"
+	}
+	return fmt.Sprintf("MatchType(%d)", int32(m))
+}
+
+type LabelMatcher struct {
+	Type  MatchType
+	Name  string
+	Value string
+}
+
+type Query struct {
+	// StartTimestampMs the start of time range, inclusive
+	StartTimestampMs int64
+	// EndTimestampMs the end of time range, inclusive
+	EndTimestampMs int64
+	Matchers       []LabelMatcher
+}
+
+type ReadRequest struct {
+	Queries []Query
+}
+
+type QueryResult struct {
+	Timeseries []TimeSeries
+}
+
+type ReadResponse struct {
+	Results []QueryResult
+}
+
+var errInvalidMessage = errors.New("invalid protobuf message")
+
+// consumeFields iterate the fields of message, the unknown fields are skipped by the handler returning -1
+func consumeFields(b []byte, handle func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n, err := handle(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// consumeMessage consume a length delimited field which holds an embedded message
+func consumeMessage(typ protowire.Type, b []byte, unmarshal func([]byte) error) (int, error) {
+	if typ != protowire.BytesType {
+		return 0, errInvalidMessage
+	}
+	v, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return n, unmarshal(v)
+}
+
+func consumeString(typ protowire.Type, b []byte, s *string) (int, error) {
+	if typ != protowire.BytesType {
+		return 0, errInvalidMessage
+	}
+	v, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	*s = string(v)
+	return n, nil
+}
+
+func consumeInt64(typ protowire.Type, b []byte, i *int64) (int, error) {
+	if typ != protowire.VarintType {
+		return 0, errInvalidMessage
+	}
+	v, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	*i = int64(v)
+	return n, nil
+}
+
+func (w *WriteRequest) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 {
+			return -1, nil
+		}
+		var ts TimeSeries
+		n, err := consumeMessage(typ, b, ts.Unmarshal)
+		w.Timeseries = append(w.Timeseries, ts)
+		return n, err
+	})
+}
+
+func (w *WriteRequest) Marshal() []byte {
+	var b []byte
+	for i := range w.Timeseries {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, w.Timeseries[i].marshal(nil))
+	}
+	return b
+}
+
+func (t *TimeSeries) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			var label Label
+			n, err := consumeMessage(typ, b, label.unmarshal)
+			t.Labels = append(t.Labels, label)
+			return n, err
+		case 2:
+			var sample Sample
+			n, err := consumeMessage(typ, b, sample.unmarshal)
+			t.Samples = append(t.Samples, sample)
+			return n, err
+		default:
+			return -1, nil
+		}
+	})
+}
+
+func (t *TimeSeries) marshal(b []byte) []byte {
+	for _, label := range t.Labels {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, label.marshal(nil))
+	}
+	for _, sample := range t.Samples {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, sample.marshal(nil))
+	}
+	return b
+}
+
+func (l *Label) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			return consumeString(typ, b, &l.Name)
+		case 2:
+			return consumeString(typ, b, &l.Value)
+		default:
+			return -1, nil
+		}
+	})
+}
+
+func (l *Label) marshal(b []byte) []byte {
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, l.Name)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendString(b, l.Value)
+}
+
+func (s *Sample) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			if typ != protowire.Fixed64Type {
+				return 0, errInvalidMessage
+			}
+			v, n := protowire.ConsumeFixed64(b)
+			if n < 0 {
+				return 0, protowire.ParseError(n)
+			}
+			s.Value = math.Float64frombits(v)
+			return n, nil
+		case 2:
+			return consumeInt64(typ, b, &s.Timestamp)
+		default:
+			return -1, nil
+		}
+	})
+}
+
+func (s *Sample) marshal(b []byte) []byte {
+	b = protowire.AppendTag(b, 1, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, math.Float64bits(s.Value))
+	b = protowire.AppendTag(b, 2, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(s.Timestamp))
+}
+
+func (r *ReadRequest) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 {
+			return -1, nil
+		}
+		var query Query
+		n, err := consumeMessage(typ, b, query.unmarshal)
+		r.Queries = append(r.Queries, query)
+		return n, err
+	})
+}
+
+func (r *ReadRequest) Marshal() []byte {
+	var b []byte
+	for i := range r.Queries {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.Queries[i].marshal(nil))
+	}
+	return b
+}
+
+func (q *Query) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			return consumeInt64(typ, b, &q.StartTimestampMs)
+		case 2:
+			return consumeInt64(typ, b, &q.EndTimestampMs)
+		case 3:
+			var matcher LabelMatcher
+			n, err := consumeMessage(typ, b, matcher.unmarshal)
+			q.Matchers = append(q.Matchers, matcher)
+			return n, err
+		default:
+			return -1, nil
+		}
+	})
+}
+
+func (q *Query) marshal(b []byte) []byte {
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(q.StartTimestampMs))
+	b = protowire.AppendTag(b, 2, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(q.EndTimestampMs))
+	for _, matcher := range q.Matchers {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, matcher.marshal(nil))
+	}
+	return b
+}
+
+func (m *LabelMatcher) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			var matchType int64
+			n, err := consumeInt64(typ, b, &matchType)
+			m.Type = MatchType(matchType)
+			return n, err
+		case 2:
+			return consumeString(typ, b, &m.Name)
+		case 3:
+			return consumeString(typ, b, &m.Value)
+		default:
+			return -1, nil
+		}
+	})
+}
+
+func (m *LabelMatcher) marshal(b []byte) []byte {
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(m.Type))
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendString(b, m.Name)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	return protowire.AppendString(b, m.Value)
+}
+
+func (r *ReadResponse) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 {
+			return -1, nil
+		}
+		var result QueryResult
+		n, err := consumeMessage(typ, b, func(b []byte) error {
+			return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+				if num != 1 {
+					return -1, nil
+				}
+				var ts TimeSeries
+				n, err := consumeMessage(typ, b, ts.Unmarshal)
+				result.Timeseries = append(result.Timeseries, ts)
+				return n, err
+			})
+		})
+		r.Results = append(r.Results, result)
+		return n, err
+	})
+}
+
+func (r *ReadResponse) Marshal() []byte {
+	var b []byte
+	for _, result := range r.Results {
+		var rb []byte
+		for i := range result.Timeseries {
+			rb = protowire.AppendTag(rb, 1, protowire.BytesType)
+			rb = protowire.AppendBytes(rb, result.Timeseries[i].marshal(nil))
+		}
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, rb)
+	}
+	return b
+}
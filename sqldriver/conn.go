@@ -0,0 +1,204 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+var (
+	ErrTransactionNotSupported = errors.New("openGemini doesn't support transactions")
+	ErrLastInsertIdUnsupported = errors.New("openGemini has no auto increment id")
+)
+
+type conn struct {
+	client opengemini.Client
+	dsn    *DSN
+	// ownClient the client is created for the connection alone by Driver.Open, it is closed with the connection
+	ownClient bool
+}
+
+var (
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+)
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext keep the statement on client side, it is sent with the arguments when executed
+func (c *conn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	if c.ownClient {
+		return c.client.Close()
+	}
+	// the client is shared by the connections and closed by connector
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, ErrTransactionNotSupported
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	var err error
+	for i := range c.dsn.Config.Addresses {
+		if err = c.client.Ping(i); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// CheckNamedValue keep the values which the client can bind as parameters, the others are converted by the default
+// converter of database/sql
+func (c *conn) CheckNamedValue(value *driver.NamedValue) error {
+	if v, ok := value.Value.([]byte); ok {
+		// the params are sent as JSON, []byte would be encoded as base64
+		value.Value = string(v)
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	params := bindParams(args)
+	if c.dsn.Validate {
+		if err := opengemini.ValidateCommand(query); err != nil {
+			return nil, err
+		}
+	}
+	q := opengemini.Query{
+		Database:        c.dsn.Database,
+		RetentionPolicy: c.dsn.RetentionPolicy,
+		Command:         query,
+		Params:          params,
+		Precision:       opengemini.PrecisionNanosecond,
+	}
+	return newRows(ctx, c, q)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result, err := c.client.ExecuteContext(ctx, opengemini.Statement{
+		Database:        c.dsn.Database,
+		RetentionPolicy: c.dsn.RetentionPolicy,
+		Command:         query,
+		Params:          bindParams(args),
+		Precision:       c.dsn.Precision,
+		Validate:        c.dsn.Validate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.QueryResult != nil {
+		if err := queryResultError(result.QueryResult); err != nil {
+			return nil, err
+		}
+	}
+	return execResult(result.AffectedRows), nil
+}
+
+// bindParams name the positional argument by its ordinal so that it is referenced as `$1`
+func bindParams(args []driver.NamedValue) map[string]any {
+	if len(args) == 0 {
+		return nil
+	}
+	params := make(map[string]any, len(args))
+	for _, arg := range args {
+		name := arg.Name
+		if name == "" {
+			name = strconv.Itoa(arg.Ordinal)
+		}
+		params[name] = arg.Value
+	}
+	return params
+}
+
+func queryResultError(result *opengemini.QueryResult) error {
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+	for _, seriesResult := range result.Results {
+		if seriesResult.Error != "" {
+			return errors.New(seriesResult.Error)
+		}
+	}
+	return nil
+}
+
+type execResult int64
+
+func (r execResult) LastInsertId() (int64, error) {
+	return 0, ErrLastInsertIdUnsupported
+}
+
+func (r execResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+var (
+	_ driver.StmtQueryContext = (*stmt)(nil)
+	_ driver.StmtExecContext  = (*stmt)(nil)
+)
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput return -1 because the placeholders are checked by server
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+// testDB start a fake openGemini server which answers the queries by command, the response is written as is so
+// that a chunked response is the lines of results
+func testDB(t *testing.T, responses map[string]string) (*sql.DB, *[]*http.Request, *[]string) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		require.Nil(t, err)
+		requests = append(requests, request)
+		bodies = append(bodies, string(body))
+		switch request.URL.Path {
+		case "/ping", "/write":
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		response, ok := responses[request.URL.Query().Get("q")]
+		if !ok {
+			response = `{"results":[{"statement_id":0}]}`
+		}
+		_, _ = writer.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	db, err := sql.Open(DriverName, "opengemini://"+strings.TrimPrefix(server.URL, "http://")+"/db0?precision=s")
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, &requests, &bodies
+}
+
+func TestQueryStreamsChunks(t *testing.T) {
+	command := `SELECT * FROM "cpu" WHERE "host" = $host AND "v" > $2 GROUP BY "host"`
+	db, requests, _ := testDB(t, map[string]string{
+		command: `{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"host":"a"},` +
+			`"columns":["time","v","s"],"values":[[1000000000,0.5,"x"],[2000000000,2,null]],"partial":true}],` +
+			`"partial":true}]}` + "\n" +
+			`{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"host":"b"},` +
+			`"columns":["time","s","v"],"values":[[3000000000,"y",1.5]]}]}]}` + "\n",
+		`SHOW FIELD KEYS FROM "cpu"`: `{"results":[{"statement_id":0,"series":[{"name":"cpu",` +
+			`"columns":["fieldKey","fieldType"],"values":[["v","float"],["s","string"]]}]}]}`,
+	})
+
+	rows, err := db.QueryContext(context.Background(), command, sql.Named("host", "a"), 0.1)
+	require.Nil(t, err)
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	require.Nil(t, err)
+	require.Equal(t, []string{"host", "time", "v", "s"}, columns)
+	types, err := rows.ColumnTypes()
+	require.Nil(t, err)
+	var typeNames []string
+	for _, columnType := range types {
+		typeNames = append(typeNames, columnType.DatabaseTypeName())
+	}
+	require.Equal(t, []string{typeTag, typeTimestamp, typeFloat, typeString}, typeNames)
+
+	type row struct {
+		host string
+		time time.Time
+		v    float64
+		s    sql.NullString
+	}
+	var result []row
+	for rows.Next() {
+		var r row
+		require.Nil(t, rows.Scan(&r.host, &r.time, &r.v, &r.s))
+		result = append(result, r)
+	}
+	require.Nil(t, rows.Err())
+	require.Equal(t, []row{
+		{host: "a", time: time.Unix(1, 0).UTC(), v: 0.5, s: sql.NullString{String: "x", Valid: true}},
+		{host: "a", time: time.Unix(2, 0).UTC(), v: 2},
+		{host: "b", time: time.Unix(3, 0).UTC(), v: 1.5, s: sql.NullString{String: "y", Valid: true}},
+	}, result)
+
+	query := (*requests)[0].URL.Query()
+	require.Equal(t, "true", query.Get("chunked"))
+	require.Equal(t, "ns", query.Get("epoch"))
+	require.Equal(t, "db0", query.Get("db"))
+	require.JSONEq(t, `{"host":"a","2":0.1}`, query.Get("params"))
+}
+
+func TestQueryError(t *testing.T) {
+	db, _, _ := testDB(t, map[string]string{
+		`SELECT * FROM "missing"`: `{"results":[{"statement_id":0,"error":"measurement not found"}]}`,
+	})
+	_, err := db.Query(`SELECT * FROM "missing"`)
+	require.ErrorContains(t, err, "measurement not found")
+
+	rows, err := db.Query(`SELECT * FROM "empty"`)
+	require.Nil(t, err)
+	require.False(t, rows.Next())
+	require.Nil(t, rows.Err())
+	require.Nil(t, rows.Close())
+}
+
+func TestExec(t *testing.T) {
+	db, requests, bodies := testDB(t, nil)
+	require.Nil(t, db.Ping())
+
+	result, err := db.Exec(`INSERT cpu,host=a v=1 1`)
+	require.Nil(t, err)
+	affected, err := result.RowsAffected()
+	require.Nil(t, err)
+	require.Equal(t, int64(1), affected)
+	_, err = result.LastInsertId()
+	require.ErrorIs(t, err, ErrLastInsertIdUnsupported)
+	require.Equal(t, "cpu,host=a v=1 1000000000\n", (*bodies)[len(*bodies)-1])
+
+	stmt, err := db.Prepare(`DROP MEASUREMENT "cpu"`)
+	require.Nil(t, err)
+	_, err = stmt.Exec()
+	require.Nil(t, err)
+	require.Nil(t, stmt.Close())
+	last := (*requests)[len(*requests)-1]
+	require.Equal(t, http.MethodPost, last.Method)
+
+	_, err = db.Begin()
+	require.ErrorIs(t, err, ErrTransactionNotSupported)
+}
+
+type testCloseClient struct {
+	opengemini.Client
+	closed int
+}
+
+func (c *testCloseClient) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestConnCloseClient(t *testing.T) {
+	// the connection of Driver.Open owns its client, the connections of connector share the client
+	c, err := (&Driver{}).Open("opengemini://127.0.0.1:8086/db0")
+	require.Nil(t, err)
+	require.True(t, c.(*conn).ownClient)
+	require.Nil(t, c.Close())
+
+	client := &testCloseClient{}
+	require.Nil(t, (&conn{client: client, ownClient: true}).Close())
+	require.Equal(t, 1, client.closed)
+	require.Nil(t, (&conn{client: client}).Close())
+	require.Equal(t, 1, client.closed)
+
+	connector := &connector{dsn: &DSN{}, client: client}
+	connector.once.Do(func() {})
+	first, err := connector.Connect(context.Background())
+	require.Nil(t, err)
+	second, err := connector.Connect(context.Background())
+	require.Nil(t, err)
+	require.Same(t, first.(*conn).client, second.(*conn).client)
+	require.Nil(t, first.Close())
+	require.Nil(t, connector.Close())
+	require.Equal(t, 2, client.closed)
+}
+
+func TestQueryConvertsByColumnType(t *testing.T) {
+	db, _, _ := testDB(t, map[string]string{
+		`SELECT "v", "n" FROM "cpu"`: `{"results":[{"statement_id":0,"series":[{"name":"cpu",` +
+			`"columns":["time","v","n"],"values":[[1,1,2],[2,1.5,3]]}]}]}`,
+		`SHOW FIELD KEYS FROM "cpu"`: `{"results":[{"statement_id":0,"series":[{"name":"cpu",` +
+			`"columns":["fieldKey","fieldType"],"values":[["v","float"],["n","integer"]]}]}]}`,
+		`SELECT MEAN("v") FROM "mem"`: `{"results":[{"statement_id":0,"series":[{"name":"mem",` +
+			`"columns":["time","mean"],"values":[[1,1],[2,1.5]]}]}]}`,
+	})
+
+	for command, expected := range map[string][][]any{
+		`SELECT "v", "n" FROM "cpu"`:  {{1.0, int64(2)}, {1.5, int64(3)}},
+		`SELECT MEAN("v") FROM "mem"`: {{1.0}, {1.5}},
+	} {
+		rows, err := db.Query(command)
+		require.Nil(t, err)
+		var actual [][]any
+		for rows.Next() {
+			var ts time.Time
+			values := make([]any, len(expected[0]))
+			dest := []any{&ts}
+			for i := range values {
+				dest = append(dest, &values[i])
+			}
+			require.Nil(t, rows.Scan(dest...))
+			actual = append(actual, values)
+		}
+		require.Nil(t, rows.Err())
+		require.Nil(t, rows.Close())
+		require.Equal(t, expected, actual, command)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldriver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+func TestParseDSN(t *testing.T) {
+	dsn, err := ParseDSN("opengemini://user:p%40ss@h1:8087,h2,[::1]:8088/db0?rp=rp0&precision=ms&timeout=10s" +
+		"&compress=gzip&content_type=msgpack&chunk_size=100&validate=true&insecure_skip_verify=true")
+	require.Nil(t, err)
+	require.Equal(t, "db0", dsn.Database)
+	require.Equal(t, "rp0", dsn.RetentionPolicy)
+	require.Equal(t, opengemini.PrecisionMillisecond, dsn.Precision)
+	require.Equal(t, 100, dsn.ChunkSize)
+	require.True(t, dsn.Validate)
+	require.Equal(t, []opengemini.Address{{Host: "h1", Port: 8087}, {Host: "h2", Port: 8086}, {Host: "::1", Port: 8088}},
+		dsn.Config.Addresses)
+	require.Equal(t, &opengemini.AuthConfig{AuthType: opengemini.AuthTypePassword, Username: "user", Password: "p@ss"},
+		dsn.Config.AuthConfig)
+	require.Equal(t, 10*time.Second, dsn.Config.Timeout)
+	require.Equal(t, opengemini.CompressMethodGzip, dsn.Config.CompressMethod)
+	require.Equal(t, opengemini.ContentTypeMsgPack, dsn.Config.ContentType)
+	require.True(t, dsn.Config.TlsConfig.InsecureSkipVerify)
+
+	dsn, err = ParseDSN("opengemini://[::1]:8088")
+	require.Nil(t, err)
+	require.Equal(t, []opengemini.Address{{Host: "::1", Port: 8088}}, dsn.Config.Addresses)
+	require.Equal(t, "", dsn.Database)
+	require.Nil(t, dsn.Config.AuthConfig)
+	require.Nil(t, dsn.Config.TlsConfig)
+	require.Equal(t, defaultChunkSize, dsn.ChunkSize)
+
+	for _, invalid := range []string{
+		"mysql://localhost/db0",
+		"opengemini:///db0",
+		"opengemini://localhost:port/db0",
+		"opengemini://localhost/db0?precision=year",
+		"opengemini://localhost/db0?chunk_size=0",
+		"opengemini://localhost/db0?compress=lz4",
+		"opengemini://localhost/db0?unknown=1",
+	} {
+		_, err = ParseDSN(invalid)
+		require.NotNil(t, err, invalid)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+const timeColumn = "time"
+
+// the database type names of ColumnTypes
+const (
+	typeTimestamp = "TIMESTAMP"
+	typeTag       = "TAG"
+	typeFloat     = "FLOAT"
+	typeInteger   = "INTEGER"
+	typeString    = "STRING"
+	typeBoolean   = "BOOLEAN"
+)
+
+// rows stream the series of chunked query result, the columns are the sorted tag keys followed by the columns of the
+// first series, the columns of the other series are matched by name
+type rows struct {
+	conn *conn
+	next func() (*opengemini.QueryResult, error, bool)
+	stop func()
+
+	columns []string
+	tagKeys []string
+	first   *opengemini.Series
+
+	queue   []*opengemini.Series
+	series  *opengemini.Series
+	indexes []int
+	row     int
+
+	types []columnType
+}
+
+type columnType struct {
+	name     string
+	scanType reflect.Type
+}
+
+var (
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+)
+
+func newRows(ctx context.Context, c *conn, query opengemini.Query) (*rows, error) {
+	next, stop := iter.Pull2(c.client.QueryChunked(ctx, query, c.dsn.ChunkSize))
+	r := &rows{conn: c, next: next, stop: stop}
+	// read until the first series to know the columns
+	for len(r.queue) == 0 {
+		ok, err := r.pull()
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		if !ok {
+			return r, nil
+		}
+	}
+
+	r.first = r.queue[0]
+	for key := range r.first.Tags {
+		r.tagKeys = append(r.tagKeys, key)
+	}
+	sort.Strings(r.tagKeys)
+	r.columns = append(append(r.columns, r.tagKeys...), r.first.Columns...)
+	return r, nil
+}
+
+// pull read the next chunk into queue
+func (r *rows) pull() (bool, error) {
+	result, err, ok := r.next()
+	if !ok {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := queryResultError(result); err != nil {
+		return false, err
+	}
+	for _, seriesResult := range result.Results {
+		r.queue = append(r.queue, seriesResult.Series...)
+	}
+	return true, nil
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	r.stop()
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	for r.series == nil || r.row >= len(r.series.Values) {
+		if len(r.queue) == 0 {
+			ok, err := r.pull()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return io.EOF
+			}
+			continue
+		}
+		r.series, r.queue = r.queue[0], r.queue[1:]
+		r.row = 0
+		r.indexes = r.columnIndexes(r.series)
+	}
+
+	values := r.series.Values[r.row]
+	r.row++
+	for i, key := range r.tagKeys {
+		if value, ok := r.series.Tags[key]; ok {
+			dest[i] = value
+		} else {
+			dest[i] = nil
+		}
+	}
+	types := r.columnTypes()
+	for i, index := range r.indexes {
+		column := len(r.tagKeys) + i
+		if index < 0 || index >= len(values) {
+			dest[column] = nil
+			continue
+		}
+		value, err := convertValue(types[column].name, values[index])
+		if err != nil {
+			return fmt.Errorf("column %s: %w", r.columns[column], err)
+		}
+		dest[column] = value
+	}
+	return nil
+}
+
+// columnIndexes map the columns after tags to the indexes of series columns, -1 means absent
+func (r *rows) columnIndexes(series *opengemini.Series) []int {
+	columns := r.columns[len(r.tagKeys):]
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = -1
+		if i < len(series.Columns) && series.Columns[i] == column {
+			indexes[i] = i
+			continue
+		}
+		for j, name := range series.Columns {
+			if name == column {
+				indexes[i] = j
+				break
+			}
+		}
+	}
+	return indexes
+}
+
+// convertValue convert the value by the type of column, so that an integral value of a FLOAT column is float64 as the
+// other values of the column
+func convertValue(typeName string, value any) (driver.Value, error) {
+	switch typeName {
+	case typeTimestamp:
+		switch v := value.(type) {
+		case json.Number:
+			ns, err := v.Int64()
+			if err != nil {
+				return nil, err
+			}
+			return time.Unix(0, ns).UTC(), nil
+		case float64:
+			return time.Unix(0, int64(v)).UTC(), nil
+		case string:
+			return time.Parse(time.RFC3339Nano, v)
+		}
+	case typeFloat:
+		if v, ok := value.(json.Number); ok {
+			return v.Float64()
+		}
+	}
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case nil, string, bool, float64, int64:
+		return v, nil
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		return string(data), err
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.columnTypes()[index].name
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	return r.columnTypes()[index].scanType
+}
+
+// ColumnTypeNullable report that only time column is not nullable, a tag or field may be absent in a row
+func (r *rows) ColumnTypeNullable(index int) (bool, bool) {
+	return r.columns[index] != timeColumn, true
+}
+
+// columnTypes resolve the types of field columns by `SHOW FIELD KEYS` of the measurement, the columns which are not
+// fields such as the results of functions are inferred from the values of first series
+func (r *rows) columnTypes() []columnType {
+	if r.types != nil {
+		return r.types
+	}
+	var fieldTypes map[string]string
+	if r.first != nil && r.first.Name != "" && r.conn.dsn.Database != "" {
+		schemas, err := r.conn.client.ShowFieldKeys(r.conn.dsn.Database, r.first.Name)
+		if err == nil {
+			fieldTypes = schemas[r.first.Name]
+		}
+	}
+
+	r.types = make([]columnType, len(r.columns))
+	for i, column := range r.columns {
+		switch {
+		case i < len(r.tagKeys):
+			r.types[i] = columnType{name: typeTag, scanType: reflect.TypeOf(sql.NullString{})}
+		case column == timeColumn:
+			r.types[i] = columnType{name: typeTimestamp, scanType: reflect.TypeOf(time.Time{})}
+		case fieldTypes[column] != "":
+			r.types[i] = fieldColumnType(fieldTypes[column])
+		default:
+			r.types[i] = r.inferColumnType(i - len(r.tagKeys))
+		}
+	}
+	return r.types
+}
+
+func fieldColumnType(fieldType string) columnType {
+	switch strings.ToLower(fieldType) {
+	case "float":
+		return columnType{name: typeFloat, scanType: reflect.TypeOf(sql.NullFloat64{})}
+	case "integer":
+		return columnType{name: typeInteger, scanType: reflect.TypeOf(sql.NullInt64{})}
+	case "boolean":
+		return columnType{name: typeBoolean, scanType: reflect.TypeOf(sql.NullBool{})}
+	default:
+		return columnType{name: typeString, scanType: reflect.TypeOf(sql.NullString{})}
+	}
+}
+
+// inferColumnType infer the type from the values of first series, the numbers are INTEGER only if all of them are
+// integral, such as the result of count, otherwise FLOAT
+func (r *rows) inferColumnType(index int) columnType {
+	inferred := ""
+	for _, values := range r.first.Values {
+		if index >= len(values) || values[index] == nil {
+			continue
+		}
+		switch v := values[index].(type) {
+		case json.Number:
+			if _, err := v.Int64(); err != nil {
+				return fieldColumnType("float")
+			}
+			inferred = "integer"
+		case float64:
+			return fieldColumnType("float")
+		case bool:
+			return fieldColumnType("boolean")
+		default:
+			return fieldColumnType("string")
+		}
+	}
+	if inferred == "" {
+		return columnType{scanType: reflect.TypeOf(new(any)).Elem()}
+	}
+	return fieldColumnType(inferred)
+}